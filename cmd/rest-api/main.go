@@ -1,23 +1,42 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/jmoiron/sqlx"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"log/slog"
 	"net/http"
+	"os"
 	_ "restAPI/docs"
 	"restAPI/internal/config"
 	"restAPI/internal/db"
+	"restAPI/internal/http-server/handlers/account"
 	"restAPI/internal/http-server/handlers/admin"
+	"restAPI/internal/http-server/handlers/agenda"
+	"restAPI/internal/http-server/handlers/attachment"
 	"restAPI/internal/http-server/handlers/auth"
+	"restAPI/internal/http-server/handlers/board"
+	"restAPI/internal/http-server/handlers/calendar"
+	"restAPI/internal/http-server/handlers/comment"
 	"restAPI/internal/http-server/handlers/date"
+	"restAPI/internal/http-server/handlers/export"
+	"restAPI/internal/http-server/handlers/importer"
+	"restAPI/internal/http-server/handlers/item"
+	"restAPI/internal/http-server/handlers/project"
+	"restAPI/internal/http-server/handlers/report"
+	"restAPI/internal/http-server/handlers/stats"
 	"restAPI/internal/http-server/handlers/tag"
 	"restAPI/internal/http-server/handlers/task"
+	"restAPI/internal/http-server/handlers/template"
+	"restAPI/internal/http-server/handlers/timer"
+	"restAPI/internal/http-server/handlers/undo"
 	jwtAuth "restAPI/internal/http-server/middleware/JWTAuth"
 	"restAPI/internal/repositories"
 	"restAPI/internal/service"
+	"restAPI/pkg/lib/blobstore"
 	"restAPI/pkg/logger"
 	"time"
 )
@@ -49,7 +68,24 @@ func main() {
 
 	rep := repositories.New(conn, log)
 
-	services := service.New(rep)
+	store, err := newBlobStore(&cfg.Attachments)
+	if err != nil {
+		log.Error("cannot create blob store", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	services := service.New(rep, store, cfg.Attachments.Quota, cfg.Accounts.DeletionGrace)
+
+	// import jobs and account exports run in the server, the ones a stopped
+	// server left running never end
+	if err := services.FailRunningImportJobs(); err != nil {
+		log.Error("cannot fail running import jobs", slog.String("error", err.Error()))
+	}
+	if err := services.FailRunningAccountExports(); err != nil {
+		log.Error("cannot fail running account exports", slog.String("error", err.Error()))
+	}
+
+	go purgeAccounts(log, services, cfg.Accounts.PurgeInterval)
 
 	router := chi.NewRouter()
 
@@ -74,9 +110,17 @@ func main() {
 			}))
 			router.Delete("/", admin.DeleteAll(log, services))
 			router.Get("/", admin.GetAll(log, services))
+			router.Get("/export", admin.Export(log, services))
 		})
 	})
 
+	// calendar apps poll the feed with its secret token instead of a JWT,
+	// URLFormat strips the .ics extension before routing
+	router.Get("/feeds/{token}/tasks", export.Feed(log, services))
+	// the archives are downloaded through short-lived signed links, see
+	// account.GetExport
+	router.Get("/account-exports/{exportId}", account.DownloadExport(log, services))
+
 	router.Group(func(router chi.Router) {
 		router.Use(jwtAuth.New(log, services))
 
@@ -87,6 +131,74 @@ func main() {
 			router.Delete("/{taskId}", task.Delete(log, services))
 			router.Delete("/", task.DeleteAll(log, services))
 			router.Put("/{taskId}", task.Update(log, services))
+			router.Put("/{taskId}/project", task.SetProject(log, services))
+			router.Post("/{taskId}/move", task.Move(log, services))
+			router.Post("/{taskId}/duplicate", task.Duplicate(log, services))
+			router.Post("/{taskId}/snooze", task.Snooze(log, services, time.Now))
+			router.Delete("/{taskId}/snooze", task.Unsnooze(log, services))
+			router.Post("/bulk/move", task.BulkMove(log, services))
+			router.Post("/quick", task.Quick(log, services, time.Now))
+			router.Route("/{taskId}/items", func(router chi.Router) {
+				router.Post("/", item.Create(log, services))
+				router.Get("/", item.GetAll(log, services))
+				router.Put("/{itemId}", item.Update(log, services))
+				router.Delete("/{itemId}", item.Delete(log, services))
+				router.Post("/{itemId}/move", item.Move(log, services))
+			})
+			router.Route("/{taskId}/comments", func(router chi.Router) {
+				router.Post("/", comment.Create(log, services))
+				router.Get("/", comment.GetAll(log, services))
+				router.Put("/{commentId}", comment.Update(log, services))
+				router.Delete("/{commentId}", comment.Delete(log, services))
+			})
+			router.Route("/{taskId}/attachments", func(router chi.Router) {
+				router.Post("/", attachment.Upload(log, services, cfg.Attachments.MaxSize))
+				router.Get("/", attachment.GetAll(log, services))
+				router.Get("/{attachmentId}", attachment.Download(log, services))
+				router.Delete("/{attachmentId}", attachment.Delete(log, services))
+			})
+			router.Post("/{taskId}/timer/start", timer.Start(log, services))
+			router.Post("/{taskId}/timer/stop", timer.Stop(log, services))
+			router.Route("/{taskId}/time", func(router chi.Router) {
+				router.Post("/", timer.Create(log, services))
+				router.Get("/", timer.GetAll(log, services))
+				router.Delete("/{entryId}", timer.Delete(log, services))
+			})
+		})
+		router.Route("/projects", func(router chi.Router) {
+			router.Post("/", project.Create(log, services))
+			router.Get("/", project.GetAll(log, services))
+			router.Get("/{projectId}", project.Get(log, services))
+			router.Put("/{projectId}", project.Update(log, services))
+			router.Delete("/{projectId}", project.Delete(log, services))
+			router.Get("/{projectId}/tasks", project.GetTasks(log, services))
+			// URLFormat strips the .svg extension before routing
+			router.Get("/{projectId}/burndown", project.Burndown(log, services))
+		})
+		router.Route("/templates", func(router chi.Router) {
+			router.Post("/", template.Create(log, services))
+			router.Get("/", template.GetAll(log, services))
+			router.Get("/{templateId}", template.Get(log, services))
+			router.Put("/{templateId}", template.Update(log, services))
+			router.Delete("/{templateId}", template.Delete(log, services))
+			router.Post("/{templateId}/instantiate", template.Instantiate(log, services))
+		})
+		router.Route("/boards", func(router chi.Router) {
+			router.Post("/", board.Create(log, services))
+			router.Get("/", board.GetAll(log, services))
+			router.Get("/{boardId}", board.Get(log, services))
+			router.Delete("/{boardId}", board.Delete(log, services))
+			router.Post("/{boardId}/columns", board.CreateColumn(log, services))
+			router.Put("/{boardId}/cards/{taskId}", board.MoveCard(log, services))
+			router.Delete("/{boardId}/cards/{taskId}", board.RemoveCard(log, services))
+		})
+		router.Route("/reports", func(router chi.Router) {
+			router.Get("/time", report.Time(log, services))
+			router.Get("/estimates", report.Estimate(log, services))
+		})
+		router.Route("/stats", func(router chi.Router) {
+			router.Get("/me", stats.Me(log, services))
+			router.Get("/me/heatmap", stats.Heatmap(log, services, time.Now))
 		})
 		router.Route("/tag", func(router chi.Router) {
 			router.Get("/{tag}", tag.Get(log, services))
@@ -95,6 +207,36 @@ func main() {
 			router.Get("/{year}/{month}/{day}", date.Get(log, services))
 
 		})
+		router.Route("/calendar", func(router chi.Router) {
+			router.Get("/{year}/{month}", calendar.Get(log, services))
+		})
+		router.Route("/export", func(router chi.Router) {
+			// the format comes from the extension, see Formats
+			router.Get("/tasks", export.Formats(map[string]http.HandlerFunc{
+				"ics": export.ICS(log, services),
+				"csv": export.CSV(log, services),
+				"txt": export.TodoTxt(log, services),
+			}))
+			router.Post("/feed", export.RotateFeed(log, services))
+			router.Delete("/feed", export.RevokeFeed(log, services))
+		})
+		router.Route("/import", func(router chi.Router) {
+			router.Post("/ics", importer.ICS(log, services))
+			router.Post("/csv", importer.CSV(log, services))
+			router.Post("/todotxt", importer.TodoTxt(log, services))
+			router.Post("/todotxt/sync", importer.SyncTodoTxt(log, services))
+		})
+		router.Route("/imports", func(router chi.Router) {
+			router.Post("/{source}", importer.StartJob(log, services))
+			router.Get("/{importId}", importer.GetJob(log, services))
+		})
+		router.Route("/me", func(router chi.Router) {
+			router.Delete("/", account.Delete(log, services))
+			router.Post("/export", account.StartExport(log, services))
+			router.Get("/exports/{exportId}", account.GetExport(log, services))
+		})
+		router.Post("/undo", undo.Undo(log, services))
+		router.Get("/agenda", agenda.Get(log, services))
 	})
 
 	log.Info("starting server", slog.String("address", cfg.Address))
@@ -113,6 +255,40 @@ func main() {
 	log.Error("Server stopped")
 }
 
+func newBlobStore(cfg *config.Attachments) (blobstore.BlobStore, error) {
+	switch cfg.Store {
+	case "s3":
+		return blobstore.NewS3(blobstore.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+		}, nil), nil
+	case "local":
+		return blobstore.NewLocal(cfg.Dir)
+	default:
+		return nil, fmt.Errorf("unknown attachment store %q", cfg.Store)
+	}
+}
+
+// purgeAccounts deletes the accounts whose grace period is over, at start and
+// then every interval.
+func purgeAccounts(log *slog.Logger, services *service.Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		purged, err := services.PurgeAccounts(context.Background())
+		if err != nil {
+			log.Error("cannot purge accounts", slog.String("error", err.Error()))
+		}
+		if purged != 0 {
+			log.Info("accounts purged", slog.Int("count", purged))
+		}
+		<-ticker.C
+	}
+}
+
 func closeConn(conn *sqlx.DB) {
 	err := conn.Close()
 	if err != nil {
@@ -9,10 +9,12 @@ import (
 )
 
 type Config struct {
-	Env        string `yaml:"env"`
-	Admin      `yaml:"admin"`
-	HTTPServer `yaml:"http_server"`
-	DB         `yaml:"db"`
+	Env         string `yaml:"env"`
+	Admin       `yaml:"admin"`
+	HTTPServer  `yaml:"http_server"`
+	DB          `yaml:"db"`
+	Attachments `yaml:"attachments"`
+	Accounts    `yaml:"accounts"`
 }
 
 type Admin struct {
@@ -28,6 +30,32 @@ type DB struct {
 	Password string
 }
 
+// Attachments configures uploads. Store is "local" for files under Dir or
+// "s3" for a bucket of an S3 compatible service.
+type Attachments struct {
+	MaxSize int64  `yaml:"max_size"`
+	Quota   int64  `yaml:"quota"`
+	Store   string `yaml:"store"`
+	Dir     string `yaml:"dir"`
+	S3      `yaml:"s3"`
+}
+
+type S3 struct {
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	AccessKey string `yaml:"access_key"`
+	SecretKey string
+}
+
+// Accounts configures the deletion of the accounts. A deleted account is kept
+// for DeletionGrace, signing in cancels the deletion, and purged by a job
+// that runs every PurgeInterval.
+type Accounts struct {
+	DeletionGrace time.Duration `yaml:"deletion_grace"`
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
 type HTTPServer struct {
 	Address     string        `yaml:"address"`
 	Timeout     time.Duration `yaml:"timeout"`
@@ -49,5 +77,6 @@ func New() *Config {
 	}
 	cfg.Admin.Password = os.Getenv("ADMIN_PASSWORD")
 	cfg.DB.Password = os.Getenv("DB_PASSWORD")
+	cfg.Attachments.S3.SecretKey = os.Getenv("S3_SECRET_KEY")
 	return &cfg
 }
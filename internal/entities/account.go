@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// AccountExport is an account_exports row, the blob key is set once the
+// archive is stored.
+type AccountExport struct {
+	ID         int64      `db:"id"`
+	OwnerID    int64      `db:"owner_id"`
+	Status     string     `db:"status"`
+	Key        *string    `db:"blob_key"`
+	Size       int64      `db:"size"`
+	Error      *string    `db:"error"`
+	CreatedAt  time.Time  `db:"created_at"`
+	FinishedAt *time.Time `db:"finished_at"`
+}
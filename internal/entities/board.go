@@ -0,0 +1,7 @@
+package entities
+
+type CardWithTag struct {
+	ColumnID int64  `db:"column_id"`
+	CardRank string `db:"card_rank"`
+	TaskWithTag
+}
@@ -0,0 +1,10 @@
+package entities
+
+import "github.com/lib/pq"
+
+type CalendarDay struct {
+	Date    string         `db:"day"`
+	Tasks   int            `db:"tasks"`
+	Done    int            `db:"done"`
+	TopTags pq.StringArray `db:"top_tags"`
+}
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// ImportJob is an import_jobs row, the report is kept as a JSON document.
+type ImportJob struct {
+	ID         int64      `db:"id"`
+	OwnerID    int64      `db:"owner_id"`
+	Source     string     `db:"source"`
+	Status     string     `db:"status"`
+	Done       int        `db:"done"`
+	Total      int        `db:"total"`
+	Report     []byte     `db:"report"`
+	Error      *string    `db:"error"`
+	CreatedAt  time.Time  `db:"created_at"`
+	FinishedAt *time.Time `db:"finished_at"`
+}
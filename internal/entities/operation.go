@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"time"
+)
+
+// TaskSnapshot is the full state of a task row stored in an operation
+// descriptor, enough to put the row back exactly as it was.
+type TaskSnapshot struct {
+	ID          int64             `json:"id" db:"id"`
+	Text        string            `json:"text" db:"task"`
+	Tags        []string          `json:"tags" db:"-"`
+	Date        time.Time         `json:"date" db:"date"`
+	ProjectID   *int64            `json:"project_id" db:"project_id"`
+	Status      string            `json:"status" db:"status"`
+	CompletedAt *time.Time        `json:"completed_at" db:"completed_at"`
+	SortKey     *string           `json:"sort_key,omitempty" db:"sort_key"`
+	Estimate    *int64            `json:"estimate,omitempty" db:"estimate"`
+	ParentID    *int64            `json:"parent_id,omitempty" db:"parent_id"`
+	Due         *time.Time        `json:"due,omitempty" db:"due"`
+	Priority    *string           `json:"priority,omitempty" db:"priority"`
+	Recurrence  *string           `json:"recurrence,omitempty" db:"recurrence"`
+	HiddenUntil *time.Time        `json:"hidden_until,omitempty" db:"hidden_until"`
+	CreatedAt   *time.Time        `json:"created_at,omitempty" db:"created_at"`
+	ICalUID     *string           `json:"ical_uid,omitempty" db:"ical_uid"`
+	Items       []ItemSnapshot    `json:"items,omitempty" db:"-"`
+	Comments    []CommentSnapshot `json:"comments,omitempty" db:"-"`
+	TimeEntries []TimeSnapshot    `json:"time_entries,omitempty" db:"-"`
+}
+
+type ItemSnapshot struct {
+	ID   int64  `json:"id" db:"id"`
+	Text string `json:"text" db:"text"`
+	Done bool   `json:"done" db:"done"`
+	Rank string `json:"rank" db:"rank"`
+}
+
+type OperationPayload struct {
+	Before []TaskSnapshot `json:"before,omitempty"`
+	After  []TaskSnapshot `json:"after,omitempty"`
+}
+
+type Operation struct {
+	ID        int64     `db:"id"`
+	OwnerID   int64     `db:"owner_id"`
+	Kind      string    `db:"kind"`
+	Payload   []byte    `db:"payload"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type CommentSnapshot struct {
+	ID        int64      `json:"id" db:"id"`
+	AuthorID  int64      `json:"author_id" db:"author_id"`
+	ParentID  *int64     `json:"parent_id" db:"parent_id"`
+	Text      string     `json:"text" db:"text"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	EditedAt  *time.Time `json:"edited_at" db:"edited_at"`
+}
+
+// TimeSnapshot is a time entry of a snapshot, a running one is stopped at the
+// time of the snapshot.
+type TimeSnapshot struct {
+	ID        int64     `json:"id" db:"id"`
+	StartedAt time.Time `json:"started_at" db:"started_at"`
+	StoppedAt time.Time `json:"stopped_at" db:"stopped_at"`
+	Running   bool      `json:"running,omitempty" db:"running"`
+}
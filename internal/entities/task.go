@@ -2,15 +2,47 @@ package entities
 
 import (
 	"fmt"
+	"github.com/lib/pq"
 	"time"
 )
 
 type TaskWithTag struct {
-	ID      int64     `db:"id"`
-	Task    string    `db:"task"`
-	Date    time.Time `db:"date"`
-	Tag     *string   `db:"tag,omitempty"`
-	OwnerID int64     `db:"owner_id"`
+	ID          int64      `db:"id"`
+	Task        string     `db:"task"`
+	Date        time.Time  `db:"date"`
+	Tag         *string    `db:"tag,omitempty"`
+	OwnerID     int64      `db:"owner_id"`
+	ProjectID   *int64     `db:"project_id"`
+	Status      string     `db:"status"`
+	CompletedAt *time.Time `db:"completed_at"`
+	Estimate    *int64     `db:"estimate"`
+	ParentID    *int64     `db:"parent_id"`
+	Due         *time.Time `db:"due"`
+	Priority    *string    `db:"priority"`
+	Recurrence  *string    `db:"recurrence"`
+	HiddenUntil *time.Time `db:"hidden_until"`
+	ItemsDone   int        `db:"items_done"`
+	ItemsTotal  int        `db:"items_total"`
+	Comments    int        `db:"comment_count"`
+}
+
+// TaskRow is a task of the admin export, its tags come in one column.
+type TaskRow struct {
+	ID          int64          `db:"id"`
+	OwnerID     int64          `db:"owner_id"`
+	Task        string         `db:"task"`
+	Tags        pq.StringArray `db:"tags"`
+	Date        time.Time      `db:"date"`
+	ProjectID   *int64         `db:"project_id"`
+	Status      string         `db:"status"`
+	CompletedAt *time.Time     `db:"completed_at"`
+	Estimate    *int64         `db:"estimate"`
+	ParentID    *int64         `db:"parent_id"`
+	Due         *time.Time     `db:"due"`
+	Priority    *string        `db:"priority"`
+	Recurrence  *string        `db:"recurrence"`
+	HiddenUntil *time.Time     `db:"hidden_until"`
+	CreatedAt   time.Time      `db:"created_at"`
 }
 
 func (task *TaskWithTag) String() string {
@@ -0,0 +1,9 @@
+package entities
+
+// Template is a templates row, the tasks are kept as a JSON document.
+type Template struct {
+	ID      int64  `db:"id"`
+	Name    string `db:"name"`
+	OwnerID int64  `db:"owner_id"`
+	Tasks   []byte `db:"tasks"`
+}
@@ -0,0 +1,105 @@
+package account
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+)
+
+type deleteRequest struct {
+	Password string `json:"password"`
+}
+
+type deleter interface {
+	DeleteAccount(userID int64, password string) (model.AccountDeletion, error)
+}
+
+// Delete account
+// @Summary Delete
+// @Security ApiKeyPath
+// @Tags Account
+// @Description Delete the account of the user with all its data once the grace period is over, 30 days by default.
+// @Description Signing in before cancels the deletion. Asking again keeps the first date.
+// @ID deleteAccount
+// @Accept json
+// @Produce json
+// @Param input body deleteRequest true "password of the user"
+// @Success 202 {object} model.AccountDeletion
+// @Failure 400,401,403,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /me [delete]
+func Delete(log *slog.Logger, deleter deleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req deleteRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		if req.Password == "" {
+			log.Error("wrong json fields")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "wrong json fields",
+			})
+			return
+		}
+
+		deletion, err := deleter.DeleteAccount(userID, req.Password)
+		if errors.Is(err, repositories.ErrWrongPassword) {
+			log.Error("wrong password", slog.Int64("userID", userID))
+			w.WriteHeader(http.StatusForbidden)
+			render.JSON(w, r, response.Message{
+				Msg: "wrong password",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrNoSuchUser) {
+			log.Error("user is not exist", slog.Int64("userID", userID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there is no such user",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't delete account", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't delete account",
+			})
+			return
+		}
+		log.Info("account deletion scheduled", slog.Int64("userID", userID),
+			slog.Time("deleteAfter", deletion.DeleteAfter))
+
+		w.WriteHeader(http.StatusAccepted)
+		render.JSON(w, r, deletion)
+	}
+}
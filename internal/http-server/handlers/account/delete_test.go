@@ -0,0 +1,121 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_Delete(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockAccount, userID int64)
+
+	var tests = []struct {
+		name                 string
+		inputBody            string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "correct working",
+			inputBody: `{"password":"testPassword"}`,
+			userID:    1,
+			mockBehavior: func(s *mock_service.MockAccount, userID int64) {
+				s.EXPECT().DeleteAccount(userID, "testPassword").Return(model.AccountDeletion{
+					DeleteAfter: time.Date(2024, time.April, 7, 18, 0, 0, 0, time.UTC),
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusAccepted,
+			expectedResponseBody: `{"delete_after":"2024-04-07T18:00:00Z"}`,
+		}, {
+			name:                 "incorrect userID",
+			inputBody:            `{"password":"testPassword"}`,
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockAccount, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect request",
+			inputBody:            `{"password":"testPassword}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAccount, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"failed to decode request"}`,
+		}, {
+			name:                 "no password",
+			inputBody:            `{}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAccount, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"wrong json fields"}`,
+		}, {
+			name:      "incorrect DeleteAccount return: wrong password",
+			inputBody: `{"password":"testPassword"}`,
+			userID:    1,
+			mockBehavior: func(s *mock_service.MockAccount, userID int64) {
+				s.EXPECT().DeleteAccount(userID, "testPassword").Return(model.AccountDeletion{}, repositories.ErrWrongPassword)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"wrong password"}`,
+		}, {
+			name:      "incorrect DeleteAccount return: no user",
+			inputBody: `{"password":"testPassword"}`,
+			userID:    1,
+			mockBehavior: func(s *mock_service.MockAccount, userID int64) {
+				s.EXPECT().DeleteAccount(userID, "testPassword").Return(model.AccountDeletion{}, repositories.ErrNoSuchUser)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there is no such user"}`,
+		}, {
+			name:      "incorrect DeleteAccount return: internal server error",
+			inputBody: `{"password":"testPassword"}`,
+			userID:    1,
+			mockBehavior: func(s *mock_service.MockAccount, userID int64) {
+				s.EXPECT().DeleteAccount(userID, "testPassword").Return(model.AccountDeletion{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't delete account"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			deleter := mock_service.NewMockAccount(ctrl)
+			test.mockBehavior(deleter, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Delete("/me", Delete(logger, deleter))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, "/me", bytes.NewBufferString(test.inputBody))
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/internal/service"
+	"restAPI/pkg/lib/blobstore"
+	"strconv"
+)
+
+type exportStarter interface {
+	StartAccountExport(userID int64) (model.AccountExport, error)
+}
+
+type exportGetter interface {
+	GetAccountExport(exportID, userID int64) (model.AccountExport, error)
+}
+
+type exportOpener interface {
+	OpenAccountExport(ctx context.Context, exportID, userID, expires int64, signature string) (model.AccountExport, io.ReadSeekCloser, error)
+}
+
+// StartExport account export
+// @Summary StartExport
+// @Security ApiKeyPath
+// @Tags Account
+// @Description Build a ZIP archive of all the data of the user in the background: the profile, the projects,
+// @Description the tasks with their tags, checklists, comments, time entries and attached files, as JSON and as
+// @Description CSV, and the undo history. Follow the export at the Location of the answer, once it is done it
+// @Description gives a download link. A user runs one export at a time, the archive is kept until the next one.
+// @ID startAccountExport
+// @Produce json
+// @Success 202 {object} model.AccountExport
+// @Failure 401,409 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /me/export [post]
+func StartExport(log *slog.Logger, starter exportStarter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		export, err := starter.StartAccountExport(userID)
+		if errors.Is(err, repositories.ErrExportRunning) {
+			log.Error("another export is running", slog.Int64("userID", userID))
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, response.Message{
+				Msg: "another export is already running",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't start export", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't start export",
+			})
+			return
+		}
+		log.Info("account export started", slog.Int64("exportID", export.ID))
+
+		w.Header().Set("Location", fmt.Sprintf("/me/exports/%d", export.ID))
+		w.WriteHeader(http.StatusAccepted)
+		render.JSON(w, r, export)
+	}
+}
+
+// GetExport account export
+// @Summary GetExport
+// @Security ApiKeyPath
+// @Tags Account
+// @Description Get an account export. A done one comes with a download link that works for 15 minutes without
+// @Description the Authorization header, get the export again for a new one.
+// @ID getAccountExport
+// @Produce json
+// @Param export_id path int true "export ID"
+// @Success 200 {object} model.AccountExport
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /me/exports/{exportId} [get]
+func GetExport(log *slog.Logger, getter exportGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		exportID, err := strconv.Atoi(chi.URLParam(r, "exportId"))
+		if err != nil {
+			log.Error("incorrect export id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect export id record",
+			})
+			return
+		}
+
+		export, err := getter.GetAccountExport(int64(exportID), userID)
+		if errors.Is(err, repositories.ErrNoAccountExport) {
+			log.Error("there is no export", slog.Int64("userID", userID), slog.Int("exportID", exportID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no export with this exportID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't get export", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't get export",
+			})
+			return
+		}
+		log.Info("account export got by id", slog.Int64("exportID", export.ID), slog.String("status", export.Status))
+
+		render.JSON(w, r, export)
+	}
+}
+
+// DownloadExport account archive
+// @Summary DownloadExport
+// @Tags Account
+// @Description Download the archive of an account export through the signed link given by GetExport
+// @ID downloadAccountExport
+// @Param export_id path int true "export ID"
+// @Param user query int true "user ID"
+// @Param expires query int true "unix time the link expires at"
+// @Param signature query string true "signature of the link"
+// @Produce application/zip
+// @Success 200 {file} file
+// @Failure 400,403,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /account-exports/{exportId} [get]
+func DownloadExport(log *slog.Logger, opener exportOpener) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		exportID, err := strconv.Atoi(chi.URLParam(r, "exportId"))
+		if err != nil {
+			log.Error("incorrect export id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect export id record",
+			})
+			return
+		}
+
+		query := r.URL.Query()
+		userID, userErr := strconv.ParseInt(query.Get("user"), 10, 64)
+		expires, expiresErr := strconv.ParseInt(query.Get("expires"), 10, 64)
+		if err = errors.Join(userErr, expiresErr); err != nil {
+			log.Error("incorrect download link", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusForbidden)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect or expired download link",
+			})
+			return
+		}
+
+		export, content, err := opener.OpenAccountExport(r.Context(), int64(exportID), userID, expires, query.Get("signature"))
+		if errors.Is(err, service.ErrExportLink) {
+			log.Error("incorrect download link", slog.Int("exportID", exportID))
+			w.WriteHeader(http.StatusForbidden)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect or expired download link",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrNoAccountExport) || errors.Is(err, blobstore.ErrNotFound) {
+			log.Error("there is no export", slog.Int("exportID", exportID), slog.Any("error", err))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no export with this exportID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't open export", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't open export",
+			})
+			return
+		}
+		defer content.Close()
+
+		name := fmt.Sprintf("account-export-%d.zip", export.ID)
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+			"filename": name,
+		}))
+		// the link gives the data of the account to whoever has it
+		w.Header().Set("Cache-Control", "no-store")
+		log.Info("account export sent", slog.Int("exportID", exportID))
+		http.ServeContent(w, r, name, export.CreatedAt, content)
+	}
+}
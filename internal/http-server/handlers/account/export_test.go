@@ -0,0 +1,305 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/internal/service"
+	mock_service "restAPI/internal/service/mocks"
+	"restAPI/pkg/lib/blobstore"
+	"strings"
+	"testing"
+	"time"
+)
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestHandler_StartExport(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockAccount, userID int64)
+
+	var tests = []struct {
+		name                 string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedLocation     string
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAccount, userID int64) {
+				s.EXPECT().StartAccountExport(userID).Return(model.AccountExport{
+					ID:        3,
+					Status:    model.AccountExportRunning,
+					CreatedAt: time.Date(2024, time.March, 8, 18, 0, 0, 0, time.UTC),
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusAccepted,
+			expectedLocation:     "/me/exports/3",
+			expectedResponseBody: `{"id":3,"status":"running","created_at":"2024-03-08T18:00:00Z"}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockAccount, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:   "incorrect StartAccountExport return: another export is running",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAccount, userID int64) {
+				s.EXPECT().StartAccountExport(userID).Return(model.AccountExport{}, repositories.ErrExportRunning)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"another export is already running"}`,
+		}, {
+			name:   "incorrect StartAccountExport return: internal server error",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAccount, userID int64) {
+				s.EXPECT().StartAccountExport(userID).Return(model.AccountExport{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't start export"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			starter := mock_service.NewMockAccount(ctrl)
+			test.mockBehavior(starter, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/me/export", StartExport(logger, starter))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/me/export", nil)
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedLocation, w.Header().Get("Location"))
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_GetExport(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockAccount, exportID, userID int64)
+
+	created := time.Date(2024, time.March, 8, 18, 0, 0, 0, time.UTC)
+	finished := created.Add(time.Minute)
+	expires := created.Add(16 * time.Minute)
+
+	var tests = []struct {
+		name                 string
+		inputID              string
+		exportID             int64
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:     "correct working",
+			inputID:  "3",
+			exportID: 3,
+			userID:   1,
+			mockBehavior: func(s *mock_service.MockAccount, exportID, userID int64) {
+				s.EXPECT().GetAccountExport(exportID, userID).Return(model.AccountExport{
+					ID:            3,
+					Status:        model.AccountExportDone,
+					Size:          2048,
+					CreatedAt:     created,
+					FinishedAt:    &finished,
+					DownloadURL:   "/account-exports/3?expires=1709921760&signature=ab&user=1",
+					LinkExpiresAt: &expires,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"id":3,"status":"done","size":2048,"created_at":"2024-03-08T18:00:00Z",` +
+				`"finished_at":"2024-03-08T18:01:00Z",` +
+				`"download_url":"/account-exports/3?expires=1709921760\u0026signature=ab\u0026user=1",` +
+				`"link_expires_at":"2024-03-08T18:16:00Z"}`,
+		}, {
+			name:                 "incorrect userID",
+			inputID:              "3",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockAccount, exportID, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect export id",
+			inputID:              "latest",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAccount, exportID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect export id record"}`,
+		}, {
+			name:     "incorrect GetAccountExport return: no export",
+			inputID:  "4",
+			exportID: 4,
+			userID:   1,
+			mockBehavior: func(s *mock_service.MockAccount, exportID, userID int64) {
+				s.EXPECT().GetAccountExport(exportID, userID).Return(model.AccountExport{}, repositories.ErrNoAccountExport)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no export with this exportID"}`,
+		}, {
+			name:     "incorrect GetAccountExport return: internal server error",
+			inputID:  "4",
+			exportID: 4,
+			userID:   1,
+			mockBehavior: func(s *mock_service.MockAccount, exportID, userID int64) {
+				s.EXPECT().GetAccountExport(exportID, userID).Return(model.AccountExport{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't get export"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			getter := mock_service.NewMockAccount(ctrl)
+			test.mockBehavior(getter, test.exportID, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/me/exports/{exportId}", GetExport(logger, getter))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/me/exports/"+test.inputID, nil)
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_DownloadExport(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockAccount)
+
+	export := model.AccountExport{
+		ID:        3,
+		OwnerID:   1,
+		Status:    model.AccountExportDone,
+		Size:      7,
+		CreatedAt: time.Date(2024, time.March, 8, 18, 0, 0, 0, time.UTC),
+	}
+
+	var tests = []struct {
+		name                 string
+		target               string
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedDisposition  string
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			target: "/account-exports/3?user=1&expires=1709921760&signature=ab",
+			mockBehavior: func(s *mock_service.MockAccount) {
+				s.EXPECT().OpenAccountExport(gomock.Any(), int64(3), int64(1), int64(1709921760), "ab").
+					Return(export, nopCloser{strings.NewReader("PK\x03\x04zip")}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedDisposition:  `attachment; filename=account-export-3.zip`,
+			expectedResponseBody: "PK\x03\x04zip",
+		}, {
+			name:                 "incorrect export id",
+			target:               "/account-exports/latest?user=1&expires=1709921760&signature=ab",
+			mockBehavior:         func(s *mock_service.MockAccount) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect export id record"}`,
+		}, {
+			name:                 "no expiry",
+			target:               "/account-exports/3?user=1&signature=ab",
+			mockBehavior:         func(s *mock_service.MockAccount) {},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"incorrect or expired download link"}`,
+		}, {
+			name:   "incorrect OpenAccountExport return: incorrect link",
+			target: "/account-exports/3?user=2&expires=1709921760&signature=ab",
+			mockBehavior: func(s *mock_service.MockAccount) {
+				s.EXPECT().OpenAccountExport(gomock.Any(), int64(3), int64(2), int64(1709921760), "ab").
+					Return(model.AccountExport{}, nil, service.ErrExportLink)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"incorrect or expired download link"}`,
+		}, {
+			name:   "incorrect OpenAccountExport return: no export",
+			target: "/account-exports/3?user=1&expires=1709921760&signature=ab",
+			mockBehavior: func(s *mock_service.MockAccount) {
+				s.EXPECT().OpenAccountExport(gomock.Any(), int64(3), int64(1), int64(1709921760), "ab").
+					Return(model.AccountExport{}, nil, blobstore.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no export with this exportID"}`,
+		}, {
+			name:   "incorrect OpenAccountExport return: internal server error",
+			target: "/account-exports/3?user=1&expires=1709921760&signature=ab",
+			mockBehavior: func(s *mock_service.MockAccount) {
+				s.EXPECT().OpenAccountExport(gomock.Any(), int64(3), int64(1), int64(1709921760), "ab").
+					Return(model.AccountExport{}, nil, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't open export"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			opener := mock_service.NewMockAccount(ctrl)
+			test.mockBehavior(opener)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/account-exports/{exportId}", DownloadExport(logger, opener))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, test.target, nil)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedDisposition, w.Header().Get("Content-Disposition"))
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
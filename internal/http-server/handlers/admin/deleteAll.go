@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net/http"
 	"restAPI/internal/repositories"
+	"restAPI/internal/service"
 )
 
 type AllDeleter interface {
@@ -20,6 +21,10 @@ func DeleteAll(log *slog.Logger, allDeleter AllDeleter) http.HandlerFunc {
 		)
 
 		err := allDeleter.DeleteAllTasks()
+		if errors.Is(err, service.ErrBlobCleanup) {
+			log.Warn("attachments of deleted tasks were not removed", slog.String("error", err.Error()))
+			err = nil
+		}
 		if err != nil {
 			log.Error("failed to delete all tasks", slog.Attr{
 				Key:   "error",
@@ -0,0 +1,115 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/model"
+	"time"
+)
+
+// flushEvery is how many lines Export writes between flushes, so that the
+// client gets the tasks while the export goes on.
+const flushEvery = 500
+
+type TaskStreamer interface {
+	StreamTasks(ctx context.Context, stream model.TaskStream, fn func(task model.TaskRow) error) error
+}
+
+// Export streams the tasks of all the users as JSON Lines, a task per line in
+// the order of their ids. The tasks can be narrowed to a user with user_id and
+// to the days from and to, both included. A cut export is resumed with after,
+// the id of the last line received.
+func Export(log *slog.Logger, streamer TaskStreamer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		if format := r.URL.Query().Get("format"); format != "" && format != "ndjson" {
+			log.Error("unknown export format", slog.String("format", format))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, "unknown export format")
+			return
+		}
+
+		var stream model.TaskStream
+		var err error
+		stream.UserID, err = request.OptionalID(r, "user_id")
+		if err != nil {
+			log.Error("incorrect user id", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, "incorrect user id")
+			return
+		}
+		after, err := request.OptionalID(r, "after")
+		if err != nil || after != nil && *after < 0 {
+			log.Error("incorrect checkpoint", slog.Any("error", err))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, "incorrect checkpoint")
+			return
+		}
+		if after != nil {
+			stream.After = *after
+		}
+		stream.From, err = request.OptionalDate(r, "from")
+		if err == nil {
+			stream.To, err = request.OptionalDate(r, "to")
+		}
+		if stream.To != nil {
+			// the to day is included
+			to := stream.To.AddDate(0, 0, 1)
+			stream.To = &to
+		}
+		if err == nil && stream.From != nil && stream.To != nil && !stream.To.After(*stream.From) {
+			err = fmt.Errorf("from %s is after to", stream.From.Format(time.DateOnly))
+		}
+		if err != nil {
+			log.Error("incorrect date range", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, "incorrect date range")
+			return
+		}
+
+		controller := http.NewResponseController(w)
+		// an export of everything outlasts the write timeout of the server
+		_ = controller.SetWriteDeadline(time.Time{})
+		encoder := json.NewEncoder(w)
+		lines, last := 0, stream.After
+		err = streamer.StreamTasks(r.Context(), stream, func(task model.TaskRow) error {
+			if lines == 0 {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+			}
+			if err := encoder.Encode(task); err != nil {
+				return err
+			}
+			lines, last = lines+1, task.ID
+			if lines%flushEvery == 0 {
+				_ = controller.Flush()
+			}
+			return nil
+		})
+		if err != nil && lines == 0 {
+			log.Error("failed to export tasks", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, "failed to export tasks")
+			return
+		}
+		if err != nil {
+			// the status is sent already, the client resumes after the last line
+			log.Error("task export stopped", slog.Int("lines", lines), slog.Int64("checkpoint", last),
+				slog.String("error", err.Error()))
+			return
+		}
+		if lines == 0 {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+		}
+		log.Info("tasks exported", slog.Int("lines", lines), slog.Int64("checkpoint", last))
+	}
+}
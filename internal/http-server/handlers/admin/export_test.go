@@ -0,0 +1,135 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	mock_service "restAPI/internal/service/mocks"
+	"testing"
+	"time"
+)
+
+func TestHandler_Export(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTask)
+
+	userID := int64(2)
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	rows := []model.TaskRow{
+		{ID: 11, OwnerID: 2, Text: "first", Tags: []string{"a"}, Date: from, Status: "todo", CreatedAt: from},
+		{ID: 12, OwnerID: 2, Text: "second", Tags: []string{}, Date: from, Status: "done", CreatedAt: from},
+	}
+	firstLine := `{"id":11,"owner_id":2,"text":"first","tags":["a"],"date":"2024-03-01T00:00:00Z","status":"todo",` +
+		`"created_at":"2024-03-01T00:00:00Z"}` + "\n"
+	secondLine := `{"id":12,"owner_id":2,"text":"second","tags":[],"date":"2024-03-01T00:00:00Z","status":"done",` +
+		`"created_at":"2024-03-01T00:00:00Z"}` + "\n"
+	streams := func(stream model.TaskStream, rows []model.TaskRow, err error) MockBehavior {
+		return func(s *mock_service.MockTask) {
+			s.EXPECT().StreamTasks(gomock.Any(), stream, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ model.TaskStream, fn func(task model.TaskRow) error) error {
+					for _, row := range rows {
+						if err := fn(row); err != nil {
+							return err
+						}
+					}
+					return err
+				})
+		}
+	}
+
+	var tests = []struct {
+		name                 string
+		query                string
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+	}{
+		{
+			name:                 "correct working",
+			query:                "?format=ndjson&user_id=2&from=2024-03-01&to=2024-03-31&after=10",
+			mockBehavior:         streams(model.TaskStream{UserID: &userID, From: &from, To: &to, After: 10}, rows, nil),
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "application/x-ndjson",
+			expectedResponseBody: firstLine + secondLine,
+		}, {
+			name:                "no tasks",
+			mockBehavior:        streams(model.TaskStream{}, nil, nil),
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+		}, {
+			name:                 "unknown format",
+			query:                "?format=xml",
+			mockBehavior:         func(s *mock_service.MockTask) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `"unknown export format"` + "\n",
+		}, {
+			name:                 "incorrect user id",
+			query:                "?user_id=me",
+			mockBehavior:         func(s *mock_service.MockTask) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `"incorrect user id"` + "\n",
+		}, {
+			name:                 "incorrect checkpoint",
+			query:                "?after=-1",
+			mockBehavior:         func(s *mock_service.MockTask) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `"incorrect checkpoint"` + "\n",
+		}, {
+			name:                 "incorrect date range",
+			query:                "?from=2024-03-31&to=2024-03-01",
+			mockBehavior:         func(s *mock_service.MockTask) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `"incorrect date range"` + "\n",
+		}, {
+			name:                 "incorrect StreamTasks return: internal server error",
+			mockBehavior:         streams(model.TaskStream{}, nil, errors.New("test")),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `"failed to export tasks"` + "\n",
+		}, {
+			name:                 "incorrect StreamTasks return: stopped after a line",
+			mockBehavior:         streams(model.TaskStream{}, rows[:1], errors.New("test")),
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "application/x-ndjson",
+			expectedResponseBody: firstLine,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			streamer := mock_service.NewMockTask(ctrl)
+			test.mockBehavior(streamer)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/admin/export", Export(logger, streamer))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/admin/export"+test.query, nil)
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), test.expectedContentType)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
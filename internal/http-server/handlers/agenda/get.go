@@ -0,0 +1,89 @@
+package agenda
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"strconv"
+	"time"
+)
+
+const defaultDays = 7
+
+type agendaGetter interface {
+	GetAgenda(userID int64, days int, loc *time.Location) (model.Agenda, error)
+}
+
+// Get agenda
+// @Summary Get
+// @Security ApiKeyPath
+// @Tags Agenda
+// @Description Get the coming days of the user starting today in the tz zone, with the tasks in progress.
+// @Description Each day lists the tasks due on it, dated on it (scheduled) and the occurrences of repeating tasks; the first day also lists the overdue tasks. Done and snoozed tasks are left out.
+// @ID getAgenda
+// @Param days query int false "number of days, 7 by default, at most 31"
+// @Param tz query string false "IANA time zone of the user, UTC by default"
+// @Produce json
+// @Success 200 {object} model.Agenda
+// @Failure 400,401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /agenda [get]
+func Get(log *slog.Logger, getter agendaGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		days := defaultDays
+		if value := r.URL.Query().Get("days"); value != "" {
+			var err error
+			days, err = strconv.Atoi(value)
+			if err != nil || days < 1 || days > model.MaxAgendaDays {
+				log.Error("incorrect days record", slog.String("days", value))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, response.Message{
+					Msg: "incorrect days record",
+				})
+				return
+			}
+		}
+
+		loc, err := request.Location(r)
+		if err != nil {
+			log.Error("incorrect time zone", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect time zone",
+			})
+			return
+		}
+
+		agenda, err := getter.GetAgenda(userID, days, loc)
+		if err != nil {
+			log.Error("can't get agenda", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't get agenda",
+			})
+			return
+		}
+		log.Info("agenda sent", slog.Int("days", days))
+		render.JSON(w, r, agenda)
+	}
+}
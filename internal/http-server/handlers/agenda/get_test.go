@@ -0,0 +1,136 @@
+package agenda
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_GetAgenda(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockAgenda, userID int64)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	due := time.Date(2024, time.March, 5, 18, 0, 0, 0, time.UTC)
+	at := time.Date(2024, time.March, 6, 9, 0, 0, 0, time.UTC)
+	emptyDay := func(date string) model.AgendaDay {
+		return model.AgendaDay{
+			Date:      date,
+			Overdue:   []model.Task{},
+			Due:       []model.Task{},
+			Scheduled: []model.Task{},
+			Recurring: []model.AgendaOccurrence{},
+		}
+	}
+
+	var tests = []struct {
+		name                 string
+		query                string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			query:  "?days=2&tz=Europe/Berlin",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAgenda, userID int64) {
+				today := emptyDay("2024-03-06")
+				today.Overdue = []model.Task{{ID: 1, Text: "Pay rent", Tags: []string{}, Date: due, Due: &due}}
+				today.Recurring = []model.AgendaOccurrence{
+					{At: at, Task: model.Task{ID: 2, Text: "Standup", Tags: []string{}, Date: at}},
+				}
+				s.EXPECT().GetAgenda(userID, 2, berlin).Return(model.Agenda{
+					InProgress: []model.Task{},
+					Days:       []model.AgendaDay{today, emptyDay("2024-03-07")},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"in_progress":[],"days":[{"date":"2024-03-06",` +
+				`"overdue":[{"text":"Pay rent","tags":[],"date":"2024-03-05T18:00:00Z","due":"2024-03-05T18:00:00Z","progress":{"done":0,"total":0},"comment_count":0}],` +
+				`"due":[],"scheduled":[],` +
+				`"recurring":[{"at":"2024-03-06T09:00:00Z","task":{"text":"Standup","tags":[],"date":"2024-03-06T09:00:00Z","progress":{"done":0,"total":0},"comment_count":0}}]},` +
+				`{"date":"2024-03-07","overdue":[],"due":[],"scheduled":[],"recurring":[]}]}`,
+		}, {
+			name:   "a week in UTC by default",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAgenda, userID int64) {
+				s.EXPECT().GetAgenda(userID, 7, time.UTC).Return(model.Agenda{
+					InProgress: []model.Task{},
+					Days:       []model.AgendaDay{},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"in_progress":[],"days":[]}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockAgenda, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect days",
+			query:                "?days=32",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAgenda, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect days record"}`,
+		}, {
+			name:                 "incorrect time zone",
+			query:                "?tz=Nowhere",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAgenda, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect time zone"}`,
+		}, {
+			name:   "incorrect Agenda return: internal server error",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAgenda, userID int64) {
+				s.EXPECT().GetAgenda(userID, 7, time.UTC).Return(model.Agenda{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't get agenda"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			agenda := mock_service.NewMockAgenda(ctrl)
+			test.mockBehavior(agenda, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/agenda", Get(logger, agenda))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/agenda"+test.query, nil)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chi.NewRouteContext()))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/repositories"
+	"restAPI/internal/service"
+	"strconv"
+)
+
+type deleter interface {
+	DeleteAttachment(ctx context.Context, taskID, attachmentID, userID int64) error
+}
+
+// Delete attachment
+// @Summary Delete
+// @Security ApiKeyPath
+// @Tags Attachment
+// @Description Delete a file attached to the task
+// @ID deleteAttachment
+// @Param task_id path int true "task ID"
+// @Param attachment_id path int true "attachment ID"
+// @Produce json
+// @Success 204
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/attachments/{attachmentId} [delete]
+func Delete(log *slog.Logger, deleter deleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		attachmentID, err := strconv.Atoi(chi.URLParam(r, "attachmentId"))
+		if err != nil {
+			log.Error("incorrect attachment id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect attachment id record",
+			})
+			return
+		}
+
+		err = deleter.DeleteAttachment(r.Context(), int64(taskID), int64(attachmentID), userID)
+		if errors.Is(err, service.ErrBlobCleanup) {
+			log.Warn("blob of deleted attachment was not removed", slog.String("error", err.Error()))
+			err = nil
+		}
+		if errors.Is(err, repositories.ErrNoAttachment) {
+			log.Error("there is no attachment", slog.Int("taskID", taskID), slog.Int("attachmentID", attachmentID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no attachment with this attachmentID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't delete attachment", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't delete attachment",
+			})
+			return
+		}
+		log.Info("attachment deleted", slog.Int("attachmentID", attachmentID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,117 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/blobstore"
+	"strconv"
+)
+
+// inlineTypes are shown in the browser, everything else is downloaded so an
+// uploaded page can't run scripts on our origin.
+var inlineTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+type opener interface {
+	OpenAttachment(ctx context.Context, taskID, attachmentID, userID int64) (model.Attachment, io.ReadSeekCloser, error)
+}
+
+// Download attachment
+// @Summary Download
+// @Security ApiKeyPath
+// @Tags Attachment
+// @Description Get the content of an attached file, the Range header is supported
+// @ID downloadAttachment
+// @Param task_id path int true "task ID"
+// @Param attachment_id path int true "attachment ID"
+// @Produce octet-stream
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Failure 400,401,404,416 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/attachments/{attachmentId} [get]
+func Download(log *slog.Logger, opener opener) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		attachmentID, err := strconv.Atoi(chi.URLParam(r, "attachmentId"))
+		if err != nil {
+			log.Error("incorrect attachment id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect attachment id record",
+			})
+			return
+		}
+
+		attachment, content, err := opener.OpenAttachment(r.Context(), int64(taskID), int64(attachmentID), userID)
+		if errors.Is(err, repositories.ErrNoAttachment) || errors.Is(err, blobstore.ErrNotFound) {
+			log.Error("there is no attachment", slog.Int("taskID", taskID), slog.Int("attachmentID", attachmentID),
+				slog.Any("error", err))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no attachment with this attachmentID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't open attachment", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't open attachment",
+			})
+			return
+		}
+		defer content.Close()
+
+		disposition := "attachment"
+		if inlineTypes[attachment.ContentType] {
+			disposition = "inline"
+		}
+		w.Header().Set("Content-Type", attachment.ContentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{
+			"filename": attachment.Name,
+		}))
+		log.Info("attachment sent", slog.Int("attachmentID", attachmentID))
+		http.ServeContent(w, r, attachment.Name, attachment.CreatedAt, content)
+	}
+}
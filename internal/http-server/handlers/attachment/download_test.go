@@ -0,0 +1,138 @@
+package attachment
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"restAPI/pkg/lib/blobstore"
+	"strings"
+	"testing"
+	"time"
+)
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestHandler_Download(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockAttachment, taskID, attachmentID, userID int64)
+
+	attachment := model.Attachment{
+		ID:          2,
+		TaskID:      4,
+		OwnerID:     1,
+		Name:        "notes.txt",
+		ContentType: "text/plain; charset=utf-8",
+		Size:        10,
+		CreatedAt:   time.Date(2000, 10, 10, 10, 10, 10, 0, time.UTC),
+	}
+
+	var tests = []struct {
+		name                 string
+		byteRange            string
+		stringAttachmentID   string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedDisposition  string
+		expectedResponseBody string
+	}{
+		{
+			name:               "correct working",
+			stringAttachmentID: "2",
+			userID:             1,
+			mockBehavior: func(s *mock_service.MockAttachment, taskID, attachmentID, userID int64) {
+				s.EXPECT().OpenAttachment(gomock.Any(), taskID, attachmentID, userID).
+					Return(attachment, nopCloser{strings.NewReader("0123456789")}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedDisposition:  `attachment; filename=notes.txt`,
+			expectedResponseBody: "0123456789",
+		}, {
+			name:               "correct working: range",
+			byteRange:          "bytes=2-5",
+			stringAttachmentID: "2",
+			userID:             1,
+			mockBehavior: func(s *mock_service.MockAttachment, taskID, attachmentID, userID int64) {
+				s.EXPECT().OpenAttachment(gomock.Any(), taskID, attachmentID, userID).
+					Return(attachment, nopCloser{strings.NewReader("0123456789")}, nil)
+			},
+			expectedStatusCode:   http.StatusPartialContent,
+			expectedDisposition:  `attachment; filename=notes.txt`,
+			expectedResponseBody: "2345",
+		}, {
+			name:                 "incorrect attachmentID",
+			stringAttachmentID:   "b",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAttachment, taskID, attachmentID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect attachment id record"}`,
+		}, {
+			name:               "incorrect OpenAttachment return: no attachment",
+			stringAttachmentID: "2",
+			userID:             1,
+			mockBehavior: func(s *mock_service.MockAttachment, taskID, attachmentID, userID int64) {
+				s.EXPECT().OpenAttachment(gomock.Any(), taskID, attachmentID, userID).
+					Return(model.Attachment{}, nil, repositories.ErrNoAttachment)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no attachment with this attachmentID"}`,
+		}, {
+			name:               "incorrect OpenAttachment return: blob is missing",
+			stringAttachmentID: "2",
+			userID:             1,
+			mockBehavior: func(s *mock_service.MockAttachment, taskID, attachmentID, userID int64) {
+				s.EXPECT().OpenAttachment(gomock.Any(), taskID, attachmentID, userID).
+					Return(model.Attachment{}, nil, blobstore.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no attachment with this attachmentID"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			opener := mock_service.NewMockAttachment(ctrl)
+			test.mockBehavior(opener, 4, 2, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/attachment", Download(logger, opener))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/attachment", nil)
+			if test.byteRange != "" {
+				r.Header.Set("Range", test.byteRange)
+			}
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("taskId", "4")
+			rctx.URLParams.Add("attachmentId", test.stringAttachmentID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedDisposition, w.Header().Get("Content-Disposition"))
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+)
+
+// multipartOverhead leaves room for the boundaries and part headers around the file.
+const multipartOverhead = 1 << 20
+
+type uploadResponse struct {
+	AttachmentID int64 `json:"attachment_id"`
+}
+
+type uploader interface {
+	CreateAttachment(ctx context.Context, attachment model.Attachment, data []byte) (int64, error)
+}
+
+// Upload attachment
+// @Summary Upload
+// @Security ApiKeyPath
+// @Tags Attachment
+// @Description Attach a file to a task, the file goes in the "file" field of a multipart form
+// @ID uploadAttachment
+// @Accept mpfd
+// @Produce json
+// @Param task_id path int true "task ID"
+// @Param file formData file true "file to attach"
+// @Success 201 {object} uploadResponse
+// @Failure 400,401,404,413 {object} response.Message
+// @Failure 500,507 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/attachments [post]
+func Upload(log *slog.Logger, uploader uploader, maxSize int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize+multipartOverhead)
+		name, data, err := readFile(r, maxSize)
+		var maxBytesErr *http.MaxBytesError
+		if errors.Is(err, errTooLarge) || errors.As(err, &maxBytesErr) {
+			log.Error("file is too large", slog.Int64("maxSize", maxSize))
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			render.JSON(w, r, response.Message{
+				Msg: "file is too large",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to read file", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to read file",
+			})
+			return
+		}
+		if !verification.AttachmentName(name) {
+			log.Error("incorrect file name", slog.String("name", name))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect file name",
+			})
+			return
+		}
+
+		attachmentID, err := uploader.CreateAttachment(r.Context(), model.Attachment{
+			TaskID:  int64(taskID),
+			OwnerID: userID,
+			Name:    name,
+		}, data)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrQuotaExceeded) {
+			log.Error("attachment quota exceeded", slog.Int64("userID", userID))
+			w.WriteHeader(http.StatusInsufficientStorage)
+			render.JSON(w, r, response.Message{
+				Msg: "attachment quota exceeded",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to upload attachment", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to upload attachment",
+			})
+			return
+		}
+		log.Info("attachment uploaded", slog.Int64("attachmentID", attachmentID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, uploadResponse{
+			AttachmentID: attachmentID,
+		})
+	}
+}
+
+var (
+	errTooLarge = errors.New("file is too large")
+	errNoFile   = errors.New("no file in the form")
+)
+
+// readFile returns the name and the content of the "file" form field.
+func readFile(r *http.Request, maxSize int64) (string, []byte, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return "", nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return "", nil, errNoFile
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(part, maxSize+1))
+		if err != nil {
+			return "", nil, err
+		}
+		if int64(len(data)) > maxSize {
+			return "", nil, errTooLarge
+		}
+		return path.Base(part.FileName()), data, nil
+	}
+}
@@ -0,0 +1,162 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func multipartBody(t *testing.T, field, name string, data []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(field, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data)
+	writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+func TestHandler_Upload(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockAttachment, attachment model.Attachment, data []byte)
+
+	var tests = []struct {
+		name                 string
+		field                string
+		fileName             string
+		data                 []byte
+		stringTaskID         string
+		inputAttachment      model.Attachment
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:            "correct working",
+			field:           "file",
+			fileName:        "notes.txt",
+			data:            []byte("buy milk"),
+			stringTaskID:    "4",
+			inputAttachment: model.Attachment{TaskID: 4, OwnerID: 1, Name: "notes.txt"},
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockAttachment, attachment model.Attachment, data []byte) {
+				s.EXPECT().CreateAttachment(gomock.Any(), attachment, data).Return(int64(7), nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"attachment_id":7}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockAttachment, attachment model.Attachment, data []byte) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "no file field",
+			field:                "document",
+			fileName:             "notes.txt",
+			data:                 []byte("buy milk"),
+			stringTaskID:         "4",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAttachment, attachment model.Attachment, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"failed to read file"}`,
+		}, {
+			name:                 "too large file",
+			field:                "file",
+			fileName:             "notes.txt",
+			data:                 bytes.Repeat([]byte("a"), 17),
+			stringTaskID:         "4",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAttachment, attachment model.Attachment, data []byte) {},
+			expectedStatusCode:   http.StatusRequestEntityTooLarge,
+			expectedResponseBody: `{"message":"file is too large"}`,
+		}, {
+			name:            "incorrect CreateAttachment return: quota exceeded",
+			field:           "file",
+			fileName:        "notes.txt",
+			data:            []byte("buy milk"),
+			stringTaskID:    "4",
+			inputAttachment: model.Attachment{TaskID: 4, OwnerID: 1, Name: "notes.txt"},
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockAttachment, attachment model.Attachment, data []byte) {
+				s.EXPECT().CreateAttachment(gomock.Any(), attachment, data).Return(int64(0), repositories.ErrQuotaExceeded)
+			},
+			expectedStatusCode:   http.StatusInsufficientStorage,
+			expectedResponseBody: `{"message":"attachment quota exceeded"}`,
+		}, {
+			name:            "incorrect CreateAttachment return: no task",
+			field:           "file",
+			fileName:        "notes.txt",
+			data:            []byte("buy milk"),
+			stringTaskID:    "4",
+			inputAttachment: model.Attachment{TaskID: 4, OwnerID: 1, Name: "notes.txt"},
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockAttachment, attachment model.Attachment, data []byte) {
+				s.EXPECT().CreateAttachment(gomock.Any(), attachment, data).Return(int64(0), repositories.ErrNoTask)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no task with this taskID"}`,
+		}, {
+			name:            "incorrect CreateAttachment return: internal server error",
+			field:           "file",
+			fileName:        "notes.txt",
+			data:            []byte("buy milk"),
+			stringTaskID:    "4",
+			inputAttachment: model.Attachment{TaskID: 4, OwnerID: 1, Name: "notes.txt"},
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockAttachment, attachment model.Attachment, data []byte) {
+				s.EXPECT().CreateAttachment(gomock.Any(), attachment, data).Return(int64(0), errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"failed to upload attachment"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			attachment := mock_service.NewMockAttachment(ctrl)
+			test.mockBehavior(attachment, test.inputAttachment, test.data)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/attachments/", Upload(logger, attachment, 16))
+
+			body, contentType := multipartBody(t, test.field, test.fileName, test.data)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/attachments/", body)
+			r.Header.Set("Content-Type", contentType)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("taskId", test.stringTaskID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package board
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/verification"
+)
+
+type columnRequest struct {
+	Name   string  `json:"name"`
+	Status *string `json:"status"`
+}
+
+type createRequest struct {
+	Name    string          `json:"name"`
+	Columns []columnRequest `json:"columns"`
+}
+
+type createResponse struct {
+	BoardID int64 `json:"board_id"`
+}
+
+type boardCreater interface {
+	CreateBoard(board model.Board) (int64, error)
+}
+
+// Create board
+// @Summary Create
+// @Security ApiKeyPath
+// @Tags Board
+// @Description Create new board, without columns it gets one column per task status
+// @ID createBoard
+// @Accept json
+// @Produce json
+// @Param input body createRequest true "Board info"
+// @Success 201 {object} createResponse
+// @Failure 400,401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /boards/ [post]
+func Create(log *slog.Logger, creater boardCreater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req createRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		board := model.Board{
+			Name:    req.Name,
+			OwnerID: userID,
+		}
+		for _, column := range req.Columns {
+			board.Columns = append(board.Columns, model.BoardColumn{
+				Name:   column.Name,
+				Status: column.Status,
+			})
+		}
+		if !verification.Board(board) {
+			log.Error("incorrect board information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect board information",
+			})
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		boardID, err := creater.CreateBoard(board)
+		if err != nil {
+			log.Error("failed to create board", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to create board",
+			})
+			return
+		}
+		log.Info("board created", slog.Int64("boardID", boardID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, createResponse{
+			BoardID: boardID,
+		})
+	}
+}
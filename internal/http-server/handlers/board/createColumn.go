@@ -0,0 +1,116 @@
+package board
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+)
+
+type createColumnResponse struct {
+	ColumnID int64 `json:"column_id"`
+}
+
+type columnCreater interface {
+	CreateColumn(column model.BoardColumn, userID int64) (int64, error)
+}
+
+// CreateColumn on board
+// @Summary CreateColumn
+// @Security ApiKeyPath
+// @Tags Board
+// @Description Add a column at the end of the board, status is optional
+// @ID createBoardColumn
+// @Accept json
+// @Produce json
+// @Param board_id path int true "board ID"
+// @Param input body columnRequest true "Column info"
+// @Success 201 {object} createColumnResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /boards/{boardId}/columns [post]
+func CreateColumn(log *slog.Logger, creater columnCreater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req columnRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		boardID, err := strconv.Atoi(chi.URLParam(r, "boardId"))
+		if err != nil {
+			log.Error("incorrect board id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect board id record",
+			})
+			return
+		}
+
+		column := model.BoardColumn{
+			BoardID: int64(boardID),
+			Name:    req.Name,
+			Status:  req.Status,
+		}
+		if !verification.BoardColumn(column) {
+			log.Error("incorrect column information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect column information",
+			})
+			return
+		}
+
+		columnID, err := creater.CreateColumn(column, userID)
+		if errors.Is(err, repositories.ErrNoBoard) {
+			log.Error("there is no board", slog.Int64("userID", userID), slog.Int("boardID", boardID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no board with this boardID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to create column", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to create column",
+			})
+			return
+		}
+		log.Info("column created", slog.Int64("columnID", columnID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, createColumnResponse{
+			ColumnID: columnID,
+		})
+	}
+}
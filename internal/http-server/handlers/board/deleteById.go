@@ -0,0 +1,78 @@
+package board
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type boardDeleter interface {
+	DeleteBoard(boardID, userID int64) error
+}
+
+// Delete board by ID
+// @Summary Delete
+// @Security ApiKeyPath
+// @Tags Board
+// @Description Delete user board by ID, its tasks are kept
+// @ID deleteBoardByID
+// @Produce json
+// @Param board_id path int true "board ID"
+// @Success 204
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /boards/{boardId} [delete]
+func Delete(log *slog.Logger, deleter boardDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		boardID, err := strconv.Atoi(chi.URLParam(r, "boardId"))
+		if err != nil {
+			log.Error("incorrect board id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect board id record",
+			})
+			return
+		}
+		err = deleter.DeleteBoard(int64(boardID), userID)
+		if errors.Is(err, repositories.ErrNoBoard) {
+			log.Error("there is no board", slog.Int64("userID", userID), slog.Int("boardID", boardID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no board with this boardID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't delete board", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't delete board",
+			})
+			return
+		}
+		log.Info("board was deleted by Id", slog.Int("id", boardID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
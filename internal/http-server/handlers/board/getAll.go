@@ -0,0 +1,64 @@
+package board
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+)
+
+type getAllResponse struct {
+	Boards []model.Board `json:"boards"`
+}
+
+type allGetter interface {
+	GetBoards(userID int64) ([]model.Board, error)
+}
+
+// GetAll user boards
+// @Summary GetAll
+// @Security ApiKeyPath
+// @Tags Board
+// @Description Get all user boards without their columns
+// @ID getAllUserBoards
+// @Produce json
+// @Success 200 {object} getAllResponse
+// @Failure 401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /boards/ [get]
+func GetAll(log *slog.Logger, getter allGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		boards, err := getter.GetBoards(userID)
+		if err != nil {
+			log.Error("couldn't get all boards by this user", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "couldn't get all boards",
+			})
+			return
+		}
+
+		log.Info("all user boards copied", slog.Int64("userID", userID))
+		render.JSON(w, r, getAllResponse{
+			Boards: boards,
+		})
+	}
+}
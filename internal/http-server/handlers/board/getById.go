@@ -0,0 +1,85 @@
+package board
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type getBoardResponse struct {
+	Board model.Board `json:"board"`
+}
+
+type boardGetter interface {
+	GetBoard(boardID, userID int64) (model.Board, error)
+}
+
+// Get board by ID
+// @Summary Get
+// @Security ApiKeyPath
+// @Tags Board
+// @Description Get user board with every column and its tasks in order
+// @ID getBoardByID
+// @Param board_id path int true "board ID"
+// @Produce json
+// @Success 200 {object} getBoardResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /boards/{boardId} [get]
+func Get(log *slog.Logger, getter boardGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		boardID, err := strconv.Atoi(chi.URLParam(r, "boardId"))
+		if err != nil {
+			log.Error("incorrect board id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect board id record",
+			})
+			return
+		}
+		board, err := getter.GetBoard(int64(boardID), userID)
+		if errors.Is(err, repositories.ErrNoBoard) {
+			log.Error("there is no board", slog.Int64("userID", userID), slog.Int("boardID", boardID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no board with this boardID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't found board", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't found board",
+			})
+			return
+		}
+		log.Info("board copied by id", slog.Int("id", boardID))
+		render.JSON(w, r, getBoardResponse{
+			Board: board,
+		})
+	}
+}
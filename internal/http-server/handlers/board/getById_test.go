@@ -0,0 +1,141 @@
+package board
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_GetBoard(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockBoard, boardID, userID int64)
+
+	status := model.TaskStatusInProgress
+
+	var tests = []struct {
+		name                 string
+		stringBoardID        string
+		boardID              int64
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:          "correct working",
+			stringBoardID: "1",
+			boardID:       1,
+			userID:        1,
+			mockBehavior: func(s *mock_service.MockBoard, boardID, userID int64) {
+				s.EXPECT().GetBoard(boardID, userID).Return(model.Board{
+					ID:      1,
+					Name:    "sprint",
+					OwnerID: 1,
+					Columns: []model.BoardColumn{
+						{
+							ID:     2,
+							Name:   "Doing",
+							Status: &status,
+							Cards: []model.BoardCard{
+								{
+									TaskID: 5,
+									Rank:   "i",
+									Task: model.Task{
+										ID:     5,
+										Text:   "TestText",
+										Tags:   []string{"testTag"},
+										Date:   time.Date(2000, 10, 10, 10, 10, 10, 0, time.UTC),
+										Status: model.TaskStatusInProgress,
+									},
+								},
+							},
+						}, {
+							ID:    3,
+							Name:  "Blocked",
+							Cards: []model.BoardCard{},
+						},
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"board":{"id":1,"name":"sprint","columns":[` +
+				`{"id":2,"name":"Doing","status":"in_progress","cards":[{"task_id":5,"text":"TestText","tags":["testTag"],"date":"2000-10-10T10:10:10Z","status":"in_progress","progress":{"done":0,"total":0},"comment_count":0}]},` +
+				`{"id":3,"name":"Blocked","cards":[]}]}}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockBoard, boardID, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect boardID",
+			stringBoardID:        "b",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockBoard, boardID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect board id record"}`,
+		}, {
+			name:          "no board",
+			stringBoardID: "1",
+			boardID:       1,
+			userID:        1,
+			mockBehavior: func(s *mock_service.MockBoard, boardID, userID int64) {
+				s.EXPECT().GetBoard(boardID, userID).Return(model.Board{}, repositories.ErrNoBoard)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no board with this boardID"}`,
+		}, {
+			name:          "incorrect GetBoard return",
+			stringBoardID: "1",
+			boardID:       1,
+			userID:        1,
+			mockBehavior: func(s *mock_service.MockBoard, boardID, userID int64) {
+				s.EXPECT().GetBoard(boardID, userID).Return(model.Board{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't found board"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			board := mock_service.NewMockBoard(ctrl)
+			test.mockBehavior(board, test.boardID, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/boards/", Get(logger, board))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/boards/", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("boardId", test.stringBoardID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
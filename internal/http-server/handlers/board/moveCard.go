@@ -0,0 +1,118 @@
+package board
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type moveRequest struct {
+	model.CardMove
+}
+
+type cardMover interface {
+	MoveCard(boardID, taskID, userID int64, move model.CardMove) error
+}
+
+// MoveCard on board
+// @Summary MoveCard
+// @Security ApiKeyPath
+// @Tags Board
+// @Description Put a task into a board column between the after and before tasks,
+// @Description without them the task goes to the end of the column. Status columns change the task status.
+// @ID moveBoardCard
+// @Accept json
+// @Produce json
+// @Param board_id path int true "board ID"
+// @Param task_id path int true "task ID"
+// @Param input body moveRequest true "Target column and neighbours"
+// @Success 204
+// @Failure 400,401,404,409 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /boards/{boardId}/cards/{taskId} [put]
+func MoveCard(log *slog.Logger, mover cardMover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req moveRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		boardID, err := strconv.Atoi(chi.URLParam(r, "boardId"))
+		if err != nil {
+			log.Error("incorrect board id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect board id record",
+			})
+			return
+		}
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+		err = mover.MoveCard(int64(boardID), int64(taskID), userID, req.CardMove)
+		if errors.Is(err, repositories.ErrNoBoard) || errors.Is(err, repositories.ErrNoColumn) ||
+			errors.Is(err, repositories.ErrNoTask) || errors.Is(err, repositories.ErrNoCard) {
+			log.Error("can't find card target", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no such board, column or task",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrCardOrder) {
+			log.Error("wrong card neighbours", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, response.Message{
+				Msg: "after and before tasks are not neighbours in this order",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't move card", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't move card",
+			})
+			return
+		}
+		log.Info("card moved", slog.Int("boardID", boardID), slog.Int("taskID", taskID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,151 @@
+package board
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_MoveCard(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockBoard, boardID, taskID, userID int64, move model.CardMove)
+
+	after := int64(7)
+
+	var tests = []struct {
+		name                 string
+		inputBody            string
+		stringBoardID        string
+		stringTaskID         string
+		boardID              int64
+		taskID               int64
+		userID               int64
+		inputMove            model.CardMove
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:          "correct working",
+			inputBody:     `{"column_id":2,"after":7}`,
+			stringBoardID: "1",
+			stringTaskID:  "5",
+			boardID:       1,
+			taskID:        5,
+			userID:        1,
+			inputMove:     model.CardMove{ColumnID: 2, After: &after},
+			mockBehavior: func(s *mock_service.MockBoard, boardID, taskID, userID int64, move model.CardMove) {
+				s.EXPECT().MoveCard(boardID, taskID, userID, move).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockBoard, boardID, taskID, userID int64, move model.CardMove) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "bad request",
+			inputBody:            `{"column_id":"2"}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockBoard, boardID, taskID, userID int64, move model.CardMove) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"failed to decode request"}`,
+		}, {
+			name:                 "incorrect taskID",
+			inputBody:            `{"column_id":2}`,
+			stringBoardID:        "1",
+			stringTaskID:         "a5",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockBoard, boardID, taskID, userID int64, move model.CardMove) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect task id record"}`,
+		}, {
+			name:          "no column",
+			inputBody:     `{"column_id":9}`,
+			stringBoardID: "1",
+			stringTaskID:  "5",
+			boardID:       1,
+			taskID:        5,
+			userID:        1,
+			inputMove:     model.CardMove{ColumnID: 9},
+			mockBehavior: func(s *mock_service.MockBoard, boardID, taskID, userID int64, move model.CardMove) {
+				s.EXPECT().MoveCard(boardID, taskID, userID, move).Return(fmt.Errorf("MoveCard: %w", repositories.ErrNoColumn))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no such board, column or task"}`,
+		}, {
+			name:          "wrong neighbours",
+			inputBody:     `{"column_id":2,"after":7}`,
+			stringBoardID: "1",
+			stringTaskID:  "5",
+			boardID:       1,
+			taskID:        5,
+			userID:        1,
+			inputMove:     model.CardMove{ColumnID: 2, After: &after},
+			mockBehavior: func(s *mock_service.MockBoard, boardID, taskID, userID int64, move model.CardMove) {
+				s.EXPECT().MoveCard(boardID, taskID, userID, move).Return(fmt.Errorf("MoveCard: %w", repositories.ErrCardOrder))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"after and before tasks are not neighbours in this order"}`,
+		}, {
+			name:          "incorrect MoveCard return",
+			inputBody:     `{"column_id":2}`,
+			stringBoardID: "1",
+			stringTaskID:  "5",
+			boardID:       1,
+			taskID:        5,
+			userID:        1,
+			inputMove:     model.CardMove{ColumnID: 2},
+			mockBehavior: func(s *mock_service.MockBoard, boardID, taskID, userID int64, move model.CardMove) {
+				s.EXPECT().MoveCard(boardID, taskID, userID, move).Return(errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't move card"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			board := mock_service.NewMockBoard(ctrl)
+			test.mockBehavior(board, test.boardID, test.taskID, test.userID, test.inputMove)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Put("/boards/cards/", MoveCard(logger, board))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/boards/cards/", bytes.NewBufferString(test.inputBody))
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("boardId", test.stringBoardID)
+			rctx.URLParams.Add("taskId", test.stringTaskID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
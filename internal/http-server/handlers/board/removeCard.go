@@ -0,0 +1,88 @@
+package board
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type cardRemover interface {
+	RemoveCard(boardID, taskID, userID int64) error
+}
+
+// RemoveCard from board
+// @Summary RemoveCard
+// @Security ApiKeyPath
+// @Tags Board
+// @Description Take a task off the board, the task itself is kept
+// @ID removeBoardCard
+// @Produce json
+// @Param board_id path int true "board ID"
+// @Param task_id path int true "task ID"
+// @Success 204
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /boards/{boardId}/cards/{taskId} [delete]
+func RemoveCard(log *slog.Logger, remover cardRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		boardID, err := strconv.Atoi(chi.URLParam(r, "boardId"))
+		if err != nil {
+			log.Error("incorrect board id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect board id record",
+			})
+			return
+		}
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+		err = remover.RemoveCard(int64(boardID), int64(taskID), userID)
+		if errors.Is(err, repositories.ErrNoCard) {
+			log.Error("there is no card", slog.Int("boardID", boardID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "task is not on this board",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't remove card", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't remove card",
+			})
+			return
+		}
+		log.Info("card removed", slog.Int("boardID", boardID), slog.Int("taskID", taskID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
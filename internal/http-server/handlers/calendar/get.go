@@ -0,0 +1,89 @@
+package calendar
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+)
+
+type getterByMonth interface {
+	GetCalendarMonth(month, year int, userID int64, filter model.TaskFilter) (model.CalendarMonth, error)
+}
+
+// Get calendar month
+// @Summary Get
+// @Security ApiKeyPath
+// @Tags Calendar
+// @Description Get every day of the month with the number of tasks, the number done and the top tags
+// @ID getCalendarMonth
+// @Param year path int true "year"
+// @Param month path int true "month"
+// @Param project query int false "project ID"
+// @Param include_deferred query bool false "also count the tasks snoozed until later"
+// @Produce json
+// @Success 200 {object} model.CalendarMonth
+// @Failure 400,401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /calendar/{year}/{month} [get]
+func Get(log *slog.Logger, getterByMonth getterByMonth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		year := chi.URLParam(r, "year")
+		month := chi.URLParam(r, "month")
+		if !verification.Month(month, year) {
+			log.Error(`incorrect data format`, slog.String("data", fmt.Sprintf("%s:%s", month, year)))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect data format",
+			})
+			return
+		}
+		monthInt, _ := strconv.Atoi(month)
+		yearInt, _ := strconv.Atoi(year)
+
+		filter, err := request.TaskFilter(r)
+		if err != nil {
+			log.Error("incorrect filter", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect filter",
+			})
+			return
+		}
+
+		calendar, err := getterByMonth.GetCalendarMonth(monthInt, yearInt, userID, filter)
+		if err != nil {
+			log.Error("get calendar month", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't get calendar",
+			})
+			return
+		}
+		log.Info("calendar month got", slog.String("month", month), slog.String("year", year))
+		render.JSON(w, r, calendar)
+	}
+}
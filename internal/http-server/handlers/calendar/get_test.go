@@ -0,0 +1,140 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_Get(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTask, userID int64)
+
+	projectID := int64(3)
+
+	var tests = []struct {
+		name                 string
+		inputYear            string
+		inputMonth           string
+		query                string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "correct working",
+			inputYear:  "2024",
+			inputMonth: "2",
+			userID:     1,
+			mockBehavior: func(s *mock_service.MockTask, userID int64) {
+				s.EXPECT().GetCalendarMonth(2, 2024, userID, model.TaskFilter{}).Return(model.CalendarMonth{
+					Year:  2024,
+					Month: 2,
+					Days: []model.CalendarDay{
+						{Date: "2024-02-01", Tasks: 3, Done: 1, TopTags: []string{"work", "home"}},
+						{Date: "2024-02-02", TopTags: []string{}},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"year":2024,"month":2,"days":[{"date":"2024-02-01","tasks":3,"done":1,"top_tags":["work","home"]},{"date":"2024-02-02","tasks":0,"done":0,"top_tags":[]}]}`,
+		}, {
+			name:       "with filter",
+			inputYear:  "2024",
+			inputMonth: "12",
+			query:      "?project=3&include_deferred=true",
+			userID:     1,
+			mockBehavior: func(s *mock_service.MockTask, userID int64) {
+				s.EXPECT().GetCalendarMonth(12, 2024, userID, model.TaskFilter{ProjectID: &projectID, IncludeDeferred: true}).
+					Return(model.CalendarMonth{Year: 2024, Month: 12, Days: []model.CalendarDay{}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"year":2024,"month":12,"days":[]}`,
+		}, {
+			name:                 "incorrect userID",
+			inputYear:            "2024",
+			inputMonth:           "2",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect month",
+			inputYear:            "2024",
+			inputMonth:           "13",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect data format"}`,
+		}, {
+			name:                 "incorrect year",
+			inputYear:            "20000",
+			inputMonth:           "2",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect data format"}`,
+		}, {
+			name:                 "incorrect filter",
+			inputYear:            "2024",
+			inputMonth:           "2",
+			query:                "?project=abc",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect filter"}`,
+		}, {
+			name:       "service error",
+			inputYear:  "2024",
+			inputMonth: "2",
+			userID:     1,
+			mockBehavior: func(s *mock_service.MockTask, userID int64) {
+				s.EXPECT().GetCalendarMonth(2, 2024, userID, model.TaskFilter{}).Return(model.CalendarMonth{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't get calendar"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			task := mock_service.NewMockTask(ctrl)
+			test.mockBehavior(task, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/calendar/", Get(logger, task))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/calendar/"+test.query, nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("year", test.inputYear)
+			rctx.URLParams.Add("month", test.inputMonth)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package comment
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+)
+
+type createRequest struct {
+	Text     string `json:"text"`
+	ParentID *int64 `json:"parent_id"`
+}
+
+type createResponse struct {
+	CommentID int64 `json:"comment_id"`
+}
+
+type creater interface {
+	CreateComment(comment model.Comment) (int64, error)
+}
+
+// Create comment
+// @Summary Create
+// @Security ApiKeyPath
+// @Tags Comment
+// @Description Comment on a task, with parent_id the comment is a reply in that thread
+// @ID createComment
+// @Accept json
+// @Produce json
+// @Param task_id path int true "task ID"
+// @Param input body createRequest true "Comment info"
+// @Success 201 {object} createResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/comments [post]
+func Create(log *slog.Logger, creater creater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req createRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		comment := model.Comment{
+			TaskID:   int64(taskID),
+			AuthorID: userID,
+			ParentID: req.ParentID,
+			Text:     req.Text,
+		}
+		if !verification.Comment(comment) {
+			log.Error("incorrect comment information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect comment information",
+			})
+			return
+		}
+
+		commentID, err := creater.CreateComment(comment)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrNoComment) {
+			log.Error("there is no parent comment", slog.Int("taskID", taskID), slog.Any("parentID", req.ParentID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no comment with this parent_id",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to create comment", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to create comment",
+			})
+			return
+		}
+		log.Info("comment created", slog.Int64("commentID", commentID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, createResponse{
+			CommentID: commentID,
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package comment
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type deleter interface {
+	DeleteComment(taskID, commentID, userID int64) error
+}
+
+// Delete comment
+// @Summary Delete
+// @Security ApiKeyPath
+// @Tags Comment
+// @Description Delete a comment with its replies, the author and the task owner can do it
+// @ID deleteComment
+// @Param task_id path int true "task ID"
+// @Param comment_id path int true "comment ID"
+// @Produce json
+// @Success 204
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/comments/{commentId} [delete]
+func Delete(log *slog.Logger, deleter deleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		commentID, err := strconv.Atoi(chi.URLParam(r, "commentId"))
+		if err != nil {
+			log.Error("incorrect comment id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect comment id record",
+			})
+			return
+		}
+
+		err = deleter.DeleteComment(int64(taskID), int64(commentID), userID)
+		if errors.Is(err, repositories.ErrNoComment) {
+			log.Error("there is no comment", slog.Int("taskID", taskID), slog.Int("commentID", commentID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no comment with this commentID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't delete comment", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't delete comment",
+			})
+			return
+		}
+		log.Info("comment deleted", slog.Int("commentID", commentID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
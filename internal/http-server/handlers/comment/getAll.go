@@ -0,0 +1,101 @@
+package comment
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type getAllResponse struct {
+	Comments []model.Comment `json:"comments"`
+	Total    int             `json:"total"`
+}
+
+type allGetter interface {
+	GetComments(taskID, userID int64, page model.Page) ([]model.Comment, int, error)
+}
+
+// GetAll task comments
+// @Summary GetAll
+// @Security ApiKeyPath
+// @Tags Comment
+// @Description Get a page of comment threads of the task with their replies, total counts the threads
+// @ID getAllTaskComments
+// @Param task_id path int true "task ID"
+// @Param limit query int false "threads on the page, 20 by default, at most 100"
+// @Param offset query int false "threads to skip"
+// @Produce json
+// @Success 200 {object} getAllResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/comments [get]
+func GetAll(log *slog.Logger, getter allGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		page, err := request.Page(r)
+		if err != nil {
+			log.Error("incorrect page", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect page",
+			})
+			return
+		}
+
+		comments, total, err := getter.GetComments(int64(taskID), userID, page)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't get comments", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't get comments",
+			})
+			return
+		}
+		log.Info("comments sent", slog.Int("taskID", taskID))
+		render.JSON(w, r, getAllResponse{
+			Comments: comments,
+			Total:    total,
+		})
+	}
+}
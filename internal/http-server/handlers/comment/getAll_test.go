@@ -0,0 +1,157 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_GetComments(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockComment, taskID, userID int64, page model.Page)
+
+	parentID := int64(1)
+	createdAt := time.Date(2000, 10, 10, 10, 10, 10, 0, time.UTC)
+
+	var tests = []struct {
+		name                 string
+		stringTaskID         string
+		query                string
+		taskID               int64
+		userID               int64
+		page                 model.Page
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:         "correct working",
+			stringTaskID: "4",
+			taskID:       4,
+			userID:       1,
+			page:         model.Page{Limit: 20},
+			mockBehavior: func(s *mock_service.MockComment, taskID, userID int64, page model.Page) {
+				s.EXPECT().GetComments(taskID, userID, page).Return([]model.Comment{
+					{
+						ID:        1,
+						TaskID:    4,
+						AuthorID:  1,
+						Text:      "what about tickets?",
+						CreatedAt: createdAt,
+						Replies: []model.Comment{
+							{ID: 2, TaskID: 4, AuthorID: 1, ParentID: &parentID, Text: "booked", CreatedAt: createdAt},
+						},
+					},
+				}, 3, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"comments":[{"id":1,"author_id":1,"text":"what about tickets?","created_at":"2000-10-10T10:10:10Z",` +
+				`"replies":[{"id":2,"author_id":1,"parent_id":1,"text":"booked","created_at":"2000-10-10T10:10:10Z"}]}],"total":3}`,
+		}, {
+			name:         "correct working: second page",
+			stringTaskID: "4",
+			query:        "?limit=1&offset=1",
+			taskID:       4,
+			userID:       1,
+			page:         model.Page{Limit: 1, Offset: 1},
+			mockBehavior: func(s *mock_service.MockComment, taskID, userID int64, page model.Page) {
+				s.EXPECT().GetComments(taskID, userID, page).Return([]model.Comment{}, 1, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"comments":[],"total":1}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockComment, taskID, userID int64, page model.Page) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect taskID",
+			stringTaskID:         "a4",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockComment, taskID, userID int64, page model.Page) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect task id record"}`,
+		}, {
+			name:                 "too big limit",
+			stringTaskID:         "4",
+			query:                "?limit=1000",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockComment, taskID, userID int64, page model.Page) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect page"}`,
+		}, {
+			name:                 "negative offset",
+			stringTaskID:         "4",
+			query:                "?offset=-1",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockComment, taskID, userID int64, page model.Page) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect page"}`,
+		}, {
+			name:         "incorrect GetComments return: no task",
+			stringTaskID: "4",
+			taskID:       4,
+			userID:       1,
+			page:         model.Page{Limit: 20},
+			mockBehavior: func(s *mock_service.MockComment, taskID, userID int64, page model.Page) {
+				s.EXPECT().GetComments(taskID, userID, page).Return(nil, 0, repositories.ErrNoTask)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no task with this taskID"}`,
+		}, {
+			name:         "incorrect GetComments return: internal server error",
+			stringTaskID: "4",
+			taskID:       4,
+			userID:       1,
+			page:         model.Page{Limit: 20},
+			mockBehavior: func(s *mock_service.MockComment, taskID, userID int64, page model.Page) {
+				s.EXPECT().GetComments(taskID, userID, page).Return(nil, 0, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't get comments"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			comment := mock_service.NewMockComment(ctrl)
+			test.mockBehavior(comment, test.taskID, test.userID, test.page)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/comments/", GetAll(logger, comment))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/comments/"+test.query, nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("taskId", test.stringTaskID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package comment
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+)
+
+type updateRequest struct {
+	Text string `json:"text"`
+}
+
+type updater interface {
+	UpdateComment(comment model.Comment, userID int64) error
+}
+
+// Update comment
+// @Summary Update
+// @Security ApiKeyPath
+// @Tags Comment
+// @Description Edit the text of a comment, only its author can do it
+// @ID updateComment
+// @Accept json
+// @Param task_id path int true "task ID"
+// @Param comment_id path int true "comment ID"
+// @Param input body updateRequest true "Comment text"
+// @Produce json
+// @Success 204
+// @Failure 400,401,403,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/comments/{commentId} [put]
+func Update(log *slog.Logger, updater updater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req updateRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		commentID, err := strconv.Atoi(chi.URLParam(r, "commentId"))
+		if err != nil {
+			log.Error("incorrect comment id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect comment id record",
+			})
+			return
+		}
+
+		comment := model.Comment{
+			ID:     int64(commentID),
+			TaskID: int64(taskID),
+			Text:   req.Text,
+		}
+		if !verification.Comment(comment) {
+			log.Error("incorrect comment information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect comment information",
+			})
+			return
+		}
+
+		err = updater.UpdateComment(comment, userID)
+		if errors.Is(err, repositories.ErrNoComment) {
+			log.Error("there is no comment", slog.Int("taskID", taskID), slog.Int("commentID", commentID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no comment with this commentID",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrCommentRights) {
+			log.Error("comment of another user", slog.Int64("userID", userID), slog.Int("commentID", commentID))
+			w.WriteHeader(http.StatusForbidden)
+			render.JSON(w, r, response.Message{
+				Msg: "only the author can edit the comment",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't update comment", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't update comment",
+			})
+			return
+		}
+		log.Info("comment updated", slog.Int("commentID", commentID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
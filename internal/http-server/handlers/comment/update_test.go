@@ -0,0 +1,140 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_UpdateComment(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockComment, comment model.Comment, userID int64)
+
+	var tests = []struct {
+		name                 string
+		inputBody            string
+		stringTaskID         string
+		stringCommentID      string
+		inputComment         model.Comment
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:            "correct working",
+			inputBody:       `{"text":"booked both ways"}`,
+			stringTaskID:    "4",
+			stringCommentID: "2",
+			inputComment:    model.Comment{ID: 2, TaskID: 4, Text: "booked both ways"},
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockComment, comment model.Comment, userID int64) {
+				s.EXPECT().UpdateComment(comment, userID).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockComment, comment model.Comment, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect commentID",
+			inputBody:            `{"text":"booked both ways"}`,
+			stringTaskID:         "4",
+			stringCommentID:      "b",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockComment, comment model.Comment, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect comment id record"}`,
+		}, {
+			name:                 "empty text",
+			inputBody:            `{"text":""}`,
+			stringTaskID:         "4",
+			stringCommentID:      "2",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockComment, comment model.Comment, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect comment information"}`,
+		}, {
+			name:            "incorrect UpdateComment return: no comment",
+			inputBody:       `{"text":"booked both ways"}`,
+			stringTaskID:    "4",
+			stringCommentID: "2",
+			inputComment:    model.Comment{ID: 2, TaskID: 4, Text: "booked both ways"},
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockComment, comment model.Comment, userID int64) {
+				s.EXPECT().UpdateComment(comment, userID).Return(repositories.ErrNoComment)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no comment with this commentID"}`,
+		}, {
+			name:            "incorrect UpdateComment return: not the author",
+			inputBody:       `{"text":"booked both ways"}`,
+			stringTaskID:    "4",
+			stringCommentID: "2",
+			inputComment:    model.Comment{ID: 2, TaskID: 4, Text: "booked both ways"},
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockComment, comment model.Comment, userID int64) {
+				s.EXPECT().UpdateComment(comment, userID).Return(repositories.ErrCommentRights)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"message":"only the author can edit the comment"}`,
+		}, {
+			name:            "incorrect UpdateComment return: internal server error",
+			inputBody:       `{"text":"booked both ways"}`,
+			stringTaskID:    "4",
+			stringCommentID: "2",
+			inputComment:    model.Comment{ID: 2, TaskID: 4, Text: "booked both ways"},
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockComment, comment model.Comment, userID int64) {
+				s.EXPECT().UpdateComment(comment, userID).Return(errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't update comment"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			comment := mock_service.NewMockComment(ctrl)
+			test.mockBehavior(comment, test.inputComment, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Put("/comments/", Update(logger, comment))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/comments/", bytes.NewBufferString(test.inputBody))
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("taskId", test.stringTaskID)
+			rctx.URLParams.Add("commentId", test.stringCommentID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
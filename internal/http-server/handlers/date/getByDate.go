@@ -7,6 +7,7 @@ import (
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"restAPI/internal/http-server/request"
 	"restAPI/internal/http-server/response"
 	"restAPI/internal/model"
 	"restAPI/pkg/lib/verification"
@@ -18,7 +19,7 @@ type getTaskResponse struct {
 }
 
 type getterByDate interface {
-	GetTasksByDate(day, month, year int, userID int64) ([]model.Task, error)
+	GetTasksByDate(day, month, year int, userID int64, filter model.TaskFilter) ([]model.Task, error)
 }
 
 // Get task by date
@@ -30,6 +31,9 @@ type getterByDate interface {
 // @Param year path int true "year"
 // @Param month path int true "month"
 // @Param day path int true "day"
+// @Param project query int false "project ID"
+// @Param sort query string false "manual for the hand-made order"
+// @Param include_deferred query bool false "also list the tasks snoozed until later"
 // @Produce json
 // @Success 200 {object} getTaskResponse
 // @Failure 400,401 {object} response.Message
@@ -68,7 +72,17 @@ func Get(log *slog.Logger, getterByDate getterByDate) http.HandlerFunc {
 		monthInt, _ := strconv.Atoi(month)
 		yearInt, _ := strconv.Atoi(year)
 
-		tasks, err := getterByDate.GetTasksByDate(dayInt, monthInt, yearInt, userID)
+		filter, err := request.TaskFilter(r)
+		if err != nil {
+			log.Error("incorrect filter", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect filter",
+			})
+			return
+		}
+
+		tasks, err := getterByDate.GetTasksByDate(dayInt, monthInt, yearInt, userID, filter)
 		if err != nil {
 			log.Error("get tasks by date", slog.String("error", err.Error()))
 			w.WriteHeader(http.StatusInternalServerError)
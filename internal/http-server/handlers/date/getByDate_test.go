@@ -40,7 +40,7 @@ func TestHandler_GetByTag(t *testing.T) {
 			userID: 1,
 
 			mockBehavior: func(s *mock_service.MockTask, day, month, year int, userID int64) {
-				s.EXPECT().GetTasksByDate(day, month, year, userID).Return([]model.Task{
+				s.EXPECT().GetTasksByDate(day, month, year, userID, model.TaskFilter{}).Return([]model.Task{
 					{
 						ID:      1,
 						Text:    "TestText",
@@ -57,7 +57,7 @@ func TestHandler_GetByTag(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"tasks":[{"text":"TestText","tags":["testTag","testTag2"],"date":"2000-10-10T10:10:10Z"},{"text":"TestText","tags":["testTag"],"date":"2000-10-10T10:10:10Z"}]}`,
+			expectedResponseBody: `{"tasks":[{"text":"TestText","tags":["testTag","testTag2"],"date":"2000-10-10T10:10:10Z","progress":{"done":0,"total":0},"comment_count":0},{"text":"TestText","tags":["testTag"],"date":"2000-10-10T10:10:10Z","progress":{"done":0,"total":0},"comment_count":0}]}`,
 		}, {
 			name:                 "incorrect userID",
 			userID:               -1,
@@ -80,7 +80,7 @@ func TestHandler_GetByTag(t *testing.T) {
 			inputMonth: 10,
 			inputDay:   10,
 			mockBehavior: func(s *mock_service.MockTask, day, month, year int, userID int64) {
-				s.EXPECT().GetTasksByDate(day, month, year, userID).Return(nil, errors.New("test"))
+				s.EXPECT().GetTasksByDate(day, month, year, userID, model.TaskFilter{}).Return(nil, errors.New("test"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"message":"couldn't find any tasks by date"}`,
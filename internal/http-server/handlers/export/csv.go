@@ -0,0 +1,92 @@
+package export
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/verification"
+	"strings"
+)
+
+const csvContentType = "text/csv; charset=utf-8"
+
+type csvExporter interface {
+	ExportCSV(userID int64, columns []string, tagDelimiter string) ([]byte, error)
+}
+
+// CSV export
+// @Summary CSV
+// @Security ApiKeyPath
+// @Tags Export
+// @Description Download all the user tasks, done and snoozed ones included, as a CSV file with a header row.
+// @Description Dates are written without a zone like "2024-03-08 18:00:00", completed_at in UTC as RFC 3339.
+// @ID exportCSV
+// @Produce text/csv
+// @Param columns query string false "comma separated columns, all by default: id,text,status,date,due,priority,tags,project_id,parent_id,estimate,recurrence,completed_at"
+// @Param tag_delimiter query string false "delimiter of the tags in their cell, ; by default"
+// @Success 200 {string} string "CSV file"
+// @Failure 400,401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /export/tasks.csv [get]
+func CSV(log *slog.Logger, exporter csvExporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		columns := model.CSVColumns
+		if query := r.URL.Query().Get("columns"); query != "" {
+			columns = strings.Split(query, ",")
+		}
+		if !verification.CSVColumns(columns) {
+			log.Error("incorrect columns", slog.Any("columns", columns))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect columns",
+			})
+			return
+		}
+
+		tagDelimiter := model.DefaultTagDelimiter
+		if r.URL.Query().Has("tag_delimiter") {
+			tagDelimiter = r.URL.Query().Get("tag_delimiter")
+		}
+		if !verification.TagDelimiter(tagDelimiter) {
+			log.Error("incorrect tag delimiter", slog.String("tag_delimiter", tagDelimiter))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect tag delimiter",
+			})
+			return
+		}
+
+		file, err := exporter.ExportCSV(userID, columns, tagDelimiter)
+		if err != nil {
+			log.Error("can't export tasks", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't export tasks",
+			})
+			return
+		}
+		log.Info("tasks exported", slog.String("format", "csv"))
+		w.Header().Set("Content-Type", csvContentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="tasks.csv"`)
+		_, _ = w.Write(file)
+	}
+}
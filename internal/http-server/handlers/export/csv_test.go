@@ -0,0 +1,147 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_CSV(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockExport, userID int64)
+
+	file := "text,tags\nPay rent,finance|home\n"
+
+	var tests = []struct {
+		name                 string
+		query                string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			query:  "?columns=text,tags&tag_delimiter=|",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockExport, userID int64) {
+				s.EXPECT().ExportCSV(userID, []string{"text", "tags"}, "|").Return([]byte(file), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "text/csv; charset=utf-8",
+			expectedResponseBody: file,
+		}, {
+			name:   "all columns by default",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockExport, userID int64) {
+				s.EXPECT().ExportCSV(userID, model.CSVColumns, ";").Return([]byte(file), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "text/csv; charset=utf-8",
+			expectedResponseBody: file,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockExport, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `{"message":"failed to get auth id"}` + "\n",
+		}, {
+			name:                 "incorrect columns",
+			query:                "?columns=text,title",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockExport, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `{"message":"incorrect columns"}` + "\n",
+		}, {
+			name:                 "incorrect tag delimiter",
+			query:                "?tag_delimiter=",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockExport, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `{"message":"incorrect tag delimiter"}` + "\n",
+		}, {
+			name:   "incorrect ExportCSV return: internal server error",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockExport, userID int64) {
+				s.EXPECT().ExportCSV(userID, model.CSVColumns, ";").Return(nil, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `{"message":"can't export tasks"}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			exporter := mock_service.NewMockExport(ctrl)
+			test.mockBehavior(exporter, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/export/tasks", CSV(logger, exporter))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/export/tasks"+test.query, nil)
+
+			rctx := chi.NewRouteContext()
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), test.expectedContentType))
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestFormats(t *testing.T) {
+	handler := func(format string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(format))
+		}
+	}
+	router := chi.NewRouter()
+	router.Use(middleware.URLFormat)
+	router.Get("/export/tasks", Formats(map[string]http.HandlerFunc{
+		"ics": handler("ics"),
+		"csv": handler("csv"),
+	}))
+
+	for path, want := range map[string]string{
+		"/export/tasks.ics": "ics",
+		"/export/tasks.csv": "csv",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, want, w.Body.String())
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export/tasks.pdf", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `{"message":"unknown export format"}`+"\n", w.Body.String())
+}
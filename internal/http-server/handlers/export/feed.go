@@ -0,0 +1,182 @@
+package export
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/repositories"
+)
+
+type feedResponse struct {
+	Token string `json:"token"`
+	// URL is the path of the feed, calendar apps subscribe to it
+	URL string `json:"url"`
+}
+
+type feedRotater interface {
+	RotateFeedToken(userID int64) (string, error)
+}
+
+type feedRevoker interface {
+	RevokeFeedToken(userID int64) error
+}
+
+type feedReader interface {
+	FeedUser(token string) (int64, error)
+	ExportICS(userID int64) ([]byte, error)
+}
+
+// RotateFeed token
+// @Summary RotateFeed
+// @Security ApiKeyPath
+// @Tags Export
+// @Description Make a new secret subscription URL for the user tasks, the previous one stops working.
+// @Description The token can't be read back later, rotate it again if it is lost.
+// @ID rotateFeed
+// @Produce json
+// @Success 201 {object} feedResponse
+// @Failure 401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /export/feed [post]
+func RotateFeed(log *slog.Logger, rotater feedRotater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		token, err := rotater.RotateFeedToken(userID)
+		if err != nil {
+			log.Error("can't rotate feed token", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't rotate feed token",
+			})
+			return
+		}
+		log.Info("feed token rotated", slog.Int64("userID", userID))
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, feedResponse{
+			Token: token,
+			URL:   "/feeds/" + token + "/tasks.ics",
+		})
+	}
+}
+
+// RevokeFeed token
+// @Summary RevokeFeed
+// @Security ApiKeyPath
+// @Tags Export
+// @Description Stop the subscription URL of the user tasks from working
+// @ID revokeFeed
+// @Produce json
+// @Success 204
+// @Failure 401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /export/feed [delete]
+func RevokeFeed(log *slog.Logger, revoker feedRevoker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		err := revoker.RevokeFeedToken(userID)
+		if errors.Is(err, repositories.ErrNoFeed) {
+			log.Error("there is no feed", slog.Int64("userID", userID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no feed for this user",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't revoke feed token", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't revoke feed token",
+			})
+			return
+		}
+		log.Info("feed token revoked", slog.Int64("userID", userID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Feed of tasks
+// @Summary Feed
+// @Tags Export
+// @Description Get the user tasks as iCalendar VTODO entries, for calendar apps to poll.
+// @Description The secret token of the path stands for the user, no JWT is needed.
+// @ID getFeed
+// @Param token path string true "feed token"
+// @Produce text/calendar
+// @Success 200 {string} string "iCalendar file"
+// @Failure 404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /feeds/{token}/tasks.ics [get]
+func Feed(log *slog.Logger, reader feedReader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID, err := reader.FeedUser(chi.URLParam(r, "token"))
+		if errors.Is(err, repositories.ErrNoFeed) {
+			log.Error("there is no feed")
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no feed with this token",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't get feed user", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't get feed",
+			})
+			return
+		}
+
+		calendar, err := reader.ExportICS(userID)
+		if err != nil {
+			log.Error("can't export tasks", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't get feed",
+			})
+			return
+		}
+		log.Info("feed sent", slog.Int64("userID", userID))
+		w.Header().Set("Content-Type", icsContentType)
+		_, _ = w.Write(calendar)
+	}
+}
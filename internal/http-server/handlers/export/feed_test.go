@@ -0,0 +1,241 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_RotateFeed(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockFeed, userID int64)
+
+	var tests = []struct {
+		name                 string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockFeed, userID int64) {
+				s.EXPECT().RotateFeedToken(userID).Return("s3cr3t", nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"token":"s3cr3t","url":"/feeds/s3cr3t/tasks.ics"}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockFeed, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:   "incorrect RotateFeedToken return: internal server error",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockFeed, userID int64) {
+				s.EXPECT().RotateFeedToken(userID).Return("", errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't rotate feed token"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			feed := mock_service.NewMockFeed(ctrl)
+			test.mockBehavior(feed, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/export/feed", RotateFeed(logger, feed))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/export/feed", nil)
+
+			rctx := chi.NewRouteContext()
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_RevokeFeed(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockFeed, userID int64)
+
+	var tests = []struct {
+		name                 string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockFeed, userID int64) {
+				s.EXPECT().RevokeFeedToken(userID).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockFeed, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:   "no feed",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockFeed, userID int64) {
+				s.EXPECT().RevokeFeedToken(userID).Return(fmt.Errorf("DeleteFeedToken: %w", repositories.ErrNoFeed))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no feed for this user"}`,
+		}, {
+			name:   "incorrect RevokeFeedToken return: internal server error",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockFeed, userID int64) {
+				s.EXPECT().RevokeFeedToken(userID).Return(errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't revoke feed token"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			feed := mock_service.NewMockFeed(ctrl)
+			test.mockBehavior(feed, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Delete("/export/feed", RevokeFeed(logger, feed))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, "/export/feed", nil)
+
+			rctx := chi.NewRouteContext()
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_Feed(t *testing.T) {
+	type MockBehavior func(f *mock_service.MockFeed, e *mock_service.MockExport, token string)
+
+	calendar := "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"
+
+	var tests = []struct {
+		name                 string
+		token                string
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "correct working",
+			token: "s3cr3t",
+			mockBehavior: func(f *mock_service.MockFeed, e *mock_service.MockExport, token string) {
+				f.EXPECT().FeedUser(token).Return(int64(7), nil)
+				e.EXPECT().ExportICS(int64(7)).Return([]byte(calendar), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: calendar,
+		}, {
+			name:  "unknown token",
+			token: "revoked",
+			mockBehavior: func(f *mock_service.MockFeed, e *mock_service.MockExport, token string) {
+				f.EXPECT().FeedUser(token).Return(int64(0), fmt.Errorf("GetFeedUser: %w", repositories.ErrNoFeed))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no feed with this token"}` + "\n",
+		}, {
+			name:  "incorrect FeedUser return: internal server error",
+			token: "s3cr3t",
+			mockBehavior: func(f *mock_service.MockFeed, e *mock_service.MockExport, token string) {
+				f.EXPECT().FeedUser(token).Return(int64(0), errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't get feed"}` + "\n",
+		}, {
+			name:  "incorrect ExportICS return: internal server error",
+			token: "s3cr3t",
+			mockBehavior: func(f *mock_service.MockFeed, e *mock_service.MockExport, token string) {
+				f.EXPECT().FeedUser(token).Return(int64(7), nil)
+				e.EXPECT().ExportICS(int64(7)).Return(nil, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't get feed"}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			feed := mock_service.NewMockFeed(ctrl)
+			exporter := mock_service.NewMockExport(ctrl)
+			test.mockBehavior(feed, exporter, test.token)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/feeds/tasks", Feed(logger, struct {
+				*mock_service.MockFeed
+				*mock_service.MockExport
+			}{feed, exporter}))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/feeds/tasks", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("token", test.token)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
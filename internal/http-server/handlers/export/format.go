@@ -0,0 +1,26 @@
+package export
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"net/http"
+	"restAPI/internal/http-server/response"
+)
+
+// Formats serves each format of a file with its own handler. The URLFormat
+// middleware takes the extension off the path before routing, so all the
+// formats share a route.
+func Formats(handlers map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format, _ := r.Context().Value(middleware.URLFormatCtxKey).(string)
+		handler, ok := handlers[format]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "unknown export format",
+			})
+			return
+		}
+		handler(w, r)
+	}
+}
@@ -0,0 +1,61 @@
+package export
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+)
+
+const icsContentType = "text/calendar; charset=utf-8"
+
+type icsExporter interface {
+	ExportICS(userID int64) ([]byte, error)
+}
+
+// ICS export
+// @Summary ICS
+// @Security ApiKeyPath
+// @Tags Export
+// @Description Download all the user tasks, done and snoozed ones included, as iCalendar VTODO entries.
+// @Description Dates and due dates are floating times, tags become categories and repeat rules RRULEs.
+// @ID exportICS
+// @Produce text/calendar
+// @Success 200 {string} string "iCalendar file"
+// @Failure 401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /export/tasks.ics [get]
+func ICS(log *slog.Logger, exporter icsExporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		calendar, err := exporter.ExportICS(userID)
+		if err != nil {
+			log.Error("can't export tasks", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't export tasks",
+			})
+			return
+		}
+		log.Info("tasks exported", slog.String("format", "ics"))
+		w.Header().Set("Content-Type", icsContentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="tasks.ics"`)
+		_, _ = w.Write(calendar)
+	}
+}
@@ -0,0 +1,62 @@
+package export
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+)
+
+const todoTxtContentType = "text/plain; charset=utf-8"
+
+type todoTxtExporter interface {
+	ExportTodoTxt(userID int64) ([]byte, error)
+}
+
+// TodoTxt export
+// @Summary todo.txt
+// @Security ApiKeyPath
+// @Tags Export
+// @Description Download all the user tasks, done and snoozed ones included, as a todo.txt file with a line per task.
+// @Description Tags starting with "+" are written as +projects, the others as @contexts. The fields todo.txt has
+// @Description no place for go in extensions like id:3 due:2024-03-12 rec:FREQ=WEEKLY, so the file can be synced back.
+// @ID exportTodoTxt
+// @Produce plain
+// @Success 200 {string} string "todo.txt file"
+// @Failure 401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /export/tasks.txt [get]
+func TodoTxt(log *slog.Logger, exporter todoTxtExporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		file, err := exporter.ExportTodoTxt(userID)
+		if err != nil {
+			log.Error("can't export tasks", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't export tasks",
+			})
+			return
+		}
+		log.Info("tasks exported", slog.String("format", "todo.txt"))
+		w.Header().Set("Content-Type", todoTxtContentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="todo.txt"`)
+		_, _ = w.Write(file)
+	}
+}
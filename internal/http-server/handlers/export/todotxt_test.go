@@ -0,0 +1,89 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_TodoTxt(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockExport, userID int64)
+
+	file := "(A) 2024-03-01 Call mom +family @phone id:3\nx 2024-03-10 2024-03-02 Pay rent id:4\n"
+
+	var tests = []struct {
+		name                 string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockExport, userID int64) {
+				s.EXPECT().ExportTodoTxt(userID).Return([]byte(file), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "text/plain; charset=utf-8",
+			expectedResponseBody: file,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockExport, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `{"message":"failed to get auth id"}` + "\n",
+		}, {
+			name:   "incorrect ExportTodoTxt return: internal server error",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockExport, userID int64) {
+				s.EXPECT().ExportTodoTxt(userID).Return(nil, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `{"message":"can't export tasks"}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			exporter := mock_service.NewMockExport(ctrl)
+			test.mockBehavior(exporter, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/export/tasks", TodoTxt(logger, exporter))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/export/tasks", nil)
+
+			rctx := chi.NewRouteContext()
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), test.expectedContentType))
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/service"
+	"restAPI/pkg/lib/verification"
+	"time"
+)
+
+type csvImporter interface {
+	ImportCSV(userID int64, file io.Reader, options model.CSVImport, loc *time.Location) (model.ImportReport, error)
+}
+
+// CSV import
+// @Summary CSV
+// @Security ApiKeyPath
+// @Tags Import
+// @Description Create tasks from the rows of a CSV file, sent in the "file" field of a multipart form.
+// @Description Headers named after a column of the CSV export are read into it, the "mapping" field maps the others
+// @Description as a JSON object like {"Title":"text"}; the remaining headers are ignored and a text column is required.
+// @Description Rows without a date are dated now. A dry run only reports what would be created, with the tasks.
+// @Description An atomic import creates no task unless every row can be imported.
+// @ID importCSV
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Param mapping formData string false "JSON object mapping headers to columns"
+// @Param tag_delimiter query string false "delimiter of the tags in their cell, ; by default"
+// @Param dry_run query bool false "only report what would be created"
+// @Param atomic query bool false "import all the rows or none"
+// @Param tz query string false "IANA time zone of the user, UTC by default"
+// @Success 200 {object} model.ImportReport
+// @Failure 400,401,413 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /import/csv [post]
+func CSV(log *slog.Logger, importer csvImporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		options := model.CSVImport{TagDelimiter: model.DefaultTagDelimiter}
+		if r.URL.Query().Has("tag_delimiter") {
+			options.TagDelimiter = r.URL.Query().Get("tag_delimiter")
+		}
+		if !verification.TagDelimiter(options.TagDelimiter) {
+			log.Error("incorrect tag delimiter", slog.String("tag_delimiter", options.TagDelimiter))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect tag delimiter",
+			})
+			return
+		}
+
+		var err error
+		options.DryRun, err = request.Flag(r, "dry_run")
+		if err != nil {
+			log.Error("incorrect dry_run", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect dry_run",
+			})
+			return
+		}
+		options.Atomic, err = request.Flag(r, "atomic")
+		if err != nil {
+			log.Error("incorrect atomic", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect atomic",
+			})
+			return
+		}
+
+		loc, err := request.Location(r)
+		if err != nil {
+			log.Error("incorrect time zone", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect time zone",
+			})
+			return
+		}
+
+		data, fields, ok := readImport(log, w, r)
+		if !ok {
+			return
+		}
+
+		if mapping, found := fields["mapping"]; found {
+			err = json.Unmarshal([]byte(mapping), &options.Mapping)
+			if err != nil || !verification.CSVMapping(options.Mapping) {
+				log.Error("incorrect mapping", slog.String("mapping", mapping))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, response.Message{
+					Msg: "incorrect mapping",
+				})
+				return
+			}
+		}
+
+		report, err := importer.ImportCSV(userID, bytes.NewReader(data), options, loc)
+		if errors.Is(err, service.ErrCSV) {
+			log.Error("incorrect csv", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect csv",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't import csv", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't import csv",
+			})
+			return
+		}
+		log.Info("csv imported", slog.Int("created", report.Created), slog.Int("invalid", report.Invalid),
+			slog.Bool("dryRun", options.DryRun))
+
+		render.JSON(w, r, report)
+	}
+}
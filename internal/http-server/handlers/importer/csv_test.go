@@ -0,0 +1,163 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/service"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_CSV(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockImport, data []byte)
+
+	file := []byte("Title,tags\nPay rent,finance\n")
+	readsFile := func(data []byte) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			file, err := io.ReadAll(x.(io.Reader))
+			return err == nil && bytes.Equal(file, data)
+		})
+	}
+
+	var tests = []struct {
+		name                 string
+		query                string
+		fields               map[string]string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			query:  "?dry_run=true&atomic=true&tag_delimiter=|",
+			fields: map[string]string{"mapping": `{"Title":"text"}`},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				options := model.CSVImport{
+					Mapping:      map[string]string{"Title": "text"},
+					TagDelimiter: "|",
+					DryRun:       true,
+					Atomic:       true,
+				}
+				s.EXPECT().ImportCSV(int64(1), readsFile(data), options, time.UTC).Return(model.ImportReport{
+					DryRun:  true,
+					Created: 1,
+					Invalid: 1,
+					Items: []model.ImportItem{
+						{Ref: "row 2", Text: "Pay rent", Status: model.ImportCreated, Task: &model.Task{
+							Text: "Pay rent",
+							Tags: []string{"finance"},
+							Date: time.Date(2024, time.March, 8, 18, 0, 0, 0, time.UTC),
+						}},
+						{Ref: "row 3", Status: model.ImportInvalid, Reason: "no text"},
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"dry_run":true,"created":1,"duplicates":0,"skipped":0,"invalid":1,"items":[` +
+				`{"ref":"row 2","text":"Pay rent","status":"created","task":{"text":"Pay rent","tags":["finance"],"date":"2024-03-08T18:00:00Z","progress":{"done":0,"total":0},"comment_count":0}},` +
+				`{"ref":"row 3","text":"","status":"invalid","reason":"no text"}]}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect tag delimiter",
+			query:                "?tag_delimiter=",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect tag delimiter"}`,
+		}, {
+			name:                 "incorrect dry_run",
+			query:                "?dry_run=maybe",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect dry_run"}`,
+		}, {
+			name:                 "incorrect atomic",
+			query:                "?atomic=2",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect atomic"}`,
+		}, {
+			name:                 "incorrect mapping: not json",
+			fields:               map[string]string{"mapping": "Title=text"},
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect mapping"}`,
+		}, {
+			name:                 "incorrect mapping: unknown column",
+			fields:               map[string]string{"mapping": `{"Title":"name"}`},
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect mapping"}`,
+		}, {
+			name:   "incorrect ImportCSV return: incorrect csv",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				s.EXPECT().ImportCSV(int64(1), readsFile(data), model.CSVImport{TagDelimiter: ";"}, time.UTC).
+					Return(model.ImportReport{}, fmt.Errorf("%w: no text column", service.ErrCSV))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect csv"}`,
+		}, {
+			name:   "incorrect ImportCSV return: internal server error",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				s.EXPECT().ImportCSV(int64(1), readsFile(data), model.CSVImport{TagDelimiter: ";"}, time.UTC).
+					Return(model.ImportReport{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't import csv"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			importer := mock_service.NewMockImport(ctrl)
+			test.mockBehavior(importer, file)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/import/csv", CSV(logger, importer))
+
+			body, contentType := multipartBody(t, "file", file, test.fields)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/import/csv"+test.query, body)
+			r.Header.Set("Content-Type", contentType)
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package importer
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+)
+
+const (
+	// maxImportSize bounds the imported files.
+	maxImportSize = 5 << 20
+	// maxFieldSize bounds the other fields of the form.
+	maxFieldSize = 64 << 10
+	// multipartOverhead leaves room for the boundaries and part headers around the file.
+	multipartOverhead = 1 << 20
+)
+
+var (
+	errTooLarge = errors.New("file is too large")
+	errNoFile   = errors.New("no file in the form")
+)
+
+// readImport reads the imported file and the other fields of the form, or
+// answers why it can't.
+func readImport(log *slog.Logger, w http.ResponseWriter, r *http.Request) ([]byte, map[string]string, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize+multipartOverhead)
+	data, fields, err := readForm(r)
+	var maxBytesErr *http.MaxBytesError
+	if errors.Is(err, errTooLarge) || errors.As(err, &maxBytesErr) {
+		log.Error("file is too large", slog.Int("maxSize", maxImportSize))
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		render.JSON(w, r, response.Message{
+			Msg: "file is too large",
+		})
+		return nil, nil, false
+	}
+	if err != nil {
+		log.Error("failed to read file", slog.String("error", err.Error()))
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, response.Message{
+			Msg: "failed to read file",
+		})
+		return nil, nil, false
+	}
+	return data, fields, true
+}
+
+// readForm returns the content of the "file" form field and the values of
+// the others.
+func readForm(r *http.Request) ([]byte, map[string]string, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, nil, err
+	}
+	var data []byte
+	fields := make(map[string]string)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		limit := maxFieldSize
+		if part.FormName() == "file" {
+			limit = maxImportSize
+		}
+		value, err := io.ReadAll(io.LimitReader(part, int64(limit)+1))
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(value) > limit {
+			return nil, nil, errTooLarge
+		}
+		if part.FormName() == "file" {
+			data = value
+		} else {
+			fields[part.FormName()] = string(value)
+		}
+	}
+	if data == nil {
+		return nil, nil, errNoFile
+	}
+	return data, fields, nil
+}
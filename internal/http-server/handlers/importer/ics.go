@@ -0,0 +1,103 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/ical"
+	"time"
+)
+
+type icsImporter interface {
+	ImportICS(userID int64, calendar io.Reader, events bool, loc *time.Location) (model.ImportReport, error)
+}
+
+// ICS import
+// @Summary ICS
+// @Security ApiKeyPath
+// @Tags Import
+// @Description Create tasks from the VTODO entries of an iCalendar file, sent in the "file" field of a multipart form.
+// @Description Categories become tags, DTSTART the date, DUE the due date and RRULE the repeat rule when it is supported.
+// @Description Entries with a UID already imported, or exported from here, are reported as duplicates.
+// @ID importICS
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "iCalendar file"
+// @Param include_events query bool false "also import the VEVENT entries"
+// @Param tz query string false "IANA time zone of the times without one, UTC by default"
+// @Success 200 {object} model.ImportReport
+// @Failure 400,401,413 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /import/ics [post]
+func ICS(log *slog.Logger, importer icsImporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		events, err := request.Flag(r, "include_events")
+		if err != nil {
+			log.Error("incorrect include_events", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect include_events",
+			})
+			return
+		}
+
+		loc, err := request.Location(r)
+		if err != nil {
+			log.Error("incorrect time zone", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect time zone",
+			})
+			return
+		}
+
+		data, _, ok := readImport(log, w, r)
+		if !ok {
+			return
+		}
+
+		report, err := importer.ImportICS(userID, bytes.NewReader(data), events, loc)
+		if errors.Is(err, ical.ErrCalendar) {
+			log.Error("incorrect calendar", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect calendar",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't import calendar", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't import calendar",
+			})
+			return
+		}
+		log.Info("calendar imported", slog.Int("created", report.Created), slog.Int("duplicates", report.Duplicates))
+
+		render.JSON(w, r, report)
+	}
+}
@@ -0,0 +1,177 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	mock_service "restAPI/internal/service/mocks"
+	"restAPI/pkg/lib/ical"
+	"strings"
+	"testing"
+	"time"
+)
+
+func multipartBody(t *testing.T, field string, data []byte, fields map[string]string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		writer.WriteField(name, value)
+	}
+	part, err := writer.CreateFormFile(field, "tasks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data)
+	writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+func TestHandler_ICS(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockImport, data []byte)
+
+	calendar := []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	readsCalendar := func(data []byte) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			calendar, err := io.ReadAll(x.(io.Reader))
+			return err == nil && bytes.Equal(calendar, data)
+		})
+	}
+
+	var tests = []struct {
+		name                 string
+		query                string
+		field                string
+		data                 []byte
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			query:  "?include_events=true&tz=Europe/Moscow",
+			field:  "file",
+			data:   calendar,
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				s.EXPECT().ImportICS(int64(1), readsCalendar(data), true, moscow).Return(model.ImportReport{
+					Created:    1,
+					Duplicates: 1,
+					Items: []model.ImportItem{
+						{Ref: "a@example.com", Text: "Pay rent", Status: model.ImportCreated, TaskID: 8},
+						{Ref: "task-3@restapi", Text: "Call mom", Status: model.ImportDuplicate, TaskID: 3},
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"created":1,"duplicates":1,"skipped":0,"invalid":0,"items":[` +
+				`{"ref":"a@example.com","text":"Pay rent","status":"created","task_id":8},` +
+				`{"ref":"task-3@restapi","text":"Call mom","status":"duplicate","task_id":3}]}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect include_events",
+			query:                "?include_events=sometimes",
+			field:                "file",
+			data:                 calendar,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect include_events"}`,
+		}, {
+			name:                 "incorrect time zone",
+			query:                "?tz=Mars/Olympus",
+			field:                "file",
+			data:                 calendar,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect time zone"}`,
+		}, {
+			name:                 "no file field",
+			field:                "calendar",
+			data:                 calendar,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"failed to read file"}`,
+		}, {
+			name:                 "too large file",
+			field:                "file",
+			data:                 bytes.Repeat([]byte("a"), maxImportSize+1),
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusRequestEntityTooLarge,
+			expectedResponseBody: `{"message":"file is too large"}`,
+		}, {
+			name:   "incorrect ImportICS return: incorrect calendar",
+			field:  "file",
+			data:   []byte("BEGIN:VCALENDAR\r\n"),
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				s.EXPECT().ImportICS(int64(1), readsCalendar(data), false, time.UTC).
+					Return(model.ImportReport{}, fmt.Errorf("%w: VCALENDAR is not ended", ical.ErrCalendar))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect calendar"}`,
+		}, {
+			name:   "incorrect ImportICS return: internal server error",
+			field:  "file",
+			data:   calendar,
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				s.EXPECT().ImportICS(int64(1), readsCalendar(data), false, time.UTC).
+					Return(model.ImportReport{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't import calendar"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			importer := mock_service.NewMockImport(ctrl)
+			test.mockBehavior(importer, test.data)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/import/ics", ICS(logger, importer))
+
+			body, contentType := multipartBody(t, test.field, test.data, nil)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/import/ics"+test.query, body)
+			r.Header.Set("Content-Type", contentType)
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/internal/service"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+	"time"
+)
+
+type jobStarter interface {
+	StartImportJob(userID int64, source string, file io.Reader, loc *time.Location) (model.ImportJob, error)
+}
+
+type jobGetter interface {
+	GetImportJob(jobID, userID int64) (model.ImportJob, error)
+}
+
+// StartJob import job
+// @Summary Start
+// @Security ApiKeyPath
+// @Tags Import
+// @Description Import the JSON export of a Todoist account or of a Trello board, sent in the "file" field of a
+// @Description multipart form, in the background. Follow the job at the Location of the answer.
+// @Description Todoist projects, Trello boards become projects; labels, and the lists of the cards, become tags;
+// @Description sub-tasks become subtasks, Trello checklists checklists, and notes, descriptions and comments comments.
+// @Description Records imported by an earlier job are duplicates, so uploading a file again only adds what's new.
+// @Description A user runs one import at a time.
+// @ID startImportJob
+// @Accept mpfd
+// @Produce json
+// @Param source path string true "app of the export" Enums(todoist, trello)
+// @Param file formData file true "JSON export"
+// @Param tz query string false "IANA time zone of the user, UTC by default"
+// @Success 202 {object} model.ImportJob
+// @Failure 400,401,404,409,413 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /imports/{source} [post]
+func StartJob(log *slog.Logger, starter jobStarter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		source := chi.URLParam(r, "source")
+		if !verification.ImportSource(source) {
+			log.Error("unknown import source", slog.String("source", source))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "unknown import source",
+			})
+			return
+		}
+
+		loc, err := request.Location(r)
+		if err != nil {
+			log.Error("incorrect time zone", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect time zone",
+			})
+			return
+		}
+
+		data, _, ok := readImport(log, w, r)
+		if !ok {
+			return
+		}
+
+		job, err := starter.StartImportJob(userID, source, bytes.NewReader(data), loc)
+		if errors.Is(err, service.ErrExportFile) {
+			log.Error("incorrect export file", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect export file",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrImportRunning) {
+			log.Error("another import is running", slog.Int64("userID", userID))
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, response.Message{
+				Msg: "another import is already running",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't start import", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't start import",
+			})
+			return
+		}
+		log.Info("import started", slog.Int64("jobID", job.ID), slog.String("source", source),
+			slog.Int("total", job.Total))
+
+		w.Header().Set("Location", fmt.Sprintf("/imports/%d", job.ID))
+		w.WriteHeader(http.StatusAccepted)
+		render.JSON(w, r, job)
+	}
+}
+
+// GetJob import job
+// @Summary Get
+// @Security ApiKeyPath
+// @Tags Import
+// @Description Get the progress of an import job, done out of total records, and its report once it is done.
+// @Description A failed job keeps what it imported, uploading the file again goes on from there.
+// @ID getImportJob
+// @Produce json
+// @Param import_id path int true "import job ID"
+// @Success 200 {object} model.ImportJob
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /imports/{importId} [get]
+func GetJob(log *slog.Logger, getter jobGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		jobID, err := strconv.Atoi(chi.URLParam(r, "importId"))
+		if err != nil {
+			log.Error("incorrect import id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect import id record",
+			})
+			return
+		}
+
+		job, err := getter.GetImportJob(int64(jobID), userID)
+		if errors.Is(err, repositories.ErrNoImportJob) {
+			log.Error("there is no import job", slog.Int64("userID", userID), slog.Int("jobID", jobID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no import with this importID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't get import", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't get import",
+			})
+			return
+		}
+		log.Info("import job got by id", slog.Int64("jobID", job.ID), slog.String("status", job.Status))
+
+		render.JSON(w, r, job)
+	}
+}
@@ -0,0 +1,255 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/internal/service"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_StartJob(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockImportJob, data []byte)
+
+	file := []byte(`{"projects": [], "items": []}`)
+	created := time.Date(2024, time.March, 8, 18, 0, 0, 0, time.UTC)
+	readsFile := func(data []byte) gomock.Matcher {
+		return readsTodoTxt(data)
+	}
+
+	var tests = []struct {
+		name                 string
+		source               string
+		query                string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedLocation     string
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			source: "todoist",
+			query:  "?tz=UTC",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImportJob, data []byte) {
+				s.EXPECT().StartImportJob(int64(1), "todoist", readsFile(data), time.UTC).Return(model.ImportJob{
+					ID:        3,
+					Source:    "todoist",
+					Status:    model.ImportJobRunning,
+					Total:     12,
+					CreatedAt: created,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusAccepted,
+			expectedLocation:   "/imports/3",
+			expectedResponseBody: `{"id":3,"source":"todoist","status":"running","done":0,"total":12,` +
+				`"created_at":"2024-03-08T18:00:00Z"}`,
+		}, {
+			name:                 "incorrect userID",
+			source:               "todoist",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockImportJob, data []byte) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "unknown source",
+			source:               "asana",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImportJob, data []byte) {},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"unknown import source"}`,
+		}, {
+			name:                 "incorrect time zone",
+			source:               "trello",
+			query:                "?tz=Mars/Olympus",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImportJob, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect time zone"}`,
+		}, {
+			name:   "incorrect StartImportJob return: incorrect export file",
+			source: "trello",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImportJob, data []byte) {
+				s.EXPECT().StartImportJob(int64(1), "trello", readsFile(data), time.UTC).
+					Return(model.ImportJob{}, fmt.Errorf("%w: no board id or cards", service.ErrExportFile))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect export file"}`,
+		}, {
+			name:   "incorrect StartImportJob return: another import is running",
+			source: "todoist",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImportJob, data []byte) {
+				s.EXPECT().StartImportJob(int64(1), "todoist", readsFile(data), time.UTC).
+					Return(model.ImportJob{}, repositories.ErrImportRunning)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"another import is already running"}`,
+		}, {
+			name:   "incorrect StartImportJob return: internal server error",
+			source: "todoist",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImportJob, data []byte) {
+				s.EXPECT().StartImportJob(int64(1), "todoist", readsFile(data), time.UTC).
+					Return(model.ImportJob{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't start import"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			starter := mock_service.NewMockImportJob(ctrl)
+			test.mockBehavior(starter, file)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/imports/{source}", StartJob(logger, starter))
+
+			body, contentType := multipartBody(t, "file", file, nil)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/imports/"+test.source+test.query, body)
+			r.Header.Set("Content-Type", contentType)
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedLocation, w.Header().Get("Location"))
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_GetJob(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockImportJob, jobID, userID int64)
+
+	created := time.Date(2024, time.March, 8, 18, 0, 0, 0, time.UTC)
+	finished := created.Add(time.Minute)
+
+	var tests = []struct {
+		name                 string
+		inputID              string
+		jobID                int64
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:    "correct working",
+			inputID: "3",
+			jobID:   3,
+			userID:  1,
+			mockBehavior: func(s *mock_service.MockImportJob, jobID, userID int64) {
+				s.EXPECT().GetImportJob(jobID, userID).Return(model.ImportJob{
+					ID:     3,
+					Source: "trello",
+					Status: model.ImportJobDone,
+					Done:   3,
+					Total:  3,
+					Report: &model.ImportJobReport{
+						Projects: model.ImportCounts{Created: 1},
+						Tasks:    model.ImportCounts{Created: 1, Skipped: 1},
+						Problems: []model.ImportItem{
+							{Ref: "task c2", Text: "Old card", Status: model.ImportSkipped, Reason: "archived in trello"},
+						},
+					},
+					CreatedAt:  created,
+					FinishedAt: &finished,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"id":3,"source":"trello","status":"done","done":3,"total":3,"report":{` +
+				`"projects":{"created":1,"duplicates":0,"skipped":0,"invalid":0},` +
+				`"tasks":{"created":1,"duplicates":0,"skipped":1,"invalid":0},` +
+				`"items":{"created":0,"duplicates":0,"skipped":0,"invalid":0},` +
+				`"comments":{"created":0,"duplicates":0,"skipped":0,"invalid":0},` +
+				`"problems":[{"ref":"task c2","text":"Old card","status":"skipped","reason":"archived in trello"}]},` +
+				`"created_at":"2024-03-08T18:00:00Z","finished_at":"2024-03-08T18:01:00Z"}`,
+		}, {
+			name:                 "incorrect userID",
+			inputID:              "3",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockImportJob, jobID, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect import id",
+			inputID:              "last",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImportJob, jobID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect import id record"}`,
+		}, {
+			name:    "incorrect GetImportJob return: no import",
+			inputID: "4",
+			jobID:   4,
+			userID:  1,
+			mockBehavior: func(s *mock_service.MockImportJob, jobID, userID int64) {
+				s.EXPECT().GetImportJob(jobID, userID).Return(model.ImportJob{}, repositories.ErrNoImportJob)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no import with this importID"}`,
+		}, {
+			name:    "incorrect GetImportJob return: internal server error",
+			inputID: "4",
+			jobID:   4,
+			userID:  1,
+			mockBehavior: func(s *mock_service.MockImportJob, jobID, userID int64) {
+				s.EXPECT().GetImportJob(jobID, userID).Return(model.ImportJob{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't get import"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			getter := mock_service.NewMockImportJob(ctrl)
+			test.mockBehavior(getter, test.jobID, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/imports/{importId}", GetJob(logger, getter))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/imports/"+test.inputID, nil)
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/service"
+	"time"
+)
+
+type todoTxtImporter interface {
+	ImportTodoTxt(userID int64, file io.Reader, loc *time.Location) (model.ImportReport, error)
+}
+
+type todoTxtSyncer interface {
+	SyncTodoTxt(userID int64, file io.Reader, dryRun bool, loc *time.Location) (model.SyncReport, error)
+}
+
+// TodoTxt import
+// @Summary todo.txt
+// @Security ApiKeyPath
+// @Tags Import
+// @Description Create a task for every line of a todo.txt file, sent in the "file" field of a multipart form.
+// @Description +projects become tags starting with "+", @contexts the other tags, and the extensions of the export
+// @Description are read back. Lines without a creation date are dated now. A line with the id of a task of the user,
+// @Description like the lines of an exported file, is reported as a duplicate; use the sync to update tasks.
+// @ID importTodoTxt
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "todo.txt file"
+// @Param tz query string false "IANA time zone of the user, UTC by default"
+// @Success 200 {object} model.ImportReport
+// @Failure 400,401,413 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /import/todotxt [post]
+func TodoTxt(log *slog.Logger, importer todoTxtImporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		loc, err := request.Location(r)
+		if err != nil {
+			log.Error("incorrect time zone", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect time zone",
+			})
+			return
+		}
+
+		data, _, ok := readImport(log, w, r)
+		if !ok {
+			return
+		}
+
+		report, err := importer.ImportTodoTxt(userID, bytes.NewReader(data), loc)
+		if errors.Is(err, service.ErrTodoTxt) {
+			log.Error("incorrect todo.txt file", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect todo.txt file",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't import todo.txt", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't import todo.txt",
+			})
+			return
+		}
+		log.Info("todo.txt imported", slog.Int("created", report.Created), slog.Int("invalid", report.Invalid))
+
+		render.JSON(w, r, report)
+	}
+}
+
+// SyncTodoTxt sync
+// @Summary todo.txt sync
+// @Security ApiKeyPath
+// @Tags Import
+// @Description Bring the user tasks in line with a todo.txt file, sent in the "file" field of a multipart form.
+// @Description A line with the id of a task of the user updates the fields of the task it differs in, listed in
+// @Description the changes of its item; the other lines are created like the import does. Tasks without a line
+// @Description are listed as missing and kept. A dry run only reports what would be done.
+// @ID syncTodoTxt
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "todo.txt file"
+// @Param dry_run query bool false "only report what would be done"
+// @Param tz query string false "IANA time zone of the user, UTC by default"
+// @Success 200 {object} model.SyncReport
+// @Failure 400,401,413 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /import/todotxt/sync [post]
+func SyncTodoTxt(log *slog.Logger, syncer todoTxtSyncer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		dryRun, err := request.Flag(r, "dry_run")
+		if err != nil {
+			log.Error("incorrect dry_run", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect dry_run",
+			})
+			return
+		}
+
+		loc, err := request.Location(r)
+		if err != nil {
+			log.Error("incorrect time zone", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect time zone",
+			})
+			return
+		}
+
+		data, _, ok := readImport(log, w, r)
+		if !ok {
+			return
+		}
+
+		report, err := syncer.SyncTodoTxt(userID, bytes.NewReader(data), dryRun, loc)
+		if errors.Is(err, service.ErrTodoTxt) {
+			log.Error("incorrect todo.txt file", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect todo.txt file",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't sync todo.txt", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't sync todo.txt",
+			})
+			return
+		}
+		log.Info("todo.txt synced", slog.Int("created", report.Created), slog.Int("updated", report.Updated),
+			slog.Int("invalid", report.Invalid), slog.Bool("dryRun", dryRun))
+
+		render.JSON(w, r, report)
+	}
+}
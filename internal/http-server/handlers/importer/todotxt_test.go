@@ -0,0 +1,230 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/service"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readsTodoTxt(data []byte) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		file, err := io.ReadAll(x.(io.Reader))
+		return err == nil && bytes.Equal(file, data)
+	})
+}
+
+func TestHandler_TodoTxt(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockImport, data []byte)
+
+	file := []byte("(A) Call mom +family\nx 2024-03-10 Pay rent id:4\n")
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name                 string
+		query                string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			query:  "?tz=Europe/Moscow",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				s.EXPECT().ImportTodoTxt(int64(1), readsTodoTxt(data), moscow).Return(model.ImportReport{
+					Created:    1,
+					Duplicates: 1,
+					Items: []model.ImportItem{
+						{Ref: "line 1", Text: "Call mom", Status: model.ImportCreated, TaskID: 8},
+						{Ref: "line 2", Text: "Pay rent", Status: model.ImportDuplicate, TaskID: 4},
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"created":1,"duplicates":1,"skipped":0,"invalid":0,"items":[` +
+				`{"ref":"line 1","text":"Call mom","status":"created","task_id":8},` +
+				`{"ref":"line 2","text":"Pay rent","status":"duplicate","task_id":4}]}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect time zone",
+			query:                "?tz=Mars/Olympus",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect time zone"}`,
+		}, {
+			name:   "incorrect ImportTodoTxt return: incorrect file",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				s.EXPECT().ImportTodoTxt(int64(1), readsTodoTxt(data), time.UTC).
+					Return(model.ImportReport{}, fmt.Errorf("%w: token too long", service.ErrTodoTxt))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect todo.txt file"}`,
+		}, {
+			name:   "incorrect ImportTodoTxt return: internal server error",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				s.EXPECT().ImportTodoTxt(int64(1), readsTodoTxt(data), time.UTC).
+					Return(model.ImportReport{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't import todo.txt"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			importer := mock_service.NewMockImport(ctrl)
+			test.mockBehavior(importer, file)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/import/todotxt", TodoTxt(logger, importer))
+
+			body, contentType := multipartBody(t, "file", file, nil)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/import/todotxt"+test.query, body)
+			r.Header.Set("Content-Type", contentType)
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_SyncTodoTxt(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockImport, data []byte)
+
+	file := []byte("(A) Call mom id:3\nPay rent\n")
+
+	var tests = []struct {
+		name                 string
+		query                string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			query:  "?dry_run=true",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				s.EXPECT().SyncTodoTxt(int64(1), readsTodoTxt(data), true, time.UTC).Return(model.SyncReport{
+					DryRun:  true,
+					Created: 1,
+					Updated: 1,
+					Items: []model.ImportItem{
+						{Ref: "line 1", Text: "Call mom", Status: model.ImportUpdated, TaskID: 3, Changes: []string{"priority"}},
+						{Ref: "line 2", Text: "Pay rent", Status: model.ImportCreated, Task: &model.Task{
+							Text:   "Pay rent",
+							Tags:   []string{},
+							Date:   time.Date(2024, time.March, 8, 18, 0, 0, 0, time.UTC),
+							Status: model.TaskStatusTodo,
+						}},
+					},
+					Missing: []int64{5},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"dry_run":true,"created":1,"updated":1,"unchanged":0,"invalid":0,"items":[` +
+				`{"ref":"line 1","text":"Call mom","status":"updated","task_id":3,"changes":["priority"]},` +
+				`{"ref":"line 2","text":"Pay rent","status":"created","task":{"text":"Pay rent","tags":[],"date":"2024-03-08T18:00:00Z","status":"todo","progress":{"done":0,"total":0},"comment_count":0}}],` +
+				`"missing":[5]}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect dry_run",
+			query:                "?dry_run=maybe",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockImport, data []byte) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect dry_run"}`,
+		}, {
+			name:   "incorrect SyncTodoTxt return: incorrect file",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				s.EXPECT().SyncTodoTxt(int64(1), readsTodoTxt(data), false, time.UTC).
+					Return(model.SyncReport{}, fmt.Errorf("%w: token too long", service.ErrTodoTxt))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect todo.txt file"}`,
+		}, {
+			name:   "incorrect SyncTodoTxt return: internal server error",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockImport, data []byte) {
+				s.EXPECT().SyncTodoTxt(int64(1), readsTodoTxt(data), false, time.UTC).
+					Return(model.SyncReport{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't sync todo.txt"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			importer := mock_service.NewMockImport(ctrl)
+			test.mockBehavior(importer, file)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/import/todotxt/sync", SyncTodoTxt(logger, importer))
+
+			body, contentType := multipartBody(t, "file", file, nil)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/import/todotxt/sync"+test.query, body)
+			r.Header.Set("Content-Type", contentType)
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
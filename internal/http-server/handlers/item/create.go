@@ -0,0 +1,121 @@
+package item
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+)
+
+type itemRequest struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+type createResponse struct {
+	ItemID int64 `json:"item_id"`
+}
+
+type creater interface {
+	CreateItem(item model.TaskItem, userID int64) (int64, error)
+}
+
+// Create checklist item
+// @Summary Create
+// @Security ApiKeyPath
+// @Tags Item
+// @Description Add an item at the end of the task checklist
+// @ID createTaskItem
+// @Accept json
+// @Produce json
+// @Param task_id path int true "task ID"
+// @Param input body itemRequest true "Item info"
+// @Success 201 {object} createResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/items [post]
+func Create(log *slog.Logger, creater creater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req itemRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		item := model.TaskItem{
+			TaskID: int64(taskID),
+			Text:   req.Text,
+			Done:   req.Done,
+		}
+		if !verification.TaskItem(item) {
+			log.Error("incorrect item information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect item information",
+			})
+			return
+		}
+
+		itemID, err := creater.CreateItem(item, userID)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to create item", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to create item",
+			})
+			return
+		}
+		log.Info("item created", slog.Int64("itemID", itemID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, createResponse{
+			ItemID: itemID,
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package item
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_CreateItem(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockItem, item model.TaskItem, userID int64)
+
+	var tests = []struct {
+		name                 string
+		inputBody            string
+		stringTaskID         string
+		inputItem            model.TaskItem
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:         "correct working",
+			inputBody:    `{"text":"passport"}`,
+			stringTaskID: "4",
+			inputItem: model.TaskItem{
+				TaskID: 4,
+				Text:   "passport",
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockItem, item model.TaskItem, userID int64) {
+				s.EXPECT().CreateItem(item, userID).Return(int64(9), nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"item_id":9}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockItem, item model.TaskItem, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "bad request",
+			inputBody:            `{"text":"passport}`,
+			stringTaskID:         "4",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockItem, item model.TaskItem, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"failed to decode request"}`,
+		}, {
+			name:                 "incorrect taskID",
+			inputBody:            `{"text":"passport"}`,
+			stringTaskID:         "a4",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockItem, item model.TaskItem, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect task id record"}`,
+		}, {
+			name:                 "empty text",
+			inputBody:            `{"done":true}`,
+			stringTaskID:         "4",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockItem, item model.TaskItem, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect item information"}`,
+		}, {
+			name:         "incorrect CreateItem return: no task",
+			inputBody:    `{"text":"passport","done":true}`,
+			stringTaskID: "4",
+			inputItem: model.TaskItem{
+				TaskID: 4,
+				Text:   "passport",
+				Done:   true,
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockItem, item model.TaskItem, userID int64) {
+				s.EXPECT().CreateItem(item, userID).Return(int64(0), repositories.ErrNoTask)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no task with this taskID"}`,
+		}, {
+			name:         "incorrect CreateItem return: internal server error",
+			inputBody:    `{"text":"passport"}`,
+			stringTaskID: "4",
+			inputItem: model.TaskItem{
+				TaskID: 4,
+				Text:   "passport",
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockItem, item model.TaskItem, userID int64) {
+				s.EXPECT().CreateItem(item, userID).Return(int64(0), errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"failed to create item"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			item := mock_service.NewMockItem(ctrl)
+			test.mockBehavior(item, test.inputItem, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/items/", Create(logger, item))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/items/", bytes.NewBufferString(test.inputBody))
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("taskId", test.stringTaskID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
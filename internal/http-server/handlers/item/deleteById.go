@@ -0,0 +1,90 @@
+package item
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type deleter interface {
+	DeleteItem(taskID, itemID, userID int64) error
+}
+
+// Delete checklist item
+// @Summary Delete
+// @Security ApiKeyPath
+// @Tags Item
+// @Description Delete an item from the task checklist
+// @ID deleteTaskItem
+// @Param task_id path int true "task ID"
+// @Param item_id path int true "item ID"
+// @Produce json
+// @Success 204
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/items/{itemId} [delete]
+func Delete(log *slog.Logger, deleter deleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		itemID, err := strconv.Atoi(chi.URLParam(r, "itemId"))
+		if err != nil {
+			log.Error("incorrect item id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect item id record",
+			})
+			return
+		}
+
+		err = deleter.DeleteItem(int64(taskID), int64(itemID), userID)
+		if errors.Is(err, repositories.ErrNoItem) {
+			log.Error("there is no item", slog.Int("taskID", taskID), slog.Int("itemID", itemID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no item with this itemID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't delete item", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't delete item",
+			})
+			return
+		}
+		log.Info("item deleted", slog.Int("itemID", itemID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
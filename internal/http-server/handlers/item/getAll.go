@@ -0,0 +1,95 @@
+package item
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type getAllResponse struct {
+	Items    []model.TaskItem   `json:"items"`
+	Progress model.TaskProgress `json:"progress"`
+}
+
+type allGetter interface {
+	GetItems(taskID, userID int64) ([]model.TaskItem, error)
+}
+
+// GetAll checklist items
+// @Summary GetAll
+// @Security ApiKeyPath
+// @Tags Item
+// @Description Get the task checklist in its order
+// @ID getAllTaskItems
+// @Param task_id path int true "task ID"
+// @Produce json
+// @Success 200 {object} getAllResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/items [get]
+func GetAll(log *slog.Logger, getter allGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		items, err := getter.GetItems(int64(taskID), userID)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't get items", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't get items",
+			})
+			return
+		}
+
+		progress := model.TaskProgress{Total: len(items)}
+		for _, item := range items {
+			if item.Done {
+				progress.Done++
+			}
+		}
+		log.Info("items sent", slog.Int("taskID", taskID))
+		render.JSON(w, r, getAllResponse{
+			Items:    items,
+			Progress: progress,
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package item
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_GetItems(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockItem, taskID, userID int64)
+
+	var tests = []struct {
+		name                 string
+		stringTaskID         string
+		taskID               int64
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:         "correct working",
+			stringTaskID: "4",
+			taskID:       4,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockItem, taskID, userID int64) {
+				s.EXPECT().GetItems(taskID, userID).Return([]model.TaskItem{
+					{ID: 2, TaskID: 4, Text: "passport", Done: true, Rank: "i"},
+					{ID: 1, TaskID: 4, Text: "tickets", Rank: "r"},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"items":[{"id":2,"text":"passport","done":true},{"id":1,"text":"tickets","done":false}],` +
+				`"progress":{"done":1,"total":2}}`,
+		}, {
+			name:         "correct working: empty checklist",
+			stringTaskID: "4",
+			taskID:       4,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockItem, taskID, userID int64) {
+				s.EXPECT().GetItems(taskID, userID).Return([]model.TaskItem{}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"items":[],"progress":{"done":0,"total":0}}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockItem, taskID, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect taskID",
+			stringTaskID:         "a4",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockItem, taskID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect task id record"}`,
+		}, {
+			name:         "incorrect GetItems return: no task",
+			stringTaskID: "4",
+			taskID:       4,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockItem, taskID, userID int64) {
+				s.EXPECT().GetItems(taskID, userID).Return(nil, repositories.ErrNoTask)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no task with this taskID"}`,
+		}, {
+			name:         "incorrect GetItems return: internal server error",
+			stringTaskID: "4",
+			taskID:       4,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockItem, taskID, userID int64) {
+				s.EXPECT().GetItems(taskID, userID).Return(nil, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't get items"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			item := mock_service.NewMockItem(ctrl)
+			test.mockBehavior(item, test.taskID, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/items/", GetAll(logger, item))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/items/", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("taskId", test.stringTaskID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
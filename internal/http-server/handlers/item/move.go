@@ -0,0 +1,128 @@
+package item
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type mover interface {
+	MoveItem(taskID, itemID, userID int64, after, before *int64) error
+}
+
+// Move checklist item
+// @Summary Move
+// @Security ApiKeyPath
+// @Tags Item
+// @Description Put a checklist item between two others, without neighbours it goes to the end
+// @ID moveTaskItem
+// @Param task_id path int true "task ID"
+// @Param item_id path int true "item ID"
+// @Param after query int false "item that goes right before the moved one"
+// @Param before query int false "item that goes right after the moved one"
+// @Produce json
+// @Success 204
+// @Failure 400,401,404,409 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/items/{itemId}/move [post]
+func Move(log *slog.Logger, mover mover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		itemID, err := strconv.Atoi(chi.URLParam(r, "itemId"))
+		if err != nil {
+			log.Error("incorrect item id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect item id record",
+			})
+			return
+		}
+
+		after, err := request.OptionalID(r, "after")
+		if err != nil {
+			log.Error("incorrect after record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect after record",
+			})
+			return
+		}
+		before, err := request.OptionalID(r, "before")
+		if err != nil {
+			log.Error("incorrect before record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect before record",
+			})
+			return
+		}
+
+		err = mover.MoveItem(int64(taskID), int64(itemID), userID, after, before)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrNoItem) {
+			log.Error("there is no item", slog.Int("taskID", taskID), slog.Int("itemID", itemID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no item with this itemID",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrItemOrder) {
+			log.Error("items are not in this order", slog.Any("after", after), slog.Any("before", before))
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, response.Message{
+				Msg: "after item doesn't go before the before item",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't move item", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't move item",
+			})
+			return
+		}
+		log.Info("item moved", slog.Int("itemID", itemID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
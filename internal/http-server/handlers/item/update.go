@@ -0,0 +1,128 @@
+package item
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+)
+
+type updater interface {
+	UpdateItem(item model.TaskItem, userID int64) error
+}
+
+// Update checklist item
+// @Summary Update
+// @Security ApiKeyPath
+// @Tags Item
+// @Description Change the text of a checklist item or tick it off
+// @ID updateTaskItem
+// @Accept json
+// @Param task_id path int true "task ID"
+// @Param item_id path int true "item ID"
+// @Param input body itemRequest true "Item info"
+// @Produce json
+// @Success 204
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/items/{itemId} [put]
+func Update(log *slog.Logger, updater updater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req itemRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		itemID, err := strconv.Atoi(chi.URLParam(r, "itemId"))
+		if err != nil {
+			log.Error("incorrect item id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect item id record",
+			})
+			return
+		}
+
+		item := model.TaskItem{
+			ID:     int64(itemID),
+			TaskID: int64(taskID),
+			Text:   req.Text,
+			Done:   req.Done,
+		}
+		if !verification.TaskItem(item) {
+			log.Error("incorrect item information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect item information",
+			})
+			return
+		}
+
+		err = updater.UpdateItem(item, userID)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrNoItem) {
+			log.Error("there is no item", slog.Int("taskID", taskID), slog.Int("itemID", itemID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no item with this itemID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't update item", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't update item",
+			})
+			return
+		}
+		log.Info("item updated", slog.Int("itemID", itemID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,112 @@
+package project
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"strconv"
+	"time"
+)
+
+const defaultBurndownDays = 30
+
+type burndownDrawer interface {
+	ProjectBurndown(projectID, userID int64, days int, loc *time.Location) ([]byte, error)
+}
+
+// Burndown of project
+// @Summary Burndown
+// @Security ApiKeyPath
+// @Tags Project
+// @Description Draw the tasks of the project still open at the end of each of the last days, today included, as an SVG chart next to the ideal line.
+// @Description The chart comes with an ETag, send it back in If-None-Match to get Not Modified while it hasn't changed.
+// @ID projectBurndown
+// @Param project_id path int true "project ID"
+// @Param days query int false "number of days, 30 by default, at most 366"
+// @Param tz query string false "IANA time zone of the user, UTC by default"
+// @Param If-None-Match header string false "ETag of the chart already fetched"
+// @Produce image/svg+xml
+// @Success 200 {string} string "SVG chart"
+// @Success 304
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /projects/{projectId}/burndown.svg [get]
+func Burndown(log *slog.Logger, drawer burndownDrawer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "projectId"))
+		if err != nil {
+			log.Error("incorrect project id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect project id record",
+			})
+			return
+		}
+
+		days := defaultBurndownDays
+		if value := r.URL.Query().Get("days"); value != "" {
+			days, err = strconv.Atoi(value)
+			if err != nil || days < 1 || days > model.MaxBurndownDays {
+				log.Error("incorrect days record", slog.String("days", value))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, response.Message{
+					Msg: "incorrect days record",
+				})
+				return
+			}
+		}
+
+		loc, err := request.Location(r)
+		if err != nil {
+			log.Error("incorrect time zone", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect time zone",
+			})
+			return
+		}
+
+		chart, err := drawer.ProjectBurndown(int64(projectID), userID, days, loc)
+		if errors.Is(err, repositories.ErrNoProject) {
+			log.Error("there is no project", slog.Int64("userID", userID), slog.Int("projectID", projectID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no project with this projectID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't draw burndown", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't draw burndown",
+			})
+			return
+		}
+		log.Info("burndown sent", slog.Int("projectID", projectID), slog.Int("days", days))
+		response.Cacheable(w, r, "image/svg+xml", chart)
+	}
+}
@@ -0,0 +1,151 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_Burndown(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockAnalytics, projectID, userID int64)
+
+	chart := []byte("<svg></svg>\n")
+	etag := `"6d6ddf6160dbd0685f29b421c8f85ad7"`
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+
+	var tests = []struct {
+		name                 string
+		stringProjectID      string
+		projectID            int64
+		query                string
+		ifNoneMatch          string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedETag         string
+		expectedResponseBody string
+	}{
+		{
+			name:            "correct working",
+			stringProjectID: "2",
+			projectID:       2,
+			query:           "?days=14&tz=Asia/Tokyo",
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockAnalytics, projectID, userID int64) {
+				s.EXPECT().ProjectBurndown(projectID, userID, 14, tokyo).Return(chart, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedETag:         etag,
+			expectedResponseBody: "<svg></svg>",
+		}, {
+			name:            "not modified",
+			stringProjectID: "2",
+			projectID:       2,
+			ifNoneMatch:     etag,
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockAnalytics, projectID, userID int64) {
+				s.EXPECT().ProjectBurndown(projectID, userID, 30, time.UTC).Return(chart, nil)
+			},
+			expectedStatusCode: http.StatusNotModified,
+			expectedETag:       etag,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, projectID, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect projectID",
+			stringProjectID:      "a2",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, projectID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect project id record"}`,
+		}, {
+			name:                 "too many days",
+			stringProjectID:      "2",
+			query:                "?days=367",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, projectID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect days record"}`,
+		}, {
+			name:                 "incorrect time zone",
+			stringProjectID:      "2",
+			query:                "?tz=Local",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, projectID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect time zone"}`,
+		}, {
+			name:            "no project",
+			stringProjectID: "2",
+			projectID:       2,
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockAnalytics, projectID, userID int64) {
+				s.EXPECT().ProjectBurndown(projectID, userID, 30, time.UTC).
+					Return(nil, fmt.Errorf("ProjectBurndown: %w", repositories.ErrNoProject))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no project with this projectID"}`,
+		}, {
+			name:            "incorrect ProjectBurndown return",
+			stringProjectID: "2",
+			projectID:       2,
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockAnalytics, projectID, userID int64) {
+				s.EXPECT().ProjectBurndown(projectID, userID, 30, time.UTC).Return(nil, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't draw burndown"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			drawer := mock_service.NewMockAnalytics(ctrl)
+			test.mockBehavior(drawer, test.projectID, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/projects/burndown.svg", Burndown(logger, drawer))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/projects/burndown.svg"+test.query, nil)
+			if test.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", test.ifNoneMatch)
+			}
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("projectId", test.stringProjectID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedETag, w.Header().Get("ETag"))
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
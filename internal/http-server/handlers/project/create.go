@@ -0,0 +1,104 @@
+package project
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/verification"
+)
+
+type createRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Colour      string `json:"colour"`
+	Archived    bool   `json:"archived"`
+}
+
+type createResponse struct {
+	ProjectID int64 `json:"project_id"`
+}
+
+type projectCreater interface {
+	CreateProject(project model.Project) (int64, error)
+}
+
+// Create project
+// @Summary Create
+// @Security ApiKeyPath
+// @Tags Project
+// @Description Create new project
+// @ID createProject
+// @Accept json
+// @Produce json
+// @Param input body createRequest true "Project info"
+// @Success 201 {object} createResponse
+// @Failure 400,401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /projects/ [post]
+func Create(log *slog.Logger, creater projectCreater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req createRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		project := model.Project{
+			Name:        req.Name,
+			Description: req.Description,
+			Colour:      req.Colour,
+			Archived:    req.Archived,
+			OwnerID:     userID,
+		}
+		if !verification.Project(project) {
+			log.Error("incorrect project information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect project information",
+			})
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		projectID, err := creater.CreateProject(project)
+		if err != nil {
+			log.Error("failed to create project", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to create project",
+			})
+			return
+		}
+		log.Info("project created", slog.Int64("projectID", projectID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, createResponse{
+			ProjectID: projectID,
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_CreateProject(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockProject, project model.Project)
+
+	var tests = []struct {
+		name                 string
+		inputBody            string
+		inputProject         model.Project
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "correct working",
+			inputBody: `{"name":"home","description":"things to do at home","colour":"#00ff00"}`,
+			inputProject: model.Project{
+				Name:        "home",
+				Description: "things to do at home",
+				Colour:      "#00ff00",
+				OwnerID:     1,
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockProject, project model.Project) {
+				s.EXPECT().CreateProject(project).Return(int64(1), nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"project_id":1}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockProject, project model.Project) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "bad request",
+			inputBody:            `{"name":"home}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockProject, project model.Project) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"failed to decode request"}`,
+		}, {
+			name:                 "incorrect colour",
+			inputBody:            `{"name":"home","colour":"green"}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockProject, project model.Project) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect project information"}`,
+		}, {
+			name:      "incorrect CreateProject return",
+			inputBody: `{"name":"home"}`,
+			inputProject: model.Project{
+				Name:    "home",
+				OwnerID: 1,
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockProject, project model.Project) {
+				s.EXPECT().CreateProject(project).Return(int64(0), errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"failed to create project"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			project := mock_service.NewMockProject(ctrl)
+			test.mockBehavior(project, test.inputProject)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/projects/", Create(logger, project))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/projects/", bytes.NewBufferString(test.inputBody))
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package project
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+)
+
+type getAllResponse struct {
+	Projects []model.Project `json:"projects"`
+}
+
+type allGetter interface {
+	GetAllProjects(userID int64, withArchived bool) ([]model.Project, error)
+}
+
+// GetAll user projects
+// @Summary GetAll
+// @Security ApiKeyPath
+// @Tags Project
+// @Description Get all user projects, archived ones only on request
+// @ID getAllUserProjects
+// @Param archived query bool false "include archived projects"
+// @Produce json
+// @Success 200 {object} getAllResponse
+// @Failure 401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /projects/ [get]
+func GetAll(log *slog.Logger, getter allGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		withArchived := r.URL.Query().Get("archived") == "true"
+		projects, err := getter.GetAllProjects(userID, withArchived)
+		if err != nil {
+			log.Error("couldn't get all projects by this user", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "couldn't get all projects",
+			})
+			return
+		}
+
+		log.Info("all user projects copied", slog.Int64("userID", userID))
+		render.JSON(w, r, getAllResponse{
+			Projects: projects,
+		})
+	}
+}
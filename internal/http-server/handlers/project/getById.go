@@ -0,0 +1,85 @@
+package project
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type getProjectResponse struct {
+	Project model.Project `json:"project"`
+}
+
+type projectGetter interface {
+	GetProject(projectID, userID int64) (model.Project, error)
+}
+
+// Get project by ID
+// @Summary Get
+// @Security ApiKeyPath
+// @Tags Project
+// @Description Get user project by ID
+// @ID getProjectByID
+// @Param project_id path int true "project ID"
+// @Produce json
+// @Success 200 {object} getProjectResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /projects/{projectId} [get]
+func Get(log *slog.Logger, getter projectGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "projectId"))
+		if err != nil {
+			log.Error("incorrect project id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect project id record",
+			})
+			return
+		}
+		project, err := getter.GetProject(int64(projectID), userID)
+		if errors.Is(err, repositories.ErrNoProject) {
+			log.Error("there is no project", slog.Int64("userID", userID), slog.Int("projectID", projectID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no project with this projectID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't found project", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't found project",
+			})
+			return
+		}
+		log.Info("project copied by id", slog.Int("id", projectID))
+		render.JSON(w, r, getProjectResponse{
+			Project: project,
+		})
+	}
+}
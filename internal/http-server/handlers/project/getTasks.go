@@ -0,0 +1,99 @@
+package project
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type getTasksResponse struct {
+	Tasks []model.Task `json:"tasks"`
+}
+
+type tasksGetter interface {
+	GetProjectTasks(projectID, userID int64, filter model.TaskFilter) ([]model.Task, error)
+}
+
+// GetTasks of project
+// @Summary GetTasks
+// @Security ApiKeyPath
+// @Tags Project
+// @Description Get all tasks of user project
+// @ID getProjectTasks
+// @Param project_id path int true "project ID"
+// @Param sort query string false "manual for the hand-made order"
+// @Param include_deferred query bool false "also list the tasks snoozed until later"
+// @Produce json
+// @Success 200 {object} getTasksResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /projects/{projectId}/tasks [get]
+func GetTasks(log *slog.Logger, getter tasksGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "projectId"))
+		if err != nil {
+			log.Error("incorrect project id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect project id record",
+			})
+			return
+		}
+
+		filter, err := request.TaskFilter(r)
+		if err != nil {
+			log.Error("incorrect filter", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect filter",
+			})
+			return
+		}
+
+		tasks, err := getter.GetProjectTasks(int64(projectID), userID, filter)
+		if errors.Is(err, repositories.ErrNoProject) {
+			log.Error("there is no project", slog.Int64("userID", userID), slog.Int("projectID", projectID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no project with this projectID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("couldn't get project tasks", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "couldn't get project tasks",
+			})
+			return
+		}
+		log.Info("project tasks copied", slog.Int("projectID", projectID))
+		render.JSON(w, r, getTasksResponse{
+			Tasks: tasks,
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_GetTasks(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockProject, projectID, userID int64)
+
+	taskProjectID := int64(2)
+
+	var tests = []struct {
+		name                 string
+		stringProjectID      string
+		projectID            int64
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:            "correct working",
+			stringProjectID: "2",
+			projectID:       2,
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockProject, projectID, userID int64) {
+				s.EXPECT().GetProjectTasks(projectID, userID, model.TaskFilter{}).Return([]model.Task{
+					{
+						ID:        1,
+						Text:      "TestText",
+						Tags:      []string{"testTag"},
+						Date:      time.Date(2000, 10, 10, 10, 10, 10, 0, time.UTC),
+						OwnerID:   1,
+						ProjectID: &taskProjectID,
+					},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"tasks":[{"text":"TestText","tags":["testTag"],"date":"2000-10-10T10:10:10Z","project_id":2,"progress":{"done":0,"total":0},"comment_count":0}]}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockProject, projectID, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect projectID",
+			stringProjectID:      "a2",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockProject, projectID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect project id record"}`,
+		}, {
+			name:            "no project",
+			stringProjectID: "2",
+			projectID:       2,
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockProject, projectID, userID int64) {
+				s.EXPECT().GetProjectTasks(projectID, userID, model.TaskFilter{}).
+					Return(nil, fmt.Errorf("GetProject: %w", repositories.ErrNoProject))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no project with this projectID"}`,
+		}, {
+			name:            "incorrect GetProjectTasks return",
+			stringProjectID: "2",
+			projectID:       2,
+			userID:          1,
+			mockBehavior: func(s *mock_service.MockProject, projectID, userID int64) {
+				s.EXPECT().GetProjectTasks(projectID, userID, model.TaskFilter{}).Return(nil, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"couldn't get project tasks"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			project := mock_service.NewMockProject(ctrl)
+			test.mockBehavior(project, test.projectID, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/projects/tasks", GetTasks(logger, project))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/projects/tasks", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("projectId", test.stringProjectID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package project
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+)
+
+type updateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Colour      string `json:"colour"`
+	Archived    bool   `json:"archived"`
+}
+
+type projectUpdater interface {
+	UpdateProject(project model.Project) error
+}
+
+// Update project by ID
+// @Summary Update
+// @Security ApiKeyPath
+// @Tags Project
+// @Description Update user project by ID, archiving is done by setting archived
+// @ID updateProjectByID
+// @Param input body updateRequest true "new project info"
+// @Param project_id path int true "project ID"
+// @Produce json
+// @Success 204
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /projects/{projectId} [put]
+func Update(log *slog.Logger, updater projectUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req updateRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		projectID, err := strconv.Atoi(chi.URLParam(r, "projectId"))
+		if err != nil {
+			log.Error("incorrect project id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect project id record",
+			})
+			return
+		}
+
+		project := model.Project{
+			ID:          int64(projectID),
+			Name:        req.Name,
+			Description: req.Description,
+			Colour:      req.Colour,
+			Archived:    req.Archived,
+			OwnerID:     userID,
+		}
+		if !verification.Project(project) {
+			log.Error("incorrect project information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect project information",
+			})
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+		err = updater.UpdateProject(project)
+		if errors.Is(err, repositories.ErrNoProject) {
+			log.Error("there is no project", slog.Int64("userID", userID), slog.Int("projectID", projectID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no project with this projectID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't update project", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't update project",
+			})
+			return
+		}
+		log.Info("project updated", slog.Int("id", projectID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
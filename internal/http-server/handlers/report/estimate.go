@@ -0,0 +1,87 @@
+package report
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"time"
+)
+
+type estimateReporter interface {
+	EstimateReport(userID int64, from, to time.Time, group string) (model.EstimateReport, error)
+}
+
+// Estimate report
+// @Summary Estimates
+// @Security ApiKeyPath
+// @Tags Report
+// @Description Compare the estimates of the tasks completed between two dates, both included, with the time logged on them.
+// @Description Rows go by tag or by week, weeks start on Monday in UTC and are keyed by that day.
+// @ID estimateReport
+// @Param from query string true "first day, 2006-01-02"
+// @Param to query string true "last day, 2006-01-02"
+// @Param group query string false "tag or week, tag by default"
+// @Produce json
+// @Success 200 {object} model.EstimateReport
+// @Failure 400,401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /reports/estimates [get]
+func Estimate(log *slog.Logger, reporter estimateReporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		from, to, err := request.DateRange(r)
+		if err != nil {
+			log.Error("incorrect date range", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect date range",
+			})
+			return
+		}
+
+		group := r.URL.Query().Get("group")
+		switch group {
+		case "":
+			group = model.EstimateGroupTag
+		case model.EstimateGroupTag, model.EstimateGroupWeek:
+		default:
+			log.Error("incorrect group", slog.String("group", group))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect group",
+			})
+			return
+		}
+
+		report, err := reporter.EstimateReport(userID, from, to, group)
+		if err != nil {
+			log.Error("can't make estimate report", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't make estimate report",
+			})
+			return
+		}
+		log.Info("estimate report sent", slog.String("group", group))
+		render.JSON(w, r, report)
+	}
+}
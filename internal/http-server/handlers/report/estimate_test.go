@@ -0,0 +1,117 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_EstimateReport(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockAnalytics, userID int64)
+
+	from := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name                 string
+		query                string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			query:  "?from=2024-03-04&to=2024-03-17&group=week",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAnalytics, userID int64) {
+				s.EXPECT().EstimateReport(userID, from, to, model.EstimateGroupWeek).Return(model.EstimateReport{
+					From:  from,
+					To:    to,
+					Group: model.EstimateGroupWeek,
+					Rows: []model.EstimateReportRow{
+						{Key: "2024-03-04", EstimateTotals: model.EstimateTotals{Tasks: 2, Estimate: 7200, Actual: 9000}},
+						{Key: "2024-03-11", EstimateTotals: model.EstimateTotals{Tasks: 1, Estimate: 3600, Actual: 1800}},
+					},
+					Total: model.EstimateTotals{Tasks: 3, Estimate: 10800, Actual: 10800},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"from":"2024-03-04T00:00:00Z","to":"2024-03-18T00:00:00Z","group":"week",` +
+				`"rows":[{"key":"2024-03-04","tasks":2,"estimate":7200,"actual":9000},` +
+				`{"key":"2024-03-11","tasks":1,"estimate":3600,"actual":1800}],` +
+				`"total":{"tasks":3,"estimate":10800,"actual":10800}}`,
+		}, {
+			name:                 "incorrect userID",
+			query:                "?from=2024-03-04&to=2024-03-17",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect date",
+			query:                "?from=2024-03-04&to=17.03.2024",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect date range"}`,
+		}, {
+			name:                 "unknown group",
+			query:                "?from=2024-03-04&to=2024-03-17&group=day",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect group"}`,
+		}, {
+			name:   "incorrect EstimateReport return: internal server error",
+			query:  "?from=2024-03-04&to=2024-03-17",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAnalytics, userID int64) {
+				s.EXPECT().EstimateReport(userID, from, to, model.EstimateGroupTag).Return(model.EstimateReport{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't make estimate report"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			reporter := mock_service.NewMockAnalytics(ctrl)
+			test.mockBehavior(reporter, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/reports/estimates", Estimate(logger, reporter))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/reports/estimates"+test.query, nil)
+
+			rctx := chi.NewRouteContext()
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package report
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"time"
+)
+
+type timeReporter interface {
+	TimeReport(userID int64, from, to time.Time, group string) (model.TimeReport, error)
+}
+
+// Time report
+// @Summary Time
+// @Security ApiKeyPath
+// @Tags Report
+// @Description Sum the logged time between two dates, both included, grouped by tag, day or task.
+// @Description Days are in UTC, a task with several tags counts for each of them and untagged time goes under the empty key.
+// @ID timeReport
+// @Param from query string true "first day, 2006-01-02"
+// @Param to query string true "last day, 2006-01-02"
+// @Param group query string false "tag, day or task, task by default"
+// @Produce json
+// @Success 200 {object} model.TimeReport
+// @Failure 400,401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /reports/time [get]
+func Time(log *slog.Logger, reporter timeReporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		from, to, err := request.DateRange(r)
+		if err != nil {
+			log.Error("incorrect date range", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect date range",
+			})
+			return
+		}
+
+		group := r.URL.Query().Get("group")
+		switch group {
+		case "":
+			group = model.TimeGroupTask
+		case model.TimeGroupTag, model.TimeGroupDay, model.TimeGroupTask:
+		default:
+			log.Error("incorrect group", slog.String("group", group))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect group",
+			})
+			return
+		}
+
+		report, err := reporter.TimeReport(userID, from, to, group)
+		if err != nil {
+			log.Error("can't make time report", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't make time report",
+			})
+			return
+		}
+		log.Info("time report sent", slog.String("group", group))
+		render.JSON(w, r, report)
+	}
+}
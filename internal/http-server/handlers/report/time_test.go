@@ -0,0 +1,141 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_TimeReport(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTime, userID int64)
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	taskID := int64(4)
+
+	var tests = []struct {
+		name                 string
+		query                string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			query:  "?from=2024-03-01&to=2024-03-31&group=tag",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTime, userID int64) {
+				s.EXPECT().TimeReport(userID, from, to, model.TimeGroupTag).Return(model.TimeReport{
+					From:  from,
+					To:    to,
+					Group: model.TimeGroupTag,
+					Rows: []model.TimeReportRow{
+						{Key: "acme", Seconds: 5400},
+						{Key: "", Seconds: 600},
+					},
+					Total: 6000,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"from":"2024-03-01T00:00:00Z","to":"2024-04-01T00:00:00Z","group":"tag",` +
+				`"rows":[{"key":"acme","seconds":5400},{"key":"","seconds":600}],"total":6000}`,
+		}, {
+			name:   "task by default",
+			query:  "?from=2024-03-01&to=2024-03-31",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTime, userID int64) {
+				s.EXPECT().TimeReport(userID, from, to, model.TimeGroupTask).Return(model.TimeReport{
+					From:  from,
+					To:    to,
+					Group: model.TimeGroupTask,
+					Rows: []model.TimeReportRow{
+						{Key: "write invoice", TaskID: &taskID, Seconds: 1800},
+					},
+					Total: 1800,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"from":"2024-03-01T00:00:00Z","to":"2024-04-01T00:00:00Z","group":"task",` +
+				`"rows":[{"key":"write invoice","task_id":4,"seconds":1800}],"total":1800}`,
+		}, {
+			name:                 "incorrect userID",
+			query:                "?from=2024-03-01&to=2024-03-31",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockTime, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "missing to",
+			query:                "?from=2024-03-01",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTime, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect date range"}`,
+		}, {
+			name:                 "to before from",
+			query:                "?from=2024-03-10&to=2024-03-01",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTime, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect date range"}`,
+		}, {
+			name:                 "unknown group",
+			query:                "?from=2024-03-01&to=2024-03-31&group=project",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTime, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect group"}`,
+		}, {
+			name:   "incorrect TimeReport return: internal server error",
+			query:  "?from=2024-03-01&to=2024-03-31&group=day",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTime, userID int64) {
+				s.EXPECT().TimeReport(userID, from, to, model.TimeGroupDay).Return(model.TimeReport{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't make time report"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			reporter := mock_service.NewMockTime(ctrl)
+			test.mockBehavior(reporter, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/reports/time", Time(logger, reporter))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/reports/time"+test.query, nil)
+
+			rctx := chi.NewRouteContext()
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+	"time"
+)
+
+type heatmapDrawer interface {
+	ActivityHeatmap(userID int64, year int) ([]byte, error)
+}
+
+// Heatmap of activity
+// @Summary Heatmap
+// @Security ApiKeyPath
+// @Tags Stats
+// @Description Draw the tasks dated on each day of the year as an SVG grid, a column per week starting on Monday.
+// @Description The chart comes with an ETag, send it back in If-None-Match to get Not Modified while it hasn't changed.
+// @ID statsHeatmap
+// @Param year query int false "year, the current one by default"
+// @Param If-None-Match header string false "ETag of the chart already fetched"
+// @Produce image/svg+xml
+// @Success 200 {string} string "SVG chart"
+// @Success 304
+// @Failure 400,401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /stats/me/heatmap.svg [get]
+func Heatmap(log *slog.Logger, drawer heatmapDrawer, now func() time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		year := now().Year()
+		if value := r.URL.Query().Get("year"); value != "" {
+			if !verification.Year(value) {
+				log.Error("incorrect year record", slog.String("year", value))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, response.Message{
+					Msg: "incorrect year record",
+				})
+				return
+			}
+			year, _ = strconv.Atoi(value)
+		}
+
+		chart, err := drawer.ActivityHeatmap(userID, year)
+		if err != nil {
+			log.Error("can't draw heatmap", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't draw heatmap",
+			})
+			return
+		}
+		log.Info("heatmap sent", slog.Int("year", year))
+		response.Cacheable(w, r, "image/svg+xml", chart)
+	}
+}
@@ -0,0 +1,123 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_Heatmap(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockAnalytics, userID int64)
+
+	now := func() time.Time {
+		return time.Date(2024, time.March, 6, 10, 30, 0, 0, time.UTC)
+	}
+	chart := []byte("<svg></svg>\n")
+	etag := `"6d6ddf6160dbd0685f29b421c8f85ad7"`
+
+	var tests = []struct {
+		name                 string
+		query                string
+		ifNoneMatch          string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedETag         string
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			query:  "?year=2023",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAnalytics, userID int64) {
+				s.EXPECT().ActivityHeatmap(userID, 2023).Return(chart, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedETag:         etag,
+			expectedResponseBody: "<svg></svg>",
+		}, {
+			name:   "current year by default",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAnalytics, userID int64) {
+				s.EXPECT().ActivityHeatmap(userID, 2024).Return(chart, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedETag:         etag,
+			expectedResponseBody: "<svg></svg>",
+		}, {
+			name:        "not modified",
+			ifNoneMatch: `"other", W/` + etag,
+			userID:      1,
+			mockBehavior: func(s *mock_service.MockAnalytics, userID int64) {
+				s.EXPECT().ActivityHeatmap(userID, 2024).Return(chart, nil)
+			},
+			expectedStatusCode: http.StatusNotModified,
+			expectedETag:       etag,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect year",
+			query:                "?year=24",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect year record"}`,
+		}, {
+			name:   "incorrect ActivityHeatmap return: internal server error",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAnalytics, userID int64) {
+				s.EXPECT().ActivityHeatmap(userID, 2024).Return(nil, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't draw heatmap"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			drawer := mock_service.NewMockAnalytics(ctrl)
+			test.mockBehavior(drawer, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/stats/me/heatmap.svg", Heatmap(logger, drawer, now))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/stats/me/heatmap.svg"+test.query, nil)
+			if test.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", test.ifNoneMatch)
+			}
+
+			rctx := chi.NewRouteContext()
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedETag, w.Header().Get("ETag"))
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
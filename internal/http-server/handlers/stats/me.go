@@ -0,0 +1,99 @@
+package stats
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"time"
+)
+
+type statsReporter interface {
+	StatsReport(userID int64, from, to time.Time, bucket string, loc *time.Location) (model.Stats, error)
+}
+
+// Me stats
+// @Summary Me
+// @Security ApiKeyPath
+// @Tags Stats
+// @Description Sum up the tasks the user created and completed between two dates, both included, in the tz zone.
+// @Description Gives the median seconds from creation to completion, the current and longest streaks of days with a completed task and the busiest tags.
+// @Description Buckets go by day or by week, weeks start on Monday and are keyed by that day.
+// @ID statsMe
+// @Param from query string true "first day, 2006-01-02"
+// @Param to query string true "last day, 2006-01-02"
+// @Param bucket query string false "day or week, day by default"
+// @Param tz query string false "IANA time zone of the user, UTC by default"
+// @Produce json
+// @Success 200 {object} model.Stats
+// @Failure 400,401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /stats/me [get]
+func Me(log *slog.Logger, reporter statsReporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		from, to, err := request.DateRange(r)
+		if err != nil {
+			log.Error("incorrect date range", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect date range",
+			})
+			return
+		}
+
+		bucket := r.URL.Query().Get("bucket")
+		switch bucket {
+		case "":
+			bucket = model.StatsBucketDay
+		case model.StatsBucketDay, model.StatsBucketWeek:
+		default:
+			log.Error("incorrect bucket", slog.String("bucket", bucket))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect bucket",
+			})
+			return
+		}
+
+		loc, err := request.Location(r)
+		if err != nil {
+			log.Error("incorrect time zone", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect time zone",
+			})
+			return
+		}
+
+		stats, err := reporter.StatsReport(userID, from, to, bucket, loc)
+		if err != nil {
+			log.Error("can't get stats", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't get stats",
+			})
+			return
+		}
+		log.Info("stats sent", slog.String("bucket", bucket))
+		render.JSON(w, r, stats)
+	}
+}
@@ -0,0 +1,148 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_Me(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockAnalytics, userID int64)
+
+	from := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+	median := int64(5400)
+
+	var tests = []struct {
+		name                 string
+		query                string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			query:  "?from=2024-03-04&to=2024-03-17&bucket=week&tz=Europe/Berlin",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAnalytics, userID int64) {
+				s.EXPECT().StatsReport(userID, from, to, model.StatsBucketWeek, berlin).Return(model.Stats{
+					From:             time.Date(2024, time.March, 4, 0, 0, 0, 0, berlin),
+					To:               time.Date(2024, time.March, 18, 0, 0, 0, 0, berlin),
+					Bucket:           model.StatsBucketWeek,
+					Total:            model.StatsTotals{Created: 5, Completed: 3},
+					MedianCompletion: &median,
+					Streaks:          model.StatsStreaks{Current: 2, Longest: 3},
+					Buckets: []model.StatsBucket{
+						{Start: "2024-03-04", StatsTotals: model.StatsTotals{Created: 4, Completed: 1}},
+						{Start: "2024-03-11", StatsTotals: model.StatsTotals{Created: 1, Completed: 2}},
+					},
+					TopTags: []model.StatsTag{
+						{Tag: "work", StatsTotals: model.StatsTotals{Created: 3, Completed: 2}},
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"from":"2024-03-04T00:00:00+01:00","to":"2024-03-18T00:00:00+01:00","bucket":"week",` +
+				`"total":{"created":5,"completed":3},"median_completion":5400,"streaks":{"current":2,"longest":3},` +
+				`"buckets":[{"start":"2024-03-04","created":4,"completed":1},{"start":"2024-03-11","created":1,"completed":2}],` +
+				`"top_tags":[{"tag":"work","created":3,"completed":2}]}`,
+		}, {
+			name:   "day bucket and UTC by default",
+			query:  "?from=2024-03-04&to=2024-03-17",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAnalytics, userID int64) {
+				s.EXPECT().StatsReport(userID, from, to, model.StatsBucketDay, time.UTC).Return(model.Stats{
+					From:    from,
+					To:      to,
+					Bucket:  model.StatsBucketDay,
+					Buckets: []model.StatsBucket{},
+					TopTags: []model.StatsTag{},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"from":"2024-03-04T00:00:00Z","to":"2024-03-18T00:00:00Z","bucket":"day",` +
+				`"total":{"created":0,"completed":0},"streaks":{"current":0,"longest":0},"buckets":[],"top_tags":[]}`,
+		}, {
+			name:                 "incorrect userID",
+			query:                "?from=2024-03-04&to=2024-03-17",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect date",
+			query:                "?from=2024-03-04",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect date range"}`,
+		}, {
+			name:                 "unknown bucket",
+			query:                "?from=2024-03-04&to=2024-03-17&bucket=month",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect bucket"}`,
+		}, {
+			name:                 "unknown time zone",
+			query:                "?from=2024-03-04&to=2024-03-17&tz=Mars/Olympus",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockAnalytics, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect time zone"}`,
+		}, {
+			name:   "incorrect StatsReport return: internal server error",
+			query:  "?from=2024-03-04&to=2024-03-17",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockAnalytics, userID int64) {
+				s.EXPECT().StatsReport(userID, from, to, model.StatsBucketDay, time.UTC).Return(model.Stats{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't get stats"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			reporter := mock_service.NewMockAnalytics(ctrl)
+			test.mockBehavior(reporter, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Get("/stats/me", Me(logger, reporter))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/stats/me"+test.query, nil)
+
+			rctx := chi.NewRouteContext()
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"restAPI/internal/http-server/request"
 	"restAPI/internal/http-server/response"
 	"restAPI/internal/model"
 )
@@ -15,7 +16,7 @@ type getTaskResponse struct {
 }
 
 type getterByTag interface {
-	GetTasksByTag(tag string, userID int64) ([]model.Task, error)
+	GetTasksByTag(tag string, userID int64, filter model.TaskFilter) ([]model.Task, error)
 }
 
 // Get task by tag
@@ -25,6 +26,9 @@ type getterByTag interface {
 // @Description Get user task by tag
 // @ID getTaskByTag
 // @Param tag path string true "tag"
+// @Param project query int false "project ID"
+// @Param sort query string false "manual for the hand-made order"
+// @Param include_deferred query bool false "also list the tasks snoozed until later"
 // @Produce json
 // @Success 200 {object} getTaskResponse
 // @Failure 400,401 {object} response.Message
@@ -57,7 +61,17 @@ func Get(log *slog.Logger, getterByTag getterByTag) http.HandlerFunc {
 			})
 			return
 		}
-		tasks, err := getterByTag.GetTasksByTag(tag, userID)
+		filter, err := request.TaskFilter(r)
+		if err != nil {
+			log.Error("incorrect filter", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect filter",
+			})
+			return
+		}
+
+		tasks, err := getterByTag.GetTasksByTag(tag, userID, filter)
 		if err != nil {
 			log.Error("couldn't find any task by tag", slog.String("error", err.Error()))
 			w.WriteHeader(http.StatusInternalServerError)
@@ -34,7 +34,7 @@ func TestHandler_GetByTag(t *testing.T) {
 			userID:   1,
 
 			mockBehavior: func(s *mock_service.MockTask, tag string, userID int64) {
-				s.EXPECT().GetTasksByTag(tag, userID).Return([]model.Task{
+				s.EXPECT().GetTasksByTag(tag, userID, model.TaskFilter{}).Return([]model.Task{
 					{
 						ID:      1,
 						Text:    "TestText",
@@ -51,7 +51,7 @@ func TestHandler_GetByTag(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"tasks":[{"text":"TestText","tags":["testTag","testTag2"],"date":"1000-10-10T10:10:10Z"},{"text":"TestText","tags":["testTag"],"date":"2000-10-10T10:10:10Z"}]}`,
+			expectedResponseBody: `{"tasks":[{"text":"TestText","tags":["testTag","testTag2"],"date":"1000-10-10T10:10:10Z","progress":{"done":0,"total":0},"comment_count":0},{"text":"TestText","tags":["testTag"],"date":"2000-10-10T10:10:10Z","progress":{"done":0,"total":0},"comment_count":0}]}`,
 		}, {
 			name:                 "incorrect userID",
 			userID:               -1,
@@ -71,7 +71,7 @@ func TestHandler_GetByTag(t *testing.T) {
 			userID:   1,
 
 			mockBehavior: func(s *mock_service.MockTask, tag string, userID int64) {
-				s.EXPECT().GetTasksByTag(tag, userID).Return(nil, errors.New("test"))
+				s.EXPECT().GetTasksByTag(tag, userID, model.TaskFilter{}).Return(nil, errors.New("test"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"message":"couldn't find any task by tag"}`,
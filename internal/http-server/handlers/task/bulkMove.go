@@ -0,0 +1,124 @@
+package task
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/verification"
+	"time"
+)
+
+type bulkMoveRequest struct {
+	TaskIDs        []int64 `json:"task_ids"`
+	ProjectID      *int64  `json:"project_id"`
+	WithoutProject bool    `json:"without_project"`
+	// Date is a day as 2006-01-02, the tasks keep their time of day
+	Date *string `json:"date"`
+}
+
+type tasksMover interface {
+	MoveTasks(userID int64, move model.TaskMove) error
+}
+
+// BulkMove tasks
+// @Summary BulkMove
+// @Security ApiKeyPath
+// @Tags Task
+// @Description Move many user tasks at once to a project, out of their projects (without_project) and/or to another day. Either all the tasks move or none, a single undo takes the move back
+// @ID bulkMoveTasks
+// @Accept json
+// @Param input body bulkMoveRequest true "tasks and where to move them"
+// @Produce json
+// @Success 204
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/bulk/move [post]
+func BulkMove(log *slog.Logger, mover tasksMover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req bulkMoveRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		move := model.TaskMove{
+			TaskIDs:        req.TaskIDs,
+			ProjectID:      req.ProjectID,
+			WithoutProject: req.WithoutProject,
+		}
+		if req.Date != nil {
+			date, err := time.Parse("2006-01-02", *req.Date)
+			if err != nil {
+				log.Error("incorrect date record", slog.String("error", err.Error()))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, response.Message{
+					Msg: "incorrect date record",
+				})
+				return
+			}
+			move.Date = &date
+		}
+		if !verification.TaskMove(move) {
+			log.Error("incorrect move information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect move information",
+			})
+			return
+		}
+
+		err = mover.MoveTasks(userID, move)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Any("taskIDs", req.TaskIDs))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with one of these task_ids",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrNoProject) {
+			log.Error("there is no project", slog.Int64("userID", userID), slog.Any("projectID", req.ProjectID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no project with this projectID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't move tasks", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't move tasks",
+			})
+			return
+		}
+		log.Info("tasks moved", slog.Int("count", len(req.TaskIDs)))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
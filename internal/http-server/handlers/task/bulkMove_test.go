@@ -0,0 +1,147 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_BulkMoveTasks(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTask, userID int64, move model.TaskMove)
+
+	projectID := int64(4)
+	date := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name                 string
+		inputBody            string
+		userID               int64
+		move                 model.TaskMove
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "correct working: to project",
+			inputBody: `{"task_ids":[1,2],"project_id":4}`,
+			userID:    1,
+			move:      model.TaskMove{TaskIDs: []int64{1, 2}, ProjectID: &projectID},
+			mockBehavior: func(s *mock_service.MockTask, userID int64, move model.TaskMove) {
+				s.EXPECT().MoveTasks(userID, move).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		}, {
+			name:      "correct working: out of project to another day",
+			inputBody: `{"task_ids":[1],"without_project":true,"date":"2024-03-05"}`,
+			userID:    1,
+			move:      model.TaskMove{TaskIDs: []int64{1}, WithoutProject: true, Date: &date},
+			mockBehavior: func(s *mock_service.MockTask, userID int64, move model.TaskMove) {
+				s.EXPECT().MoveTasks(userID, move).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64, move model.TaskMove) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect date",
+			inputBody:            `{"task_ids":[1],"date":"05.03.2024"}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64, move model.TaskMove) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect date record"}`,
+		}, {
+			name:                 "incorrect move: no target",
+			inputBody:            `{"task_ids":[1]}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64, move model.TaskMove) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect move information"}`,
+		}, {
+			name:                 "incorrect move: project and without project",
+			inputBody:            `{"task_ids":[1],"project_id":4,"without_project":true}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64, move model.TaskMove) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect move information"}`,
+		}, {
+			name:                 "incorrect move: no tasks",
+			inputBody:            `{"task_ids":[],"project_id":4}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64, move model.TaskMove) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect move information"}`,
+		}, {
+			name:      "incorrect MoveTasks return: no task",
+			inputBody: `{"task_ids":[1,2],"project_id":4}`,
+			userID:    1,
+			move:      model.TaskMove{TaskIDs: []int64{1, 2}, ProjectID: &projectID},
+			mockBehavior: func(s *mock_service.MockTask, userID int64, move model.TaskMove) {
+				s.EXPECT().MoveTasks(userID, move).Return(repositories.ErrNoTask)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no task with one of these task_ids"}`,
+		}, {
+			name:      "incorrect MoveTasks return: no project",
+			inputBody: `{"task_ids":[1,2],"project_id":4}`,
+			userID:    1,
+			move:      model.TaskMove{TaskIDs: []int64{1, 2}, ProjectID: &projectID},
+			mockBehavior: func(s *mock_service.MockTask, userID int64, move model.TaskMove) {
+				s.EXPECT().MoveTasks(userID, move).Return(repositories.ErrNoProject)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no project with this projectID"}`,
+		}, {
+			name:      "incorrect MoveTasks return: internal server error",
+			inputBody: `{"task_ids":[1,2],"project_id":4}`,
+			userID:    1,
+			move:      model.TaskMove{TaskIDs: []int64{1, 2}, ProjectID: &projectID},
+			mockBehavior: func(s *mock_service.MockTask, userID int64, move model.TaskMove) {
+				s.EXPECT().MoveTasks(userID, move).Return(errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't move tasks"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			task := mock_service.NewMockTask(ctrl)
+			test.mockBehavior(task, test.userID, test.move)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/tasks/bulk/move", BulkMove(logger, task))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/tasks/bulk/move", strings.NewReader(test.inputBody))
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
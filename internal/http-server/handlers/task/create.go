@@ -1,12 +1,14 @@
 package task
 
 import (
+	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
 	"restAPI/internal/http-server/response"
 	"restAPI/internal/model"
+	"restAPI/internal/repositories"
 	"restAPI/pkg/lib/verification"
 	"time"
 )
@@ -27,13 +29,13 @@ type taskCreater interface {
 // @Summary Create
 // @Security ApiKeyPath
 // @Tags Task
-// @Description Create new task
+// @Description Create new task, subtasks given with it are created under it
 // @ID createTask
 // @Accept json
 // @Produce json
 // @Param input body createRequest true "Task info"
 // @Success 201 {object} createResponse
-// @Failure 400,401 {object} response.Message
+// @Failure 400,401,404 {object} response.Message
 // @Failure 500 {object} response.Message
 // @Failure default {object} response.Message
 // @Router /tasks/ [post]
@@ -84,6 +86,22 @@ func Create(log *slog.Logger, creater taskCreater, callTime time.Time) http.Hand
 
 		taskId, err := creater.CreateTask(req.Task)
 
+		if errors.Is(err, repositories.ErrNoProject) {
+			log.Error("there is no project", slog.Int64("userID", userID), slog.Any("projectID", req.Task.ProjectID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no project with this projectID",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no parent task", slog.Int64("userID", userID), slog.Any("parentID", req.Task.ParentID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this parent_id",
+			})
+			return
+		}
 		if err != nil {
 			log.Error("failed to create task:", slog.String("error", err.Error()))
 			w.WriteHeader(http.StatusInternalServerError)
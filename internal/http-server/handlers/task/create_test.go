@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"restAPI/internal/model"
+	"restAPI/internal/repositories"
 	mock_service "restAPI/internal/service/mocks"
 	"strings"
 	"testing"
@@ -21,6 +22,8 @@ import (
 func TestHandler_CreateTask(t *testing.T) {
 	type MockBehavior func(s *mock_service.MockTask, task model.Task)
 
+	parentID := int64(7)
+
 	var tests = []struct {
 		name                 string
 		inputBody            string
@@ -59,6 +62,43 @@ func TestHandler_CreateTask(t *testing.T) {
 			},
 			expectedStatusCode:   http.StatusCreated,
 			expectedResponseBody: `{"task_id":1}`,
+		}, {
+			name:      "correct working with subtasks",
+			inputBody: `{"text":"TestText","subtasks":[{"text":"TestSubtask"}]}`,
+			inputTask: model.Task{
+				Text:     "TestText",
+				Date:     time.Date(1000, 10, 10, 10, 10, 10, 0, time.UTC),
+				OwnerID:  1,
+				Subtasks: []model.Task{{Text: "TestSubtask"}},
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTask, task model.Task) {
+				s.EXPECT().CreateTask(task).Return(int64(1), nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"task_id":1}`,
+		}, {
+			name:                 "subtask without text",
+			inputBody:            `{"text":"TestText","subtasks":[{"tags":["TestTag1"]}]}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, task model.Task) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect task information"}`,
+		}, {
+			name:      "incorrect CreateTask return: no parent",
+			inputBody: `{"text":"TestText","parent_id":7}`,
+			inputTask: model.Task{
+				Text:     "TestText",
+				Date:     time.Date(1000, 10, 10, 10, 10, 10, 0, time.UTC),
+				OwnerID:  1,
+				ParentID: &parentID,
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTask, task model.Task) {
+				s.EXPECT().CreateTask(task).Return(int64(0), repositories.ErrNoTask)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no task with this parent_id"}`,
 		}, {
 			name:                 "incorrect request",
 			inputBody:            `{"text":"TestText}`,
@@ -1,11 +1,13 @@
 package task
 
 import (
+	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
 	"restAPI/internal/http-server/response"
+	"restAPI/internal/service"
 )
 
 type allTasksDeleter interface {
@@ -42,6 +44,10 @@ func DeleteAll(log *slog.Logger, deleter allTasksDeleter) http.HandlerFunc {
 		}
 
 		err := deleter.DeleteAllByUser(userID)
+		if errors.Is(err, service.ErrBlobCleanup) {
+			log.Warn("attachments of deleted tasks were not removed", slog.String("error", err.Error()))
+			err = nil
+		}
 
 		if err != nil {
 			log.Error("couldn't delete all tasks by this user", slog.String("error", err.Error()))
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"restAPI/internal/http-server/response"
 	"restAPI/internal/repositories"
+	"restAPI/internal/service"
 	"strconv"
 )
 
@@ -65,6 +66,11 @@ func Delete(log *slog.Logger, deleter taskDeleter) http.HandlerFunc {
 			return
 		}
 		err = deleter.DeleteTask(int64(taskID), userID)
+		if errors.Is(err, service.ErrBlobCleanup) {
+			// the task is gone already, only its files are left behind
+			log.Warn("attachments of deleted task were not removed", slog.String("error", err.Error()))
+			err = nil
+		}
 		if errors.Is(err, repositories.ErrNoTask) {
 			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
 			w.WriteHeader(http.StatusNotFound)
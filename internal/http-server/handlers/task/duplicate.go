@@ -0,0 +1,115 @@
+package task
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+// duplicateRequest tells what the copy takes along, everything by default.
+type duplicateRequest struct {
+	Tags      *bool `json:"tags"`
+	Checklist *bool `json:"checklist"`
+	Subtasks  *bool `json:"subtasks"`
+}
+
+func (req duplicateRequest) options() model.DuplicateOptions {
+	include := func(option *bool) bool {
+		return option == nil || *option
+	}
+	return model.DuplicateOptions{
+		Tags:      include(req.Tags),
+		Checklist: include(req.Checklist),
+		Subtasks:  include(req.Subtasks),
+	}
+}
+
+type duplicator interface {
+	DuplicateTask(taskID, userID int64, options model.DuplicateOptions) (int64, error)
+}
+
+// Duplicate task
+// @Summary Duplicate
+// @Security ApiKeyPath
+// @Tags Task
+// @Description Copy user task with its tags, checklist and subtasks, the body may leave any of them out. The copy starts as not done
+// @ID duplicateTask
+// @Accept json
+// @Param input body duplicateRequest false "what to copy"
+// @Param task_id path int true "task ID"
+// @Produce json
+// @Success 201 {object} createResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/duplicate [post]
+func Duplicate(log *slog.Logger, duplicator duplicator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		var req duplicateRequest
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil && !errors.Is(err, io.EOF) {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		copyID, err := duplicator.DuplicateTask(int64(taskID), userID, req.options())
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't duplicate task", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't duplicate task",
+			})
+			return
+		}
+		log.Info("task duplicated", slog.Int("taskID", taskID), slog.Int64("copyID", copyID))
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, createResponse{
+			TaskID: copyID,
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_DuplicateTask(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTask, taskID, userID int64, options model.DuplicateOptions)
+
+	everything := model.DuplicateOptions{Tags: true, Checklist: true, Subtasks: true}
+
+	var tests = []struct {
+		name                 string
+		stringTaskID         string
+		inputBody            string
+		taskID               int64
+		userID               int64
+		options              model.DuplicateOptions
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:         "correct working",
+			stringTaskID: "1",
+			taskID:       1,
+			userID:       1,
+			options:      everything,
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, options model.DuplicateOptions) {
+				s.EXPECT().DuplicateTask(taskID, userID, options).Return(int64(7), nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"task_id":7}`,
+		}, {
+			name:         "correct working: without checklist",
+			stringTaskID: "1",
+			inputBody:    `{"checklist":false}`,
+			taskID:       1,
+			userID:       1,
+			options:      model.DuplicateOptions{Tags: true, Subtasks: true},
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, options model.DuplicateOptions) {
+				s.EXPECT().DuplicateTask(taskID, userID, options).Return(int64(7), nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"task_id":7}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, options model.DuplicateOptions) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect taskID",
+			stringTaskID:         "a1",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, options model.DuplicateOptions) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect task id record"}`,
+		}, {
+			name:                 "incorrect body",
+			stringTaskID:         "1",
+			inputBody:            `{"tags":"no"}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, options model.DuplicateOptions) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"failed to decode request"}`,
+		}, {
+			name:         "incorrect DuplicateTask return: no task",
+			stringTaskID: "1",
+			taskID:       1,
+			userID:       1,
+			options:      everything,
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, options model.DuplicateOptions) {
+				s.EXPECT().DuplicateTask(taskID, userID, options).Return(int64(0), repositories.ErrNoTask)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no task with this taskID"}`,
+		}, {
+			name:         "incorrect DuplicateTask return: internal server error",
+			stringTaskID: "1",
+			taskID:       1,
+			userID:       1,
+			options:      everything,
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, options model.DuplicateOptions) {
+				s.EXPECT().DuplicateTask(taskID, userID, options).Return(int64(0), errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't duplicate task"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			task := mock_service.NewMockTask(ctrl)
+			test.mockBehavior(task, test.taskID, test.userID, test.options)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/task/duplicate", Duplicate(logger, task))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/task/duplicate", strings.NewReader(test.inputBody))
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("taskId", test.stringTaskID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
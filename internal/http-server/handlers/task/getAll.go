@@ -5,6 +5,7 @@ import (
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"restAPI/internal/http-server/request"
 	"restAPI/internal/http-server/response"
 	"restAPI/internal/model"
 )
@@ -14,7 +15,7 @@ type getAllResponse struct {
 }
 
 type allGetterByUser interface {
-	GetAllByUser(userID int64) ([]model.Task, error)
+	GetAllByUser(userID int64, filter model.TaskFilter) ([]model.Task, error)
 }
 
 // GetAll user tasks
@@ -23,9 +24,12 @@ type allGetterByUser interface {
 // @Tags Task
 // @Description Get all user tasks
 // @ID getAllUserTasks
+// @Param project query int false "project ID"
+// @Param sort query string false "manual for the hand-made order"
+// @Param include_deferred query bool false "also list the tasks snoozed until later"
 // @Produce json
 // @Success 200 {object} getAllResponse
-// @Failure 401 {object} response.Message
+// @Failure 400,401 {object} response.Message
 // @Failure 500 {object} response.Message
 // @Failure default {object} response.Message
 // @Router /tasks/ [get]
@@ -46,7 +50,17 @@ func GetAll(log *slog.Logger, getter allGetterByUser) http.HandlerFunc {
 			return
 		}
 
-		tasks, err := getter.GetAllByUser(userID)
+		filter, err := request.TaskFilter(r)
+		if err != nil {
+			log.Error("incorrect filter", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect filter",
+			})
+			return
+		}
+
+		tasks, err := getter.GetAllByUser(userID, filter)
 
 		if err != nil {
 			log.Error("couldn't get all tasks by this user", slog.String("error", err.Error()))
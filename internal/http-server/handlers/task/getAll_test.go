@@ -20,8 +20,12 @@ import (
 func TestHandler_GetAll(t *testing.T) {
 	type MockBehavior func(s *mock_service.MockTask, userID int64)
 
+	projectID := int64(3)
+	hiddenUntil := time.Date(2000, 10, 11, 8, 0, 0, 0, time.UTC)
+
 	var tests = []struct {
 		name                 string
+		query                string
 		userID               int64
 		mockBehavior         MockBehavior
 		expectedStatusCode   int
@@ -32,7 +36,7 @@ func TestHandler_GetAll(t *testing.T) {
 			userID: 1,
 
 			mockBehavior: func(s *mock_service.MockTask, userID int64) {
-				s.EXPECT().GetAllByUser(userID).Return([]model.Task{
+				s.EXPECT().GetAllByUser(userID, model.TaskFilter{}).Return([]model.Task{
 					{
 						ID:      1,
 						Text:    "TestText",
@@ -49,7 +53,7 @@ func TestHandler_GetAll(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"tasks":[{"text":"TestText","tags":["testTag","testTag2"],"date":"1000-10-10T10:10:10Z"},{"text":"TestText","tags":["testTag"],"date":"2000-10-10T10:10:10Z"}]}`,
+			expectedResponseBody: `{"tasks":[{"text":"TestText","tags":["testTag","testTag2"],"date":"1000-10-10T10:10:10Z","progress":{"done":0,"total":0},"comment_count":0},{"text":"TestText","tags":["testTag"],"date":"2000-10-10T10:10:10Z","progress":{"done":0,"total":0},"comment_count":0}]}`,
 		}, {
 			name:                 "incorrect userID",
 			userID:               -1,
@@ -61,10 +65,62 @@ func TestHandler_GetAll(t *testing.T) {
 			userID: 1,
 
 			mockBehavior: func(s *mock_service.MockTask, userID int64) {
-				s.EXPECT().GetAllByUser(userID).Return(nil, errors.New("test"))
+				s.EXPECT().GetAllByUser(userID, model.TaskFilter{}).Return(nil, errors.New("test"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"message":"couldn't get all task"}`,
+		}, {
+			name:   "project filter",
+			query:  "?project=3",
+			userID: 1,
+
+			mockBehavior: func(s *mock_service.MockTask, userID int64) {
+				s.EXPECT().GetAllByUser(userID, model.TaskFilter{ProjectID: &projectID}).Return([]model.Task{
+					{
+						ID:        1,
+						Text:      "TestText",
+						Tags:      []string{"testTag"},
+						Date:      time.Date(2000, 10, 10, 10, 10, 10, 0, time.UTC),
+						OwnerID:   1,
+						ProjectID: &projectID,
+					},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"tasks":[{"text":"TestText","tags":["testTag"],"date":"2000-10-10T10:10:10Z","project_id":3,"progress":{"done":0,"total":0},"comment_count":0}]}`,
+		}, {
+			name:                 "incorrect project filter",
+			query:                "?project=a",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect filter"}`,
+		}, {
+			name:   "deferred tasks included",
+			query:  "?include_deferred=true",
+			userID: 1,
+
+			mockBehavior: func(s *mock_service.MockTask, userID int64) {
+				s.EXPECT().GetAllByUser(userID, model.TaskFilter{IncludeDeferred: true}).Return([]model.Task{
+					{
+						ID:          1,
+						Text:        "TestText",
+						Tags:        []string{"testTag"},
+						Date:        time.Date(2000, 10, 10, 10, 10, 10, 0, time.UTC),
+						OwnerID:     1,
+						HiddenUntil: &hiddenUntil,
+					},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"tasks":[{"text":"TestText","tags":["testTag"],"date":"2000-10-10T10:10:10Z","hidden_until":"2000-10-11T08:00:00Z","progress":{"done":0,"total":0},"comment_count":0}]}`,
+		}, {
+			name:                 "incorrect include_deferred filter",
+			query:                "?include_deferred=maybe",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect filter"}`,
 		},
 	}
 
@@ -84,7 +140,7 @@ func TestHandler_GetAll(t *testing.T) {
 			router.Get("/tasks/", GetAll(logger, task))
 
 			w := httptest.NewRecorder()
-			r := httptest.NewRequest(http.MethodGet, "/tasks/", nil)
+			r := httptest.NewRequest(http.MethodGet, "/tasks/"+test.query, nil)
 
 			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
 
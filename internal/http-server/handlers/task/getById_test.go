@@ -43,10 +43,14 @@ func TestHandler_GetTask(t *testing.T) {
 					Tags:    []string{"testTag1", "testTag2"},
 					Date:    time.Date(1000, 10, 10, 10, 10, 10, 0, time.UTC),
 					OwnerID: 1,
+					Progress: model.TaskProgress{
+						Done:  3,
+						Total: 7,
+					},
 				}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"task":{"text":"TestText","tags":["testTag1","testTag2"],"date":"1000-10-10T10:10:10Z"}}`,
+			expectedResponseBody: `{"task":{"text":"TestText","tags":["testTag1","testTag2"],"date":"1000-10-10T10:10:10Z","progress":{"done":3,"total":7},"comment_count":0}}`,
 		}, {
 			name:                 "incorrect userID",
 			userID:               -1,
@@ -0,0 +1,109 @@
+package task
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type mover interface {
+	MoveTask(taskID, userID int64, after, before *int64) error
+}
+
+// Move task
+// @Summary Move
+// @Security ApiKeyPath
+// @Tags Task
+// @Description Put user task between two others in the manual order, see sort=manual on the list endpoints
+// @ID moveTask
+// @Param task_id path int true "task ID"
+// @Param after query int false "task that goes right before the moved one"
+// @Param before query int false "task that goes right after the moved one"
+// @Produce json
+// @Success 204
+// @Failure 400,401,404,409 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/move [post]
+func Move(log *slog.Logger, mover mover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		after, err := request.OptionalID(r, "after")
+		if err != nil {
+			log.Error("incorrect after record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect after record",
+			})
+			return
+		}
+		before, err := request.OptionalID(r, "before")
+		if err != nil {
+			log.Error("incorrect before record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect before record",
+			})
+			return
+		}
+
+		err = mover.MoveTask(int64(taskID), userID, after, before)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrTaskOrder) {
+			log.Error("tasks are not in this order", slog.Any("after", after), slog.Any("before", before))
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, response.Message{
+				Msg: "after task doesn't go before the before task",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't move task", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't move task",
+			})
+			return
+		}
+		log.Info("task moved", slog.Int("taskID", taskID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,139 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_MoveTask(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTask, taskID, userID int64)
+
+	after, before := int64(2), int64(3)
+
+	var tests = []struct {
+		name                 string
+		stringTaskID         string
+		query                string
+		taskID               int64
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:         "correct working",
+			stringTaskID: "1",
+			query:        "?after=2&before=3",
+			taskID:       1,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64) {
+				s.EXPECT().MoveTask(taskID, userID, &after, &before).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		}, {
+			name:         "correct working: to the end",
+			stringTaskID: "1",
+			taskID:       1,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64) {
+				s.EXPECT().MoveTask(taskID, userID, nil, nil).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect taskID",
+			stringTaskID:         "a1",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect task id record"}`,
+		}, {
+			name:                 "incorrect after",
+			stringTaskID:         "1",
+			query:                "?after=b",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect after record"}`,
+		}, {
+			name:         "incorrect MoveTask return: no task",
+			stringTaskID: "1",
+			query:        "?before=3",
+			taskID:       1,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64) {
+				s.EXPECT().MoveTask(taskID, userID, nil, &before).Return(repositories.ErrNoTask)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no task with this taskID"}`,
+		}, {
+			name:         "incorrect MoveTask return: wrong order",
+			stringTaskID: "1",
+			query:        "?after=3&before=2",
+			taskID:       1,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64) {
+				s.EXPECT().MoveTask(taskID, userID, &before, &after).Return(repositories.ErrTaskOrder)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"after task doesn't go before the before task"}`,
+		}, {
+			name:         "incorrect MoveTask return: internal server error",
+			stringTaskID: "1",
+			taskID:       1,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64) {
+				s.EXPECT().MoveTask(taskID, userID, nil, nil).Return(errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't move task"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			task := mock_service.NewMockTask(ctrl)
+			test.mockBehavior(task, test.taskID, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/task/move", Move(logger, task))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/task/move"+test.query, nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("taskId", test.stringTaskID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package task
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/quickadd"
+	"restAPI/pkg/lib/verification"
+	"time"
+)
+
+type quickRequest struct {
+	// Text is the whole line, like "Pay rent tomorrow 9am #finance !high every month"
+	Text      string `json:"text"`
+	ProjectID *int64 `json:"project_id"`
+}
+
+// quickInterpretation tells how the line was read, so clients can confirm it.
+type quickInterpretation struct {
+	Text string   `json:"text"`
+	Tags []string `json:"tags"`
+	// Date is only set when the line named a day or a time, the task is then
+	// dated by it, otherwise by the time of the request
+	Date       *time.Time `json:"date,omitempty"`
+	HasTime    bool       `json:"has_time"`
+	Priority   string     `json:"priority,omitempty"`
+	Recurrence string     `json:"recurrence,omitempty"`
+}
+
+type quickResponse struct {
+	TaskID int64               `json:"task_id"`
+	Parsed quickInterpretation `json:"parsed"`
+}
+
+// Quick task
+// @Summary Quick
+// @Security ApiKeyPath
+// @Tags Task
+// @Description Create task from a single line of text. The line may name a day (today, tomorrow, friday, next week, in 3 days, march 5, 2024-03-05), a time (9am, 18:30, at noon), #tags, a priority (!high, !medium, !low or !!!, !!) and a repeat rule (daily, every 2 weeks, every monday and thursday). Days are read in the tz zone
+// @ID quickTask
+// @Accept json
+// @Produce json
+// @Param input body quickRequest true "line of text"
+// @Param tz query string false "IANA time zone of the user, UTC by default"
+// @Success 201 {object} quickResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/quick [post]
+func Quick(log *slog.Logger, creater taskCreater, now func() time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		loc, err := request.Location(r)
+		if err != nil {
+			log.Error("incorrect time zone", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect time zone",
+			})
+			return
+		}
+
+		var req quickRequest
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		callTime := now().In(loc)
+		parsed, err := quickadd.Parse(req.Text, callTime)
+		if err != nil {
+			log.Error("can't parse task line", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task text in this line",
+			})
+			return
+		}
+
+		task := model.Task{
+			Text:      parsed.Text,
+			Tags:      parsed.Tags,
+			Date:      callTime,
+			OwnerID:   userID,
+			ProjectID: req.ProjectID,
+		}
+		if parsed.Date != nil {
+			task.Date = *parsed.Date
+		}
+		if parsed.Priority != "" {
+			task.Priority = &parsed.Priority
+		}
+		if parsed.Recurrence != "" {
+			task.Recurrence = &parsed.Recurrence
+		}
+		if !verification.Task(task) {
+			log.Error("incorrect task information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task information",
+			})
+			return
+		}
+
+		taskID, err := creater.CreateTask(task)
+		if errors.Is(err, repositories.ErrNoProject) {
+			log.Error("there is no project", slog.Int64("userID", userID), slog.Any("projectID", req.ProjectID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no project with this projectID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to create task:", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to create task",
+			})
+			return
+		}
+		log.Info("task created", slog.Int64("taskID", taskID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, quickResponse{
+			TaskID: taskID,
+			Parsed: quickInterpretation{
+				Text:       parsed.Text,
+				Tags:       parsed.Tags,
+				Date:       parsed.Date,
+				HasTime:    parsed.HasTime,
+				Priority:   parsed.Priority,
+				Recurrence: parsed.Recurrence,
+			},
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_QuickTask(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTask, task model.Task)
+
+	// a wednesday
+	now := time.Date(2024, time.March, 6, 10, 30, 0, 0, time.UTC)
+	high := model.TaskPriorityHigh
+	monthly := "FREQ=MONTHLY"
+	projectID := int64(3)
+
+	var tests = []struct {
+		name                 string
+		inputBody            string
+		query                string
+		inputTask            model.Task
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "correct working",
+			inputBody: `{"text":"Pay rent tomorrow 9am #finance !high every month","project_id":3}`,
+			inputTask: model.Task{
+				Text:       "Pay rent",
+				Tags:       []string{"finance"},
+				Date:       time.Date(2024, time.March, 7, 9, 0, 0, 0, time.UTC),
+				OwnerID:    1,
+				ProjectID:  &projectID,
+				Priority:   &high,
+				Recurrence: &monthly,
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTask, task model.Task) {
+				s.EXPECT().CreateTask(task).Return(int64(5), nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponseBody: `{"task_id":5,"parsed":{"text":"Pay rent","tags":["finance"],` +
+				`"date":"2024-03-07T09:00:00Z","has_time":true,"priority":"high","recurrence":"FREQ=MONTHLY"}}`,
+		}, {
+			name:      "correct working: nothing to parse",
+			inputBody: `{"text":"Read a book"}`,
+			inputTask: model.Task{
+				Text:    "Read a book",
+				Tags:    []string{},
+				Date:    now,
+				OwnerID: 1,
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTask, task model.Task) {
+				s.EXPECT().CreateTask(task).Return(int64(5), nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"task_id":5,"parsed":{"text":"Read a book","tags":[],"has_time":false}}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockTask, task model.Task) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect time zone",
+			inputBody:            `{"text":"Read a book"}`,
+			query:                "?tz=Mars/Olympus",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, task model.Task) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect time zone"}`,
+		}, {
+			name:                 "incorrect line: only markers",
+			inputBody:            `{"text":"tomorrow #home"}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, task model.Task) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"there no task text in this line"}`,
+		}, {
+			name:      "incorrect CreateTask return: no project",
+			inputBody: `{"text":"Read a book","project_id":3}`,
+			inputTask: model.Task{
+				Text:      "Read a book",
+				Tags:      []string{},
+				Date:      now,
+				OwnerID:   1,
+				ProjectID: &projectID,
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTask, task model.Task) {
+				s.EXPECT().CreateTask(task).Return(int64(0), repositories.ErrNoProject)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no project with this projectID"}`,
+		}, {
+			name:      "incorrect CreateTask return: internal server error",
+			inputBody: `{"text":"Read a book"}`,
+			inputTask: model.Task{
+				Text:    "Read a book",
+				Tags:    []string{},
+				Date:    now,
+				OwnerID: 1,
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTask, task model.Task) {
+				s.EXPECT().CreateTask(task).Return(int64(0), errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"failed to create task"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			task := mock_service.NewMockTask(ctrl)
+			test.mockBehavior(task, test.inputTask)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/tasks/quick", Quick(logger, task, func() time.Time { return now }))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/tasks/quick"+test.query, bytes.NewBufferString(test.inputBody))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
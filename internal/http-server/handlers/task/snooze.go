@@ -0,0 +1,239 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/request"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"strconv"
+	"time"
+)
+
+const (
+	// morningHour and eveningHour are when snoozed tasks come back.
+	morningHour = 8
+	eveningHour = 19
+)
+
+// snoozeRequest takes either a preset or an exact time.
+type snoozeRequest struct {
+	// Preset is one of tonight, tomorrow, weekend and next_week
+	Preset string     `json:"preset"`
+	Until  *time.Time `json:"until"`
+}
+
+type snoozeResponse struct {
+	HiddenUntil time.Time `json:"hidden_until"`
+}
+
+type snoozer interface {
+	SnoozeTask(taskID, userID int64, until *time.Time) error
+}
+
+// snoozeUntil turns a preset into a time: tonight is 19:00 today, tomorrow
+// 8:00 tomorrow, weekend 8:00 on the coming saturday and next_week 8:00 on
+// the next monday.
+func snoozeUntil(preset string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var until time.Time
+	switch preset {
+	case model.SnoozeTonight:
+		until = today.Add(eveningHour * time.Hour)
+	case model.SnoozeTomorrow:
+		until = today.AddDate(0, 0, 1).Add(morningHour * time.Hour)
+	case model.SnoozeWeekend:
+		// on a saturday the coming one is a week later
+		days := (int(time.Saturday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		until = today.AddDate(0, 0, days).Add(morningHour * time.Hour)
+	case model.SnoozeNextWeek:
+		until = today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7).Add(morningHour * time.Hour)
+	default:
+		return time.Time{}, fmt.Errorf("unknown preset %q", preset)
+	}
+	if !until.After(now) {
+		return time.Time{}, fmt.Errorf("preset %q has already passed", preset)
+	}
+	return until, nil
+}
+
+// Snooze task
+// @Summary Snooze
+// @Security ApiKeyPath
+// @Tags Task
+// @Description Hide user task from the task lists until later. Give either a preset (tonight, tomorrow, weekend, next_week), read in the tz zone, or an exact until time
+// @ID snoozeTask
+// @Accept json
+// @Param input body snoozeRequest true "when the task comes back"
+// @Param task_id path int true "task ID"
+// @Param tz query string false "IANA time zone of the user, UTC by default"
+// @Produce json
+// @Success 200 {object} snoozeResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/snooze [post]
+func Snooze(log *slog.Logger, snoozer snoozer, now func() time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		loc, err := request.Location(r)
+		if err != nil {
+			log.Error("incorrect time zone", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect time zone",
+			})
+			return
+		}
+
+		var req snoozeRequest
+		err = render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		callTime := now().In(loc)
+		var until time.Time
+		switch {
+		case req.Preset != "" && req.Until == nil:
+			until, err = snoozeUntil(req.Preset, callTime)
+		case req.Preset == "" && req.Until != nil:
+			until = *req.Until
+			if !until.After(callTime) {
+				err = fmt.Errorf("until %s has already passed", until)
+			}
+		default:
+			err = errors.New("one of preset and until is needed")
+		}
+		if err != nil {
+			log.Error("incorrect snooze information", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect snooze information",
+			})
+			return
+		}
+
+		err = snoozer.SnoozeTask(int64(taskID), userID, &until)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't snooze task", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't snooze task",
+			})
+			return
+		}
+		log.Info("task snoozed", slog.Int("taskID", taskID), slog.Time("until", until))
+		render.JSON(w, r, snoozeResponse{
+			HiddenUntil: until.UTC(),
+		})
+	}
+}
+
+// Unsnooze task
+// @Summary Unsnooze
+// @Security ApiKeyPath
+// @Tags Task
+// @Description Bring a snoozed user task back to the task lists right away
+// @ID unsnoozeTask
+// @Param task_id path int true "task ID"
+// @Produce json
+// @Success 204
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/snooze [delete]
+func Unsnooze(log *slog.Logger, snoozer snoozer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		err = snoozer.SnoozeTask(int64(taskID), userID, nil)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't unsnooze task", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't unsnooze task",
+			})
+			return
+		}
+		log.Info("task unsnoozed", slog.Int("taskID", taskID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,200 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_SnoozeTask(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTask, taskID, userID int64, until time.Time)
+
+	// a wednesday
+	now := time.Date(2024, time.March, 6, 10, 30, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name                 string
+		stringTaskID         string
+		inputBody            string
+		query                string
+		taskID               int64
+		userID               int64
+		until                time.Time
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:         "correct working: tonight",
+			stringTaskID: "1",
+			inputBody:    `{"preset":"tonight"}`,
+			taskID:       1,
+			userID:       1,
+			until:        time.Date(2024, time.March, 6, 19, 0, 0, 0, time.UTC),
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, until time.Time) {
+				s.EXPECT().SnoozeTask(taskID, userID, gomock.Any()).DoAndReturn(
+					func(taskID, userID int64, got *time.Time) error {
+						assert.True(t, until.Equal(*got))
+						return nil
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"hidden_until":"2024-03-06T19:00:00Z"}`,
+		}, {
+			name:         "correct working: next week in a zone",
+			stringTaskID: "1",
+			inputBody:    `{"preset":"next_week"}`,
+			query:        "?tz=Europe/Berlin",
+			taskID:       1,
+			userID:       1,
+			until:        time.Date(2024, time.March, 11, 8, 0, 0, 0, berlin),
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, until time.Time) {
+				s.EXPECT().SnoozeTask(taskID, userID, gomock.Any()).DoAndReturn(
+					func(taskID, userID int64, got *time.Time) error {
+						assert.True(t, until.Equal(*got))
+						return nil
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"hidden_until":"2024-03-11T07:00:00Z"}`,
+		}, {
+			name:         "correct working: exact time",
+			stringTaskID: "1",
+			inputBody:    `{"until":"2024-03-09T12:00:00Z"}`,
+			taskID:       1,
+			userID:       1,
+			until:        time.Date(2024, time.March, 9, 12, 0, 0, 0, time.UTC),
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, until time.Time) {
+				s.EXPECT().SnoozeTask(taskID, userID, gomock.Any()).DoAndReturn(
+					func(taskID, userID int64, got *time.Time) error {
+						assert.True(t, until.Equal(*got))
+						return nil
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"hidden_until":"2024-03-09T12:00:00Z"}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, until time.Time) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect taskID",
+			stringTaskID:         "a1",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, until time.Time) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect task id record"}`,
+		}, {
+			name:                 "incorrect preset",
+			stringTaskID:         "1",
+			inputBody:            `{"preset":"someday"}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, until time.Time) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect snooze information"}`,
+		}, {
+			name:                 "incorrect until: already passed",
+			stringTaskID:         "1",
+			inputBody:            `{"until":"2024-03-05T12:00:00Z"}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, until time.Time) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect snooze information"}`,
+		}, {
+			name:                 "incorrect body: preset and until",
+			stringTaskID:         "1",
+			inputBody:            `{"preset":"tomorrow","until":"2024-03-09T12:00:00Z"}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, until time.Time) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect snooze information"}`,
+		}, {
+			name:         "incorrect SnoozeTask return: no task",
+			stringTaskID: "1",
+			inputBody:    `{"preset":"tomorrow"}`,
+			taskID:       1,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, until time.Time) {
+				s.EXPECT().SnoozeTask(taskID, userID, gomock.Any()).Return(repositories.ErrNoTask)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no task with this taskID"}`,
+		}, {
+			name:         "incorrect SnoozeTask return: internal server error",
+			stringTaskID: "1",
+			inputBody:    `{"preset":"weekend"}`,
+			taskID:       1,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, until time.Time) {
+				s.EXPECT().SnoozeTask(taskID, userID, gomock.Any()).Return(errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't snooze task"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			task := mock_service.NewMockTask(ctrl)
+			test.mockBehavior(task, test.taskID, test.userID, test.until)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/task/snooze", Snooze(logger, task, func() time.Time { return now }))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/task/snooze"+test.query, bytes.NewBufferString(test.inputBody))
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("taskId", test.stringTaskID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestSnoozeUntil(t *testing.T) {
+	// a saturday evening
+	now := time.Date(2024, time.March, 9, 20, 0, 0, 0, time.UTC)
+
+	weekend, err := snoozeUntil("weekend", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.March, 16, 8, 0, 0, 0, time.UTC), weekend)
+
+	nextWeek, err := snoozeUntil("next_week", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.March, 11, 8, 0, 0, 0, time.UTC), nextWeek)
+
+	_, err = snoozeUntil("tonight", now)
+	assert.Error(t, err)
+}
@@ -8,26 +8,29 @@ import (
 	"log/slog"
 	"net/http"
 	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
 	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/verification"
 	"strconv"
 )
 
 type updateRequest struct {
-	Text string   `json:"text"`
-	Tags []string `json:"tags"`
+	Text     string   `json:"text"`
+	Tags     []string `json:"tags"`
+	Estimate *int64   `json:"estimate"`
 }
 
 type taskUpdater interface {
-	UpdateTask(taskID, userID int64, Text string, Tags []string) error
+	UpdateTask(taskID, userID int64, Text string, Tags []string, Estimate *int64) error
 }
 
 // Update task by ID
 // @Summary Update
 // @Security ApiKeyPath
 // @Tags Task
-// @Description Update user task by ID
+// @Description Update user task by ID, a missing estimate removes it
 // @ID updateTaskByID
-// @Param input body updateRequest true "new text and tags"
+// @Param input body updateRequest true "new text, tags and estimate in seconds"
 // @Param task_id path int true "task ID"
 // @Produce json
 // @Success 204
@@ -73,6 +76,15 @@ func Update(log *slog.Logger, updater taskUpdater) http.HandlerFunc {
 			return
 		}
 
+		if !verification.Task(model.Task{Text: req.Text, Tags: req.Tags, Estimate: req.Estimate}) {
+			log.Error("incorrect task information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task information",
+			})
+			return
+		}
+
 		taskIdString := chi.URLParam(r, "taskId")
 		if taskIdString == "" {
 			log.Error("failed to get task id from url")
@@ -92,7 +104,7 @@ func Update(log *slog.Logger, updater taskUpdater) http.HandlerFunc {
 			return
 		}
 		log.Info("request body decoded", slog.Any("request", req))
-		err = updater.UpdateTask(int64(taskID), userID, req.Text, req.Tags)
+		err = updater.UpdateTask(int64(taskID), userID, req.Text, req.Tags, req.Estimate)
 		if errors.Is(err, repositories.ErrNoTask) {
 			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
 			w.WriteHeader(http.StatusNotFound)
@@ -17,7 +17,9 @@ import (
 )
 
 func TestHandler_UpdateTask(t *testing.T) {
-	type MockBehavior func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string)
+	type MockBehavior func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string, estimate *int64)
+
+	estimate := int64(5400)
 
 	var tests = []struct {
 		name                 string
@@ -40,28 +42,51 @@ func TestHandler_UpdateTask(t *testing.T) {
 				Text: "testText",
 				Tags: []string{"testTag1", "testTag2"},
 			},
-			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string) {
-				s.EXPECT().UpdateTask(taskID, userID, text, tags).Return(nil)
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string, estimate *int64) {
+				s.EXPECT().UpdateTask(taskID, userID, text, tags, estimate).Return(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+		}, {
+			name:         "correct working with estimate",
+			inputBody:    `{"text":"testText","tags":["testTag1"],"estimate":5400}`,
+			stringTaskID: "1",
+			taskID:       1,
+			userID:       1,
+			inputRequest: updateRequest{
+				Text:     "testText",
+				Tags:     []string{"testTag1"},
+				Estimate: &estimate,
+			},
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string, estimate *int64) {
+				s.EXPECT().UpdateTask(taskID, userID, text, tags, estimate).Return(nil)
 			},
 			expectedStatusCode: http.StatusNoContent,
+		}, {
+			name:                 "incorrect estimate",
+			inputBody:            `{"text":"testText","estimate":-60}`,
+			stringTaskID:         "1",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string, estimate *int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect task information"}`,
 		}, {
 			name:                 "incorrect userID",
 			userID:               -1,
-			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string) {},
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string, estimate *int64) {},
 			expectedStatusCode:   http.StatusUnauthorized,
 			expectedResponseBody: `{"message":"failed to get auth id"}`,
 		}, {
 			name:                 "bad request",
 			inputBody:            `{"text":"testText","tags":["testTag1, "testTag2"]}`,
 			userID:               1,
-			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string) {},
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string, estimate *int64) {},
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"message":"failed to decode request"}`,
 		}, {
 			name:                 "empty text",
 			userID:               1,
 			inputBody:            `{"tags":["testTag1", "testTag2"]}`,
-			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string) {},
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string, estimate *int64) {},
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"message":"there is no text in task"}`,
 		}, {
@@ -69,7 +94,7 @@ func TestHandler_UpdateTask(t *testing.T) {
 			inputBody:            `{"text":"testText","tags":["testTag1", "testTag2"]}`,
 			stringTaskID:         "",
 			userID:               1,
-			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string) {},
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string, estimate *int64) {},
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"message":"failed to get task id from url"}`,
 		}, {
@@ -77,7 +102,7 @@ func TestHandler_UpdateTask(t *testing.T) {
 			inputBody:            `{"text":"testText","tags":["testTag1", "testTag2"]}`,
 			stringTaskID:         "a1",
 			userID:               1,
-			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string) {},
+			mockBehavior:         func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string, estimate *int64) {},
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"message":"incorrect task id record"}`,
 		}, {
@@ -90,8 +115,8 @@ func TestHandler_UpdateTask(t *testing.T) {
 				Text: "testText",
 				Tags: []string{"testTag1", "testTag2"},
 			},
-			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string) {
-				s.EXPECT().UpdateTask(taskID, userID, text, tags).Return(repositories.ErrNoTask)
+			mockBehavior: func(s *mock_service.MockTask, taskID, userID int64, text string, tags []string, estimate *int64) {
+				s.EXPECT().UpdateTask(taskID, userID, text, tags, estimate).Return(repositories.ErrNoTask)
 			},
 			expectedStatusCode:   http.StatusNotFound,
 			expectedResponseBody: `{"message":"there no task with this taskID"}`,
@@ -106,7 +131,7 @@ func TestHandler_UpdateTask(t *testing.T) {
 			defer ctrl.Finish()
 
 			task := mock_service.NewMockTask(ctrl)
-			test.mockBehavior(task, test.taskID, test.userID, test.inputRequest.Text, test.inputRequest.Tags)
+			test.mockBehavior(task, test.taskID, test.userID, test.inputRequest.Text, test.inputRequest.Tags, test.inputRequest.Estimate)
 
 			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
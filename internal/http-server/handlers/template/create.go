@@ -0,0 +1,99 @@
+package template
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/verification"
+)
+
+type createRequest struct {
+	Name  string               `json:"name"`
+	Tasks []model.TemplateTask `json:"tasks"`
+}
+
+type createResponse struct {
+	TemplateID int64 `json:"template_id"`
+}
+
+type creater interface {
+	CreateTemplate(template model.Template) (int64, error)
+}
+
+// Create template
+// @Summary Create
+// @Security ApiKeyPath
+// @Tags Template
+// @Description Create a reusable set of tasks. Texts and tags may hold {{variables}},
+// @Description due_offset is the number of days from the start of an instance to the due date
+// @ID createTemplate
+// @Accept json
+// @Produce json
+// @Param input body createRequest true "Template info"
+// @Success 201 {object} createResponse
+// @Failure 400,401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /templates/ [post]
+func Create(log *slog.Logger, creater creater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req createRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		template := model.Template{
+			Name:    req.Name,
+			OwnerID: userID,
+			Tasks:   req.Tasks,
+		}
+		if !verification.Template(template) {
+			log.Error("incorrect template information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect template information",
+			})
+			return
+		}
+
+		templateID, err := creater.CreateTemplate(template)
+		if err != nil {
+			log.Error("failed to create template", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to create template",
+			})
+			return
+		}
+		log.Info("template created", slog.Int64("templateID", templateID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, createResponse{
+			TemplateID: templateID,
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_CreateTemplate(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTemplate, template model.Template)
+
+	dueOffset := 3
+
+	var tests = []struct {
+		name                 string
+		inputBody            string
+		inputTemplate        model.Template
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "correct working",
+			inputBody: `{"name":"onboarding","tasks":[{"text":"Laptop for {{name}}","tags":["it"],"due_offset":3,` +
+				`"subtasks":[{"text":"Order"},{"text":"Set up"}]}]}`,
+			inputTemplate: model.Template{
+				Name:    "onboarding",
+				OwnerID: 1,
+				Tasks: []model.TemplateTask{
+					{
+						Text:      "Laptop for {{name}}",
+						Tags:      []string{"it"},
+						DueOffset: &dueOffset,
+						Subtasks:  []model.TemplateTask{{Text: "Order"}, {Text: "Set up"}},
+					},
+				},
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTemplate, template model.Template) {
+				s.EXPECT().CreateTemplate(template).Return(int64(2), nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"template_id":2}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockTemplate, template model.Template) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "bad request",
+			inputBody:            `{"name":"onboarding","tasks":[}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTemplate, template model.Template) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"failed to decode request"}`,
+		}, {
+			name:                 "no tasks",
+			inputBody:            `{"name":"onboarding","tasks":[]}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTemplate, template model.Template) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect template information"}`,
+		}, {
+			name:                 "subtask without text",
+			inputBody:            `{"name":"onboarding","tasks":[{"text":"Laptop","subtasks":[{"tags":["it"]}]}]}`,
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTemplate, template model.Template) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect template information"}`,
+		}, {
+			name:      "incorrect CreateTemplate return: internal server error",
+			inputBody: `{"name":"onboarding","tasks":[{"text":"Badge"}]}`,
+			inputTemplate: model.Template{
+				Name:    "onboarding",
+				OwnerID: 1,
+				Tasks:   []model.TemplateTask{{Text: "Badge"}},
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTemplate, template model.Template) {
+				s.EXPECT().CreateTemplate(template).Return(int64(0), errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"failed to create template"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			template := mock_service.NewMockTemplate(ctrl)
+			test.mockBehavior(template, test.inputTemplate)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/templates/", Create(logger, template))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/templates/", bytes.NewBufferString(test.inputBody))
+
+			rctx := chi.NewRouteContext()
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package template
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+)
+
+type allGetter interface {
+	GetTemplates(userID int64) ([]model.Template, error)
+}
+
+// GetAll templates
+// @Summary GetAll
+// @Security ApiKeyPath
+// @Tags Template
+// @Description Get all templates of the user
+// @ID getAllTemplates
+// @Produce json
+// @Success 200 {array} model.Template
+// @Failure 401 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /templates/ [get]
+func GetAll(log *slog.Logger, getter allGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		templates, err := getter.GetTemplates(userID)
+		if err != nil {
+			log.Error("can't get templates", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't get templates",
+			})
+			return
+		}
+		log.Info("templates sent", slog.Int64("userID", userID))
+		render.JSON(w, r, templates)
+	}
+}
@@ -0,0 +1,80 @@
+package template
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type getter interface {
+	GetTemplate(templateID, userID int64) (model.Template, error)
+}
+
+// Get template by ID
+// @Summary Get
+// @Security ApiKeyPath
+// @Tags Template
+// @Description Get user template by ID
+// @ID getTemplateByID
+// @Param template_id path int true "template ID"
+// @Produce json
+// @Success 200 {object} model.Template
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /templates/{templateId} [get]
+func Get(log *slog.Logger, getter getter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		templateID, err := strconv.Atoi(chi.URLParam(r, "templateId"))
+		if err != nil {
+			log.Error("incorrect template id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect template id record",
+			})
+			return
+		}
+
+		template, err := getter.GetTemplate(int64(templateID), userID)
+		if errors.Is(err, repositories.ErrNoTemplate) {
+			log.Error("there is no template", slog.Int64("userID", userID), slog.Int("templateID", templateID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no template with this templateID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't get template", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't get template",
+			})
+			return
+		}
+		log.Info("template sent", slog.Int("templateID", templateID))
+		render.JSON(w, r, template)
+	}
+}
@@ -0,0 +1,144 @@
+package template
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/internal/service"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+	"time"
+)
+
+type instantiateRequest struct {
+	Variables map[string]string `json:"variables"`
+	Start     *time.Time        `json:"start"`
+	ProjectID *int64            `json:"project_id"`
+}
+
+type instantiateResponse struct {
+	TaskIDs []int64 `json:"task_ids"`
+}
+
+type instantiater interface {
+	InstantiateTemplate(templateID, userID int64, instance model.TemplateInstance) ([]int64, error)
+}
+
+// Instantiate template
+// @Summary Instantiate
+// @Security ApiKeyPath
+// @Tags Template
+// @Description Create all the tasks of the template at once with the variables filled in.
+// @Description Due dates are counted from start, now by default; a single undo removes the whole set
+// @ID instantiateTemplate
+// @Accept json
+// @Produce json
+// @Param template_id path int true "template ID"
+// @Param input body instantiateRequest true "variable values, start and project of the tasks"
+// @Success 201 {object} instantiateResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /templates/{templateId}/instantiate [post]
+func Instantiate(log *slog.Logger, instantiater instantiater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req instantiateRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		templateID, err := strconv.Atoi(chi.URLParam(r, "templateId"))
+		if err != nil {
+			log.Error("incorrect template id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect template id record",
+			})
+			return
+		}
+
+		if !verification.TemplateVariables(req.Variables) {
+			log.Error("incorrect template variables")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect template variables",
+			})
+			return
+		}
+
+		instance := model.TemplateInstance{
+			Variables: req.Variables,
+			ProjectID: req.ProjectID,
+		}
+		if req.Start != nil {
+			instance.Start = *req.Start
+		}
+
+		taskIDs, err := instantiater.InstantiateTemplate(int64(templateID), userID, instance)
+		if errors.Is(err, repositories.ErrNoTemplate) {
+			log.Error("there is no template", slog.Int64("userID", userID), slog.Int("templateID", templateID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no template with this templateID",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrNoProject) {
+			log.Error("there is no project", slog.Int64("userID", userID), slog.Any("projectID", req.ProjectID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no project with this projectID",
+			})
+			return
+		}
+		if errors.Is(err, service.ErrTemplateVariable) {
+			log.Error("template variable is missing", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: err.Error(),
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't instantiate template", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't instantiate template",
+			})
+			return
+		}
+		log.Info("template instantiated", slog.Int("templateID", templateID), slog.Int("tasks", len(taskIDs)))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, instantiateResponse{
+			TaskIDs: taskIDs,
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/internal/service"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_InstantiateTemplate(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTemplate, templateID, userID int64, instance model.TemplateInstance)
+
+	start := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name                 string
+		inputBody            string
+		stringTemplateID     string
+		templateID           int64
+		inputInstance        model.TemplateInstance
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:             "correct working",
+			inputBody:        `{"variables":{"name":"Ann"},"start":"2024-03-04T09:00:00Z"}`,
+			stringTemplateID: "2",
+			templateID:       2,
+			inputInstance: model.TemplateInstance{
+				Start:     start,
+				Variables: map[string]string{"name": "Ann"},
+			},
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTemplate, templateID, userID int64, instance model.TemplateInstance) {
+				s.EXPECT().InstantiateTemplate(templateID, userID, instance).Return([]int64{10, 13}, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"task_ids":[10,13]}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockTemplate, templateID, userID int64, instance model.TemplateInstance) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect templateID",
+			inputBody:            `{}`,
+			stringTemplateID:     "t2",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTemplate, templateID, userID int64, instance model.TemplateInstance) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect template id record"}`,
+		}, {
+			name:                 "empty variable",
+			inputBody:            `{"variables":{"name":""}}`,
+			stringTemplateID:     "2",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTemplate, templateID, userID int64, instance model.TemplateInstance) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect template variables"}`,
+		}, {
+			name:             "incorrect InstantiateTemplate return: missing variable",
+			inputBody:        `{}`,
+			stringTemplateID: "2",
+			templateID:       2,
+			userID:           1,
+			mockBehavior: func(s *mock_service.MockTemplate, templateID, userID int64, instance model.TemplateInstance) {
+				s.EXPECT().InstantiateTemplate(templateID, userID, instance).
+					Return(nil, fmt.Errorf("%w: name", service.ErrTemplateVariable))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"template variable is not given: name"}`,
+		}, {
+			name:             "incorrect InstantiateTemplate return: no template",
+			inputBody:        `{}`,
+			stringTemplateID: "2",
+			templateID:       2,
+			userID:           1,
+			mockBehavior: func(s *mock_service.MockTemplate, templateID, userID int64, instance model.TemplateInstance) {
+				s.EXPECT().InstantiateTemplate(templateID, userID, instance).Return(nil, repositories.ErrNoTemplate)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no template with this templateID"}`,
+		}, {
+			name:             "incorrect InstantiateTemplate return: internal server error",
+			inputBody:        `{}`,
+			stringTemplateID: "2",
+			templateID:       2,
+			userID:           1,
+			mockBehavior: func(s *mock_service.MockTemplate, templateID, userID int64, instance model.TemplateInstance) {
+				s.EXPECT().InstantiateTemplate(templateID, userID, instance).Return(nil, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't instantiate template"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			template := mock_service.NewMockTemplate(ctrl)
+			test.mockBehavior(template, test.templateID, test.userID, test.inputInstance)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/instantiate", Instantiate(logger, template))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/instantiate", bytes.NewBufferString(test.inputBody))
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("templateId", test.stringTemplateID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
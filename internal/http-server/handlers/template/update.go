@@ -0,0 +1,113 @@
+package template
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+)
+
+type updateRequest struct {
+	Name  string               `json:"name"`
+	Tasks []model.TemplateTask `json:"tasks"`
+}
+
+type updater interface {
+	UpdateTemplate(template model.Template) error
+}
+
+// Update template by ID
+// @Summary Update
+// @Security ApiKeyPath
+// @Tags Template
+// @Description Replace the name and the tasks of a template, tasks already created from it stay as they are
+// @ID updateTemplateByID
+// @Param input body updateRequest true "new template info"
+// @Param template_id path int true "template ID"
+// @Produce json
+// @Success 204
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /templates/{templateId} [put]
+func Update(log *slog.Logger, updater updater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req updateRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		templateID, err := strconv.Atoi(chi.URLParam(r, "templateId"))
+		if err != nil {
+			log.Error("incorrect template id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect template id record",
+			})
+			return
+		}
+
+		template := model.Template{
+			ID:      int64(templateID),
+			Name:    req.Name,
+			OwnerID: userID,
+			Tasks:   req.Tasks,
+		}
+		if !verification.Template(template) {
+			log.Error("incorrect template information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect template information",
+			})
+			return
+		}
+
+		err = updater.UpdateTemplate(template)
+		if errors.Is(err, repositories.ErrNoTemplate) {
+			log.Error("there is no template", slog.Int64("userID", userID), slog.Int("templateID", templateID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no template with this templateID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't update template", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't update template",
+			})
+			return
+		}
+		log.Info("template updated", slog.Int("templateID", templateID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,123 @@
+package timer
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/verification"
+	"strconv"
+	"time"
+)
+
+type createRequest struct {
+	StartedAt time.Time  `json:"started_at"`
+	StoppedAt *time.Time `json:"stopped_at"`
+}
+
+type createResponse struct {
+	EntryID int64 `json:"entry_id"`
+}
+
+type creater interface {
+	CreateTimeEntry(entry model.TimeEntry) (int64, error)
+}
+
+// Create time entry
+// @Summary Create
+// @Security ApiKeyPath
+// @Tags Time
+// @Description Log time spent on the task by hand, an entry spans at most 24 hours
+// @ID createTimeEntry
+// @Accept json
+// @Produce json
+// @Param task_id path int true "task ID"
+// @Param input body createRequest true "RFC 3339 start and stop of the work"
+// @Success 201 {object} createResponse
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/time [post]
+func Create(log *slog.Logger, creater creater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		var req createRequest
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to decode request",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		entry := model.TimeEntry{
+			TaskID:    int64(taskID),
+			OwnerID:   userID,
+			StartedAt: req.StartedAt,
+			StoppedAt: req.StoppedAt,
+		}
+		if !verification.TimeEntry(entry) {
+			log.Error("incorrect time entry information")
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect time entry information",
+			})
+			return
+		}
+
+		entryID, err := creater.CreateTimeEntry(entry)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("failed to create time entry", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to create time entry",
+			})
+			return
+		}
+		log.Info("time entry created", slog.Int64("entryID", entryID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, createResponse{
+			EntryID: entryID,
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package timer
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type deleter interface {
+	DeleteTimeEntry(taskID, entryID, userID int64) error
+}
+
+// Delete time entry
+// @Summary Delete
+// @Security ApiKeyPath
+// @Tags Time
+// @Description Delete a time entry of the task, a running timer is dropped without logging anything
+// @ID deleteTimeEntry
+// @Param task_id path int true "task ID"
+// @Param entry_id path int true "time entry ID"
+// @Produce json
+// @Success 204
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/time/{entryId} [delete]
+func Delete(log *slog.Logger, deleter deleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		entryID, err := strconv.Atoi(chi.URLParam(r, "entryId"))
+		if err != nil {
+			log.Error("incorrect entry id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect entry id record",
+			})
+			return
+		}
+
+		err = deleter.DeleteTimeEntry(int64(taskID), int64(entryID), userID)
+		if errors.Is(err, repositories.ErrNoTimeEntry) {
+			log.Error("there is no time entry", slog.Int("taskID", taskID), slog.Int("entryID", entryID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no time entry with this entryID",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't delete time entry", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't delete time entry",
+			})
+			return
+		}
+		log.Info("time entry deleted", slog.Int("entryID", entryID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,95 @@
+package timer
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type startResponse struct {
+	EntryID int64 `json:"entry_id"`
+}
+
+type starter interface {
+	StartTimer(taskID, userID int64) (int64, error)
+}
+
+// Start timer
+// @Summary Start
+// @Security ApiKeyPath
+// @Tags Time
+// @Description Start a timer on the task, a user has at most one running timer
+// @ID startTimer
+// @Param task_id path int true "task ID"
+// @Produce json
+// @Success 201 {object} startResponse
+// @Failure 400,401,404,409 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/timer/start [post]
+func Start(log *slog.Logger, starter starter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		entryID, err := starter.StartTimer(int64(taskID), userID)
+		if errors.Is(err, repositories.ErrNoTask) {
+			log.Error("there is no task", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no task with this taskID",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrTimerRunning) {
+			log.Error("another timer is running", slog.Int64("userID", userID))
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, response.Message{
+				Msg: "another timer is already running",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't start timer", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't start timer",
+			})
+			return
+		}
+		log.Info("timer started", slog.Int64("entryID", entryID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, startResponse{
+			EntryID: entryID,
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package timer
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_StartTimer(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTime, taskID, userID int64)
+
+	var tests = []struct {
+		name                 string
+		stringTaskID         string
+		taskID               int64
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:         "correct working",
+			stringTaskID: "4",
+			taskID:       4,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockTime, taskID, userID int64) {
+				s.EXPECT().StartTimer(taskID, userID).Return(int64(12), nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"entry_id":12}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockTime, taskID, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:                 "incorrect taskID",
+			stringTaskID:         "a4",
+			userID:               1,
+			mockBehavior:         func(s *mock_service.MockTime, taskID, userID int64) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"message":"incorrect task id record"}`,
+		}, {
+			name:         "incorrect StartTimer return: no task",
+			stringTaskID: "4",
+			taskID:       4,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockTime, taskID, userID int64) {
+				s.EXPECT().StartTimer(taskID, userID).Return(int64(0), repositories.ErrNoTask)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"there no task with this taskID"}`,
+		}, {
+			name:         "incorrect StartTimer return: timer running",
+			stringTaskID: "4",
+			taskID:       4,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockTime, taskID, userID int64) {
+				s.EXPECT().StartTimer(taskID, userID).Return(int64(0), repositories.ErrTimerRunning)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"another timer is already running"}`,
+		}, {
+			name:         "incorrect StartTimer return: internal server error",
+			stringTaskID: "4",
+			taskID:       4,
+			userID:       1,
+			mockBehavior: func(s *mock_service.MockTime, taskID, userID int64) {
+				s.EXPECT().StartTimer(taskID, userID).Return(int64(0), errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't start timer"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			timer := mock_service.NewMockTime(ctrl)
+			test.mockBehavior(timer, test.taskID, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/timer/start", Start(logger, timer))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/timer/start", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("taskId", test.stringTaskID)
+
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
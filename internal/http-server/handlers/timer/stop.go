@@ -0,0 +1,80 @@
+package timer
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"strconv"
+)
+
+type stopper interface {
+	StopTimer(taskID, userID int64) (model.TimeEntry, error)
+}
+
+// Stop timer
+// @Summary Stop
+// @Security ApiKeyPath
+// @Tags Time
+// @Description Stop the timer running on the task and get the finished time entry
+// @ID stopTimer
+// @Param task_id path int true "task ID"
+// @Produce json
+// @Success 200 {object} model.TimeEntry
+// @Failure 400,401,404 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /tasks/{taskId}/timer/stop [post]
+func Stop(log *slog.Logger, stopper stopper) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		taskID, err := strconv.Atoi(chi.URLParam(r, "taskId"))
+		if err != nil {
+			log.Error("incorrect task id record", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, response.Message{
+				Msg: "incorrect task id record",
+			})
+			return
+		}
+
+		entry, err := stopper.StopTimer(int64(taskID), userID)
+		if errors.Is(err, repositories.ErrNoTimer) {
+			log.Error("there is no running timer", slog.Int64("userID", userID), slog.Int("taskID", taskID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "there no running timer on this task",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't stop timer", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't stop timer",
+			})
+			return
+		}
+		log.Info("timer stopped", slog.Int64("entryID", entry.ID))
+		render.JSON(w, r, entry)
+	}
+}
@@ -0,0 +1,81 @@
+package undo
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"restAPI/internal/http-server/response"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+)
+
+type undoResponse struct {
+	model.UndoResult
+}
+
+type undoer interface {
+	Undo(userID int64) (model.UndoResult, error)
+}
+
+// Undo last operation
+// @Summary Undo
+// @Security ApiKeyPath
+// @Tags Undo
+// @Description Reverse the most recent create, update, delete or delete-all of the user
+// @ID undo
+// @Produce json
+// @Success 200 {object} undoResponse
+// @Failure 401,404,409 {object} response.Message
+// @Failure 500 {object} response.Message
+// @Failure default {object} response.Message
+// @Router /undo [post]
+func Undo(log *slog.Logger, undoer undoer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(
+			slog.String("requestID", middleware.GetReqID(r.Context())),
+		)
+
+		userID := r.Context().Value("userID").(int64)
+
+		if userID <= 0 {
+			log.Error("couldn't get userID")
+			w.WriteHeader(http.StatusUnauthorized)
+			render.JSON(w, r, response.Message{
+				Msg: "failed to get auth id",
+			})
+			return
+		}
+
+		result, err := undoer.Undo(userID)
+		if errors.Is(err, repositories.ErrNothingToUndo) {
+			log.Error("nothing to undo", slog.Int64("userID", userID))
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, response.Message{
+				Msg: "nothing to undo",
+			})
+			return
+		}
+		if errors.Is(err, repositories.ErrUndoConflict) {
+			log.Error("undo conflicts with a later change", slog.Int64("userID", userID))
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, response.Message{
+				Msg: "tasks were changed after this operation",
+			})
+			return
+		}
+		if err != nil {
+			log.Error("can't undo operation", slog.String("error", err.Error()))
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, response.Message{
+				Msg: "can't undo operation",
+			})
+			return
+		}
+		log.Info("operation undone", slog.String("operation", result.Operation), slog.Int64("userID", userID))
+		render.JSON(w, r, undoResponse{
+			UndoResult: result,
+		})
+	}
+}
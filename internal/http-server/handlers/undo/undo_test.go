@@ -0,0 +1,101 @@
+package undo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	mock_service "restAPI/internal/service/mocks"
+	"strings"
+	"testing"
+)
+
+func TestHandler_Undo(t *testing.T) {
+	type MockBehavior func(s *mock_service.MockTask, userID int64)
+
+	var tests = []struct {
+		name                 string
+		userID               int64
+		mockBehavior         MockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "correct working",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTask, userID int64) {
+				s.EXPECT().Undo(userID).Return(model.UndoResult{
+					Operation: model.OperationDeleteAll,
+					TaskIDs:   []int64{1, 2},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"operation":"delete_all","task_ids":[1,2]}`,
+		}, {
+			name:                 "incorrect userID",
+			userID:               -1,
+			mockBehavior:         func(s *mock_service.MockTask, userID int64) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"message":"failed to get auth id"}`,
+		}, {
+			name:   "empty stack",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTask, userID int64) {
+				s.EXPECT().Undo(userID).Return(model.UndoResult{}, fmt.Errorf("Undo: %w", repositories.ErrNothingToUndo))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"message":"nothing to undo"}`,
+		}, {
+			name:   "conflict",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTask, userID int64) {
+				s.EXPECT().Undo(userID).Return(model.UndoResult{}, fmt.Errorf("Undo: %w", repositories.ErrUndoConflict))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"message":"tasks were changed after this operation"}`,
+		}, {
+			name:   "incorrect Undo return",
+			userID: 1,
+			mockBehavior: func(s *mock_service.MockTask, userID int64) {
+				s.EXPECT().Undo(userID).Return(model.UndoResult{}, errors.New("test"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"message":"can't undo operation"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			task := mock_service.NewMockTask(ctrl)
+			test.mockBehavior(task, test.userID)
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			router := chi.NewRouter()
+			router.Post("/undo", Undo(logger, task))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/undo", nil)
+
+			r = r.WithContext(context.WithValue(r.Context(), "userID", test.userID))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// maxRangeDays bounds the ranges the reports are computed for.
+	maxRangeDays = 366
+)
+
+// DateRange reads the from and to dates of the query string. Both days are
+// included, so the returned to is the midnight after the to date, in UTC.
+func DateRange(r *http.Request) (time.Time, time.Time, error) {
+	query := r.URL.Query()
+	from, err := time.Parse(dateLayout, query.Get("from"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("incorrect from date %q", query.Get("from"))
+	}
+	to, err := time.Parse(dateLayout, query.Get("to"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("incorrect to date %q", query.Get("to"))
+	}
+	to = to.AddDate(0, 0, 1)
+	if !to.After(from) || to.Sub(from) > maxRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("incorrect range from %s to %s", query.Get("from"), query.Get("to"))
+	}
+	return from, to, nil
+}
+
+// OptionalDate reads a date of the query string, nil when it isn't given.
+func OptionalDate(r *http.Request, key string) (*time.Time, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect %s date %q", key, value)
+	}
+	return &date, nil
+}
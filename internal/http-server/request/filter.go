@@ -0,0 +1,35 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"restAPI/internal/model"
+	"strconv"
+)
+
+// TaskFilter reads the filters shared by the task list endpoints from the query string.
+func TaskFilter(r *http.Request) (model.TaskFilter, error) {
+	var filter model.TaskFilter
+	query := r.URL.Query()
+	if project := query.Get("project"); project != "" {
+		projectID, err := strconv.ParseInt(project, 10, 64)
+		if err != nil || projectID <= 0 {
+			return model.TaskFilter{}, fmt.Errorf("incorrect project id %q", project)
+		}
+		filter.ProjectID = &projectID
+	}
+	if deferred := query.Get("include_deferred"); deferred != "" {
+		include, err := strconv.ParseBool(deferred)
+		if err != nil {
+			return model.TaskFilter{}, fmt.Errorf("incorrect include_deferred %q", deferred)
+		}
+		filter.IncludeDeferred = include
+	}
+	switch sort := query.Get("sort"); sort {
+	case "", model.TaskSortManual:
+		filter.Sort = sort
+	default:
+		return model.TaskFilter{}, fmt.Errorf("unknown sort %q", sort)
+	}
+	return filter, nil
+}
@@ -0,0 +1,20 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Flag reads a boolean from the query string, false when it isn't given.
+func Flag(r *http.Request, key string) (bool, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return false, nil
+	}
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("incorrect %s %q", key, value)
+	}
+	return flag, nil
+}
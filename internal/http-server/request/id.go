@@ -0,0 +1,19 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// OptionalID reads an id from the query string, nil when it isn't given.
+func OptionalID(r *http.Request, key string) (*int64, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -0,0 +1,27 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	// the zone database is embedded so zones work on hosts without one
+	_ "time/tzdata"
+)
+
+// Location reads the tz query parameter, an IANA zone name like
+// Europe/Berlin. Without it the location is UTC.
+func Location(r *http.Request) (*time.Location, error) {
+	name := r.URL.Query().Get("tz")
+	if name == "" {
+		return time.UTC, nil
+	}
+	// "Local" would be the zone of the server, which means nothing to clients
+	if name == "Local" {
+		return nil, fmt.Errorf("incorrect time zone %q", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect time zone %q", name)
+	}
+	return loc, nil
+}
@@ -0,0 +1,34 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"restAPI/internal/model"
+	"strconv"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// Page reads limit and offset from the query string.
+func Page(r *http.Request) (model.Page, error) {
+	page := model.Page{Limit: defaultPageLimit}
+	query := r.URL.Query()
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value <= 0 || value > maxPageLimit {
+			return model.Page{}, fmt.Errorf("incorrect limit %q", limit)
+		}
+		page.Limit = value
+	}
+	if offset := query.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return model.Page{}, fmt.Errorf("incorrect offset %q", offset)
+		}
+		page.Offset = value
+	}
+	return page, nil
+}
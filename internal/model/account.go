@@ -0,0 +1,63 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AccountExportRunning = "running"
+	AccountExportDone    = "done"
+	AccountExportFailed  = "failed"
+)
+
+// AccountExport is an archive of all the data of a user, built in the
+// background. A done export is downloaded through a signed link that works
+// until LinkExpiresAt, without the JWT.
+type AccountExport struct {
+	ID            int64      `json:"id"`
+	OwnerID       int64      `json:"-"`
+	Status        string     `json:"status"`
+	Key           string     `json:"-"`
+	Size          int64      `json:"size,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+	DownloadURL   string     `json:"download_url,omitempty"`
+	LinkExpiresAt *time.Time `json:"link_expires_at,omitempty"`
+}
+
+// Profile is the user as the account export tells it, without the password
+// hash.
+type Profile struct {
+	ID        int64  `json:"id" db:"id"`
+	FirstName string `json:"first_name" db:"firstname"`
+	LastName  string `json:"last_name" db:"lastname"`
+	Login     string `json:"login" db:"login"`
+}
+
+// Operation is an entry of the undo history, Payload holds what the
+// operation changed.
+type Operation struct {
+	ID        int64           `json:"id" db:"id"`
+	Kind      string          `json:"kind" db:"kind"`
+	Payload   json.RawMessage `json:"payload" db:"payload"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AccountData is what the account export reads besides the tasks and the
+// projects, the records of the tasks come with their TaskID.
+type AccountData struct {
+	Profile     Profile
+	Items       []TaskItem
+	Comments    []Comment
+	TimeEntries []TimeEntry
+	Attachments []Attachment
+	History     []Operation
+}
+
+// AccountDeletion tells when a deleted account goes for good, signing in
+// before cancels the deletion.
+type AccountDeletion struct {
+	DeleteAfter time.Time `json:"delete_after"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// MaxAgendaDays is the longest agenda that can be asked for.
+const MaxAgendaDays = 31
+
+// Agenda lays out the coming days of the user, the first day is today in the
+// user's time zone. Snoozed and done tasks stay out of it.
+type Agenda struct {
+	InProgress []Task      `json:"in_progress"`
+	Days       []AgendaDay `json:"days"`
+}
+
+// AgendaDay holds the tasks due on the day, the ones dated on it and the
+// occurrences of repeating tasks falling on it. Only the first day has
+// overdue tasks, those due before it. A repeating task shows up through its
+// occurrences and never as scheduled.
+type AgendaDay struct {
+	Date      string             `json:"date"`
+	Overdue   []Task             `json:"overdue"`
+	Due       []Task             `json:"due"`
+	Scheduled []Task             `json:"scheduled"`
+	Recurring []AgendaOccurrence `json:"recurring"`
+}
+
+type AgendaOccurrence struct {
+	At   time.Time `json:"at"`
+	Task Task      `json:"task"`
+}
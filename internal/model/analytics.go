@@ -0,0 +1,96 @@
+package model
+
+import "time"
+
+const (
+	EstimateGroupTag  = "tag"
+	EstimateGroupWeek = "week"
+)
+
+// EstimateTotals compares the estimates of tasks with the time logged on them,
+// both in seconds.
+type EstimateTotals struct {
+	Tasks    int   `json:"tasks" db:"tasks"`
+	Estimate int64 `json:"estimate" db:"estimate"`
+	Actual   int64 `json:"actual" db:"actual"`
+}
+
+// EstimateReport covers the estimated tasks completed between From and To.
+// Weeks start on Monday and are keyed by that day, a task with several tags
+// counts for each of them and untagged tasks go under the empty key.
+type EstimateReport struct {
+	From  time.Time           `json:"from"`
+	To    time.Time           `json:"to"`
+	Group string              `json:"group"`
+	Rows  []EstimateReportRow `json:"rows"`
+	Total EstimateTotals      `json:"total"`
+}
+
+type EstimateReportRow struct {
+	Key string `json:"key" db:"key"`
+	EstimateTotals
+}
+
+const (
+	StatsBucketDay  = "day"
+	StatsBucketWeek = "week"
+)
+
+// MaxStatsTags is the number of busiest tags given in the stats.
+const MaxStatsTags = 5
+
+// StatsTotals counts the tasks created and the tasks completed.
+type StatsTotals struct {
+	Created   int `json:"created" db:"created"`
+	Completed int `json:"completed" db:"completed"`
+}
+
+// Stats sums up the tasks the user created and completed between From and
+// To. Buckets and streak days are in the time zone of From; weeks start on
+// Monday and are keyed by that day, the first one may start before From.
+type Stats struct {
+	From   time.Time   `json:"from"`
+	To     time.Time   `json:"to"`
+	Bucket string      `json:"bucket"`
+	Total  StatsTotals `json:"total"`
+	// MedianCompletion is the median number of seconds from creation to
+	// completion of the tasks completed in the range, nil without any.
+	MedianCompletion *int64        `json:"median_completion,omitempty"`
+	Streaks          StatsStreaks  `json:"streaks"`
+	Buckets          []StatsBucket `json:"buckets"`
+	// TopTags are the tags with the most tasks created and completed,
+	// busiest first
+	TopTags []StatsTag `json:"top_tags"`
+}
+
+// StatsStreaks count the days in a row with a completed task. The current
+// streak goes on if a task was completed yesterday but none yet today.
+type StatsStreaks struct {
+	Current int `json:"current" db:"current"`
+	Longest int `json:"longest" db:"longest"`
+}
+
+type StatsBucket struct {
+	Start string `json:"start" db:"start"`
+	StatsTotals
+}
+
+type StatsTag struct {
+	Tag string `json:"tag" db:"tag"`
+	StatsTotals
+}
+
+// MaxBurndownDays bounds the days a burndown chart covers.
+const MaxBurndownDays = 366
+
+// HeatmapDay counts the tasks dated on a day.
+type HeatmapDay struct {
+	Date  time.Time `json:"date" db:"day"`
+	Tasks int       `json:"tasks" db:"tasks"`
+}
+
+// BurndownDay counts the tasks of a project still open at the end of a day.
+type BurndownDay struct {
+	Date      time.Time `json:"date" db:"day"`
+	Remaining int       `json:"remaining" db:"remaining"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Attachment describes a file uploaded to a task, the content itself lives
+// in the blob store under Key.
+type Attachment struct {
+	ID          int64     `json:"id" db:"id"`
+	TaskID      int64     `json:"-" db:"task_id"`
+	OwnerID     int64     `json:"-" db:"owner_id"`
+	Name        string    `json:"name" db:"name"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Size        int64     `json:"size" db:"size"`
+	Key         string    `json:"-" db:"blob_key"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,33 @@
+package model
+
+type Board struct {
+	ID      int64         `json:"id" db:"id"`
+	Name    string        `json:"name" db:"name"`
+	OwnerID int64         `json:"-" db:"owner_id"`
+	Columns []BoardColumn `json:"columns,omitempty" db:"-"`
+}
+
+// BoardColumn is a lane of a board. A column with a status moves the tasks
+// dropped into it to that status, a column without one is a custom lane.
+type BoardColumn struct {
+	ID      int64       `json:"id" db:"id"`
+	BoardID int64       `json:"-" db:"board_id"`
+	Name    string      `json:"name" db:"name"`
+	Status  *string     `json:"status,omitempty" db:"status"`
+	Rank    string      `json:"-" db:"rank"`
+	Cards   []BoardCard `json:"cards" db:"-"`
+}
+
+type BoardCard struct {
+	TaskID int64  `json:"task_id"`
+	Rank   string `json:"-"`
+	Task
+}
+
+// CardMove places a task in a column, between the cards of the After and
+// Before tasks; without them the task goes to the end of the column.
+type CardMove struct {
+	ColumnID int64  `json:"column_id"`
+	After    *int64 `json:"after"`
+	Before   *int64 `json:"before"`
+}
@@ -0,0 +1,20 @@
+package model
+
+// MaxCalendarTags is the number of most used tags given for a calendar day.
+const MaxCalendarTags = 3
+
+// CalendarMonth counts the tasks of every day of the month, days without
+// tasks included.
+type CalendarMonth struct {
+	Year  int           `json:"year"`
+	Month int           `json:"month"`
+	Days  []CalendarDay `json:"days"`
+}
+
+type CalendarDay struct {
+	Date  string `json:"date"`
+	Tasks int    `json:"tasks"`
+	Done  int    `json:"done"`
+	// TopTags are the most used tags of the day, most used first
+	TopTags []string `json:"top_tags"`
+}
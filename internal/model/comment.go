@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Comment is a note on a task. Comments with a parent are replies and come
+// nested in the thread of their parent.
+type Comment struct {
+	ID        int64      `json:"id" db:"id"`
+	TaskID    int64      `json:"-" db:"task_id"`
+	AuthorID  int64      `json:"author_id" db:"author_id"`
+	ParentID  *int64     `json:"parent_id,omitempty" db:"parent_id"`
+	Text      string     `json:"text" db:"text"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty" db:"edited_at"`
+	Replies   []Comment  `json:"replies,omitempty" db:"-"`
+}
+
+// Page selects a slice of a long list.
+type Page struct {
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,185 @@
+package model
+
+import "time"
+
+const (
+	ImportCreated   = "created"
+	ImportDuplicate = "duplicate"
+	ImportSkipped   = "skipped"
+	ImportInvalid   = "invalid"
+	ImportUpdated   = "updated"
+	ImportUnchanged = "unchanged"
+)
+
+// CSVColumns are the columns of the task CSV files, in the order they are
+// exported by default.
+var CSVColumns = []string{
+	"id", "text", "status", "date", "due", "priority", "tags",
+	"project_id", "parent_id", "estimate", "recurrence", "completed_at",
+}
+
+// DefaultTagDelimiter joins the tags of a task in a CSV cell.
+const DefaultTagDelimiter = ";"
+
+// CSVImport tells how to read a CSV file of tasks. Mapping gives the column
+// of the headers that aren't named after one, the others are ignored.
+// A dry run only tells what the import would do, an atomic one creates no
+// task unless all the rows can be imported.
+type CSVImport struct {
+	Mapping      map[string]string
+	TagDelimiter string
+	DryRun       bool
+	Atomic       bool
+}
+
+// ImportReport tells what became of every item of an imported file, in the
+// order of the file. Nothing is created by a dry run, its items tell what
+// would be.
+type ImportReport struct {
+	DryRun     bool         `json:"dry_run,omitempty"`
+	Created    int          `json:"created"`
+	Duplicates int          `json:"duplicates"`
+	Skipped    int          `json:"skipped"`
+	Invalid    int          `json:"invalid"`
+	Items      []ImportItem `json:"items"`
+}
+
+// ImportItem is an item of the file. Ref identifies it in the file, TaskID is
+// the task it was imported as or the one it duplicates. Reason says why it
+// was left out, or what of it was. Task previews the task of a dry run,
+// Changes names the fields a sync updates.
+type ImportItem struct {
+	Ref     string   `json:"ref"`
+	Text    string   `json:"text"`
+	Status  string   `json:"status"`
+	TaskID  int64    `json:"task_id,omitempty"`
+	Reason  string   `json:"reason,omitempty"`
+	Task    *Task    `json:"task,omitempty"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+// Add records item and counts it.
+func (r *ImportReport) Add(item ImportItem) {
+	switch item.Status {
+	case ImportCreated:
+		r.Created++
+	case ImportDuplicate:
+		r.Duplicates++
+	case ImportSkipped:
+		r.Skipped++
+	case ImportInvalid:
+		r.Invalid++
+	}
+	r.Items = append(r.Items, item)
+}
+
+// SyncReport tells what a sync did with every line of the file, in the order
+// of the file. Missing are the tasks the file doesn't have, a sync leaves
+// them as they are.
+type SyncReport struct {
+	DryRun    bool         `json:"dry_run,omitempty"`
+	Created   int          `json:"created"`
+	Updated   int          `json:"updated"`
+	Unchanged int          `json:"unchanged"`
+	Invalid   int          `json:"invalid"`
+	Items     []ImportItem `json:"items"`
+	Missing   []int64      `json:"missing"`
+}
+
+// Add records item and counts it.
+func (r *SyncReport) Add(item ImportItem) {
+	switch item.Status {
+	case ImportCreated:
+		r.Created++
+	case ImportUpdated:
+		r.Updated++
+	case ImportUnchanged:
+		r.Unchanged++
+	case ImportInvalid:
+		r.Invalid++
+	}
+	r.Items = append(r.Items, item)
+}
+
+// The apps whose export files are imported by jobs.
+const (
+	ImportSourceTodoist = "todoist"
+	ImportSourceTrello  = "trello"
+)
+
+const (
+	ImportJobRunning = "running"
+	ImportJobDone    = "done"
+	ImportJobFailed  = "failed"
+)
+
+// The kinds of records an import job brings in.
+const (
+	ImportKindProject = "project"
+	ImportKindTask    = "task"
+	ImportKindItem    = "item"
+	ImportKindComment = "comment"
+)
+
+// ImportJob imports the export file of another app in the background. Done
+// counts the records of the file gone through out of Total, the report comes
+// when the job is done and the error when it failed.
+type ImportJob struct {
+	ID         int64            `json:"id" db:"id"`
+	OwnerID    int64            `json:"-" db:"owner_id"`
+	Source     string           `json:"source" db:"source"`
+	Status     string           `json:"status" db:"status"`
+	Done       int              `json:"done" db:"done"`
+	Total      int              `json:"total" db:"total"`
+	Report     *ImportJobReport `json:"report,omitempty" db:"-"`
+	Error      string           `json:"error,omitempty" db:"-"`
+	CreatedAt  time.Time        `json:"created_at" db:"created_at"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// ImportJobReport counts what became of the records of each kind. Only the
+// records left out, or imported without something, are listed, with the
+// kind of the record in their ref.
+type ImportJobReport struct {
+	Projects ImportCounts `json:"projects"`
+	Tasks    ImportCounts `json:"tasks"`
+	Items    ImportCounts `json:"items"`
+	Comments ImportCounts `json:"comments"`
+	Problems []ImportItem `json:"problems"`
+}
+
+type ImportCounts struct {
+	Created    int `json:"created"`
+	Duplicates int `json:"duplicates"`
+	Skipped    int `json:"skipped"`
+	Invalid    int `json:"invalid"`
+}
+
+// Add counts item as a record of the kind.
+func (r *ImportJobReport) Add(kind string, item ImportItem) {
+	counts := map[string]*ImportCounts{
+		ImportKindProject: &r.Projects,
+		ImportKindTask:    &r.Tasks,
+		ImportKindItem:    &r.Items,
+		ImportKindComment: &r.Comments,
+	}[kind]
+	switch item.Status {
+	case ImportCreated:
+		counts.Created++
+	case ImportDuplicate:
+		counts.Duplicates++
+	case ImportSkipped:
+		counts.Skipped++
+	case ImportInvalid:
+		counts.Invalid++
+	}
+	if item.Status == ImportSkipped || item.Status == ImportInvalid || item.Reason != "" {
+		r.Problems = append(r.Problems, item)
+	}
+}
+
+// ImportRef identifies a record in the files of a source.
+type ImportRef struct {
+	Kind       string
+	ExternalID string
+}
@@ -0,0 +1,16 @@
+package model
+
+// TaskItem is one line of the checklist of a task.
+type TaskItem struct {
+	ID     int64  `json:"id" db:"id"`
+	TaskID int64  `json:"-" db:"task_id"`
+	Text   string `json:"text" db:"text"`
+	Done   bool   `json:"done" db:"done"`
+	Rank   string `json:"-" db:"rank"`
+}
+
+// TaskProgress counts the checked items of the task checklist.
+type TaskProgress struct {
+	Done  int `json:"done" db:"items_done"`
+	Total int `json:"total" db:"items_total"`
+}
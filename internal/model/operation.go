@@ -0,0 +1,14 @@
+package model
+
+const (
+	OperationCreate    = "create"
+	OperationUpdate    = "update"
+	OperationDelete    = "delete"
+	OperationDeleteAll = "delete_all"
+	OperationMove      = "move"
+)
+
+type UndoResult struct {
+	Operation string  `json:"operation"`
+	TaskIDs   []int64 `json:"task_ids"`
+}
@@ -0,0 +1,10 @@
+package model
+
+type Project struct {
+	ID          int64  `json:"id" db:"id"`
+	Name        string `json:"name" db:"name"`
+	Description string `json:"description" db:"description"`
+	Colour      string `json:"colour" db:"colour"`
+	Archived    bool   `json:"archived" db:"archived"`
+	OwnerID     int64  `json:"-" db:"owner_id"`
+}
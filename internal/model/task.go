@@ -5,15 +5,109 @@ import (
 	"time"
 )
 
+const (
+	TaskStatusTodo       = "todo"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+)
+
+const (
+	TaskPriorityLow    = "low"
+	TaskPriorityMedium = "medium"
+	TaskPriorityHigh   = "high"
+)
+
 type Task struct {
-	ID      int64     `json:"-" db:"id"`
-	Text    string    `json:"text" db:"task"`
-	Tags    []string  `json:"tags" db:"omitempty"`
-	Date    time.Time `json:"date" db:"date"`
-	OwnerID int64     `json:"-" db:"owner_id"`
+	ID          int64      `json:"-" db:"id"`
+	Text        string     `json:"text" db:"task"`
+	Tags        []string   `json:"tags" db:"omitempty"`
+	Date        time.Time  `json:"date" db:"date"`
+	OwnerID     int64      `json:"-" db:"owner_id"`
+	ProjectID   *int64     `json:"project_id,omitempty" db:"project_id"`
+	Status      string     `json:"status,omitempty" db:"status"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	// Estimate is the expected work on the task in seconds.
+	Estimate *int64     `json:"estimate,omitempty" db:"estimate"`
+	ParentID *int64     `json:"parent_id,omitempty" db:"parent_id"`
+	Due      *time.Time `json:"due,omitempty" db:"due"`
+	Priority *string    `json:"priority,omitempty" db:"priority"`
+	// Recurrence is the repeat rule of the task, see pkg/lib/recurrence.
+	Recurrence *string `json:"recurrence,omitempty" db:"recurrence"`
+	// HiddenUntil keeps a snoozed task out of the lists until then, in UTC.
+	HiddenUntil *time.Time `json:"hidden_until,omitempty" db:"hidden_until"`
+	// ICalUID is the UID of the calendar entry the task was imported from.
+	ICalUID *string `json:"-" db:"ical_uid"`
+	// Subtasks are only read when creating tasks, they are created together
+	// with their parent.
+	Subtasks []Task       `json:"subtasks,omitempty" db:"-"`
+	Progress TaskProgress `json:"progress" db:"-"`
+	Comments int          `json:"comment_count" db:"-"`
+}
+
+// TaskSortManual orders lists by the position the user gave the tasks by hand.
+const TaskSortManual = "manual"
+
+// TaskFilter narrows the task list endpoints; zero value means no filtering.
+type TaskFilter struct {
+	ProjectID *int64
+	Sort      string
+	// IncludeDeferred also lists the tasks snoozed until later.
+	IncludeDeferred bool
+}
+
+const (
+	SnoozeTonight  = "tonight"
+	SnoozeTomorrow = "tomorrow"
+	SnoozeWeekend  = "weekend"
+	SnoozeNextWeek = "next_week"
+)
+
+// DuplicateOptions tells what a copy of a task takes along besides its text.
+type DuplicateOptions struct {
+	Tags      bool
+	Checklist bool
+	Subtasks  bool
+}
+
+// TaskMove sends many tasks at once to a project, out of their projects or
+// to another day.
+type TaskMove struct {
+	TaskIDs        []int64
+	ProjectID      *int64
+	WithoutProject bool
+	Date           *time.Time
 }
 
 func (task *Task) String() string {
 	return fmt.Sprintf("task ID: %d\n task text: %s\n task tags: %v\n task date %v\n",
 		task.ID, task.Text, task.Tags, task.Date)
 }
+
+// TaskStream selects the tasks of the admin export, nil filters select all.
+// The export goes on after the task with the id After, in the order of the
+// ids, so that a cut export can be resumed from its last task.
+type TaskStream struct {
+	UserID *int64
+	From   *time.Time
+	To     *time.Time
+	After  int64
+}
+
+// TaskRow is a task of the admin export, with the ids the task lists hide.
+type TaskRow struct {
+	ID          int64      `json:"id"`
+	OwnerID     int64      `json:"owner_id"`
+	Text        string     `json:"text"`
+	Tags        []string   `json:"tags"`
+	Date        time.Time  `json:"date"`
+	ProjectID   *int64     `json:"project_id,omitempty"`
+	Status      string     `json:"status"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Estimate    *int64     `json:"estimate,omitempty"`
+	ParentID    *int64     `json:"parent_id,omitempty"`
+	Due         *time.Time `json:"due,omitempty"`
+	Priority    *string    `json:"priority,omitempty"`
+	Recurrence  *string    `json:"recurrence,omitempty"`
+	HiddenUntil *time.Time `json:"hidden_until,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
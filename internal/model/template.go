@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// Template is a reusable set of tasks. Texts and tags may hold {{variables}}
+// that are filled in when the template is instantiated.
+type Template struct {
+	ID      int64          `json:"id" db:"id"`
+	Name    string         `json:"name" db:"name"`
+	OwnerID int64          `json:"-" db:"owner_id"`
+	Tasks   []TemplateTask `json:"tasks" db:"-"`
+}
+
+// TemplateTask is a task of a template. DueOffset is the number of days from
+// the start of the instance to the due date of the task.
+type TemplateTask struct {
+	Text      string         `json:"text"`
+	Tags      []string       `json:"tags,omitempty"`
+	DueOffset *int           `json:"due_offset,omitempty"`
+	Subtasks  []TemplateTask `json:"subtasks,omitempty"`
+}
+
+// TemplateInstance is what a template is instantiated with.
+type TemplateInstance struct {
+	Start     time.Time
+	Variables map[string]string
+	ProjectID *int64
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+const (
+	TimeGroupTag  = "tag"
+	TimeGroupDay  = "day"
+	TimeGroupTask = "task"
+)
+
+// TimeEntry is a span of work on a task. A running timer has no StoppedAt
+// and counts up to now.
+type TimeEntry struct {
+	ID        int64      `json:"id" db:"id"`
+	TaskID    int64      `json:"task_id" db:"task_id"`
+	OwnerID   int64      `json:"-" db:"owner_id"`
+	StartedAt time.Time  `json:"started_at" db:"started_at"`
+	StoppedAt *time.Time `json:"stopped_at,omitempty" db:"stopped_at"`
+	Seconds   int64      `json:"seconds" db:"seconds"`
+}
+
+// TimeReport sums the logged time between From and To. Grouped by tag a task
+// counts for each of its tags, untagged tasks go under the empty key.
+type TimeReport struct {
+	From  time.Time       `json:"from"`
+	To    time.Time       `json:"to"`
+	Group string          `json:"group"`
+	Rows  []TimeReportRow `json:"rows"`
+	Total int64           `json:"total"`
+}
+
+type TimeReportRow struct {
+	Key     string `json:"key" db:"key"`
+	TaskID  *int64 `json:"task_id,omitempty" db:"task_id"`
+	Seconds int64  `json:"seconds" db:"seconds"`
+}
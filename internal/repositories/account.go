@@ -0,0 +1,293 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"restAPI/internal/entities"
+	"restAPI/internal/model"
+	"time"
+)
+
+// AccountPostgres reads the data of a whole account and keeps its exports.
+type AccountPostgres struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewAccountPostgres(db *sqlx.DB, log *slog.Logger) *AccountPostgres {
+	return &AccountPostgres{
+		db:  db,
+		log: log,
+	}
+}
+
+func toAccountExport(rawExport entities.AccountExport) model.AccountExport {
+	export := model.AccountExport{
+		ID:         rawExport.ID,
+		OwnerID:    rawExport.OwnerID,
+		Status:     rawExport.Status,
+		Size:       rawExport.Size,
+		CreatedAt:  rawExport.CreatedAt,
+		FinishedAt: rawExport.FinishedAt,
+	}
+	if rawExport.Key != nil {
+		export.Key = *rawExport.Key
+	}
+	if rawExport.Error != nil {
+		export.Error = *rawExport.Error
+	}
+	return export
+}
+
+// CreateAccountExport starts a running export. A user has one running export
+// at most, ErrExportRunning tells another one is.
+func (r *AccountPostgres) CreateAccountExport(userID int64) (int64, error) {
+	op := "CreateAccountExport"
+	exportIDs := make([]int64, 0, 1)
+	query := `INSERT INTO account_exports (owner_id, status) VALUES ($1, $2)
+			  ON CONFLICT DO NOTHING RETURNING id`
+	if err := r.db.Select(&exportIDs, query, userID, model.AccountExportRunning); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(exportIDs) == 0 {
+		return 0, fmt.Errorf("%s: %w", op, ErrExportRunning)
+	}
+	return exportIDs[0], nil
+}
+
+func (r *AccountPostgres) GetAccountExport(exportID, userID int64) (model.AccountExport, error) {
+	op := "GetAccountExport"
+	rawExports := make([]entities.AccountExport, 0, 1)
+	query := `SELECT id, owner_id, status, blob_key, size, error, created_at, finished_at
+			  FROM account_exports WHERE id = $1 AND owner_id = $2`
+	if err := r.db.Select(&rawExports, query, exportID, userID); err != nil {
+		return model.AccountExport{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(rawExports) == 0 {
+		return model.AccountExport{}, fmt.Errorf("%s: %w", op, ErrNoAccountExport)
+	}
+	return toAccountExport(rawExports[0]), nil
+}
+
+// FinishAccountExport ends the export with its status and, for a done one,
+// its archive. The archive of a user is kept until the next export is done,
+// the older exports are deleted and the keys of their archives returned. An
+// export that was failed meanwhile keeps its end, its archive isn't recorded.
+func (r *AccountPostgres) FinishAccountExport(export model.AccountExport) ([]string, error) {
+	op := "FinishAccountExport"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	var key, exportError *string
+	if export.Key != "" {
+		key = &export.Key
+	}
+	if export.Error != "" {
+		exportError = &export.Error
+	}
+	query := `UPDATE account_exports SET status = $2, blob_key = $3, size = $4, error = $5, finished_at = now() AT TIME ZONE 'UTC'
+			  WHERE id = $1 AND status = $6`
+	res, err := tx.Exec(query, export.ID, export.Status, key, export.Size, exportError, model.AccountExportRunning)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrExportNotRunning)
+	}
+	keys := make([]string, 0)
+	if export.Status == model.AccountExportDone {
+		query = `DELETE FROM account_exports WHERE owner_id = $1 AND id < $2 AND status <> $3
+				 RETURNING blob_key`
+		rawKeys := make([]sql.NullString, 0)
+		if err = tx.Select(&rawKeys, query, export.OwnerID, export.ID, model.AccountExportRunning); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		for _, rawKey := range rawKeys {
+			if rawKey.Valid {
+				keys = append(keys, rawKey.String)
+			}
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return keys, nil
+}
+
+// FailRunningAccountExports fails the exports still running, exports are built
+// in the server and don't outlive it.
+func (r *AccountPostgres) FailRunningAccountExports(reason string) error {
+	op := "FailRunningAccountExports"
+	query := "UPDATE account_exports SET status = $1, error = $2, finished_at = now() AT TIME ZONE 'UTC' WHERE status = $3"
+	if _, err := r.db.Exec(query, model.AccountExportFailed, reason, model.AccountExportRunning); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// GetAccountData reads the profile of the user and the records of their tasks
+// from one snapshot of the database. Running timers count up to now.
+func (r *AccountPostgres) GetAccountData(userID int64, now time.Time) (model.AccountData, error) {
+	op := "GetAccountData"
+	tx, err := r.db.BeginTxx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return model.AccountData{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	var data model.AccountData
+	profiles := make([]model.Profile, 0, 1)
+	query := "SELECT id, firstname, lastname, login FROM users WHERE id = $1"
+	if err = tx.Select(&profiles, query, userID); err != nil {
+		return model.AccountData{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(profiles) == 0 {
+		return model.AccountData{}, fmt.Errorf("%s: %w", op, ErrNoSuchUser)
+	}
+	data.Profile = profiles[0]
+	data.Items = make([]model.TaskItem, 0)
+	query = `SELECT task_items.id, task_id, text, done, rank FROM task_items
+			 JOIN tasks
+			     ON tasks.id = task_items.task_id
+			 WHERE tasks.owner_id = $1
+			 ORDER BY task_id, rank`
+	if err = tx.Select(&data.Items, query, userID); err != nil {
+		return model.AccountData{}, fmt.Errorf("%s: %w", op, err)
+	}
+	data.Comments = make([]model.Comment, 0)
+	query = `SELECT comments.id, task_id, author_id, comments.parent_id, text, comments.created_at, edited_at
+			 FROM comments
+			 JOIN tasks
+			     ON tasks.id = comments.task_id
+			 WHERE tasks.owner_id = $1
+			 ORDER BY comments.id`
+	if err = tx.Select(&data.Comments, query, userID); err != nil {
+		return model.AccountData{}, fmt.Errorf("%s: %w", op, err)
+	}
+	data.TimeEntries = make([]model.TimeEntry, 0)
+	query = `SELECT id, task_id, owner_id, started_at, stopped_at,
+			     extract(epoch FROM COALESCE(stopped_at, $2) - started_at)::bigint AS seconds
+			 FROM time_entries
+			 WHERE owner_id = $1
+			 ORDER BY started_at, id`
+	if err = tx.Select(&data.TimeEntries, query, userID, now); err != nil {
+		return model.AccountData{}, fmt.Errorf("%s: %w", op, err)
+	}
+	data.Attachments = make([]model.Attachment, 0)
+	query = `SELECT id, task_id, owner_id, name, content_type, size, blob_key, created_at FROM attachments
+			 WHERE owner_id = $1
+			 ORDER BY id`
+	if err = tx.Select(&data.Attachments, query, userID); err != nil {
+		return model.AccountData{}, fmt.Errorf("%s: %w", op, err)
+	}
+	data.History = make([]model.Operation, 0)
+	query = "SELECT id, kind, payload, created_at FROM task_operations WHERE owner_id = $1 ORDER BY id"
+	if err = tx.Select(&data.History, query, userID); err != nil {
+		return model.AccountData{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return model.AccountData{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return data, nil
+}
+
+// ScheduleDeletion deletes the user once the grace period is over, unless
+// they sign in before. Asking again keeps the first date.
+func (r *AccountPostgres) ScheduleDeletion(userID int64, passwordHash string, grace time.Duration) (time.Time, error) {
+	op := "ScheduleDeletion"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	hashes := make([]string, 0, 1)
+	if err = tx.Select(&hashes, "SELECT password_hash FROM users WHERE id = $1 FOR UPDATE", userID); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(hashes) == 0 {
+		return time.Time{}, fmt.Errorf("%s: %w", op, ErrNoSuchUser)
+	}
+	if hashes[0] != passwordHash {
+		return time.Time{}, fmt.Errorf("%s: %w", op, ErrWrongPassword)
+	}
+	var deleteAfter time.Time
+	query := `UPDATE users SET delete_after = COALESCE(delete_after, (now() AT TIME ZONE 'UTC') + $2 * interval '1 second')
+			  WHERE id = $1 RETURNING delete_after`
+	if err = tx.Get(&deleteAfter, query, userID, grace.Seconds()); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return deleteAfter, nil
+}
+
+// PurgeUsers deletes the users whose grace period is over, their records go
+// with them by the cascades. It returns how many were deleted and the keys of
+// the blobs of their attachments and archives, which the database can't
+// delete.
+func (r *AccountPostgres) PurgeUsers() (int, []string, error) {
+	op := "PurgeUsers"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	// the rows are locked so that signing in can't cancel a deletion that is
+	// already going on
+	userIDs := make([]int64, 0)
+	query := "SELECT id FROM users WHERE delete_after <= now() AT TIME ZONE 'UTC' FOR UPDATE"
+	if err = tx.Select(&userIDs, query); err != nil {
+		return 0, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	keys := make([]string, 0)
+	if len(userIDs) == 0 {
+		return 0, keys, nil
+	}
+	query, args, err := sqlx.In(`SELECT blob_key FROM attachments WHERE owner_id IN (?)
+								 UNION ALL
+								 SELECT blob_key FROM account_exports WHERE owner_id IN (?) AND blob_key IS NOT NULL`,
+		userIDs, userIDs)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Select(&keys, tx.Rebind(query), args...); err != nil {
+		return 0, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	query, args, err = sqlx.In("DELETE FROM users WHERE id IN (?)", userIDs)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err = tx.Exec(tx.Rebind(query), args...); err != nil {
+		return 0, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return len(userIDs), keys, nil
+}
+
+// DeleteOrphanTags deletes the tags no task has anymore. Tags are shared by
+// the users, so the ones of a deleted user may still be used by others.
+func (r *AccountPostgres) DeleteOrphanTags() (int64, error) {
+	op := "DeleteOrphanTags"
+	query := `DELETE FROM tags
+			  WHERE NOT EXISTS (SELECT 1 FROM tags_in_task WHERE tags_in_task.tag_id = tags.id)`
+	res, err := r.db.Exec(query)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,268 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"restAPI/internal/model"
+	"time"
+)
+
+// estimatedTasks is the set the estimate report is built from: the estimated
+// tasks of user $1 completed in [$2, $3) with all the time logged on them,
+// a forgotten timer counting up to $4.
+const estimatedTasks = `WITH estimated AS (
+		SELECT tasks.id, tasks.estimate, tasks.completed_at,
+		    (SELECT COALESCE(sum(extract(epoch FROM COALESCE(stopped_at, $4) - started_at)), 0)
+		     FROM time_entries
+		     WHERE time_entries.task_id = tasks.id)::bigint AS actual
+		FROM tasks
+		WHERE tasks.owner_id = $1 AND tasks.estimate IS NOT NULL
+		    AND tasks.completed_at >= $2 AND tasks.completed_at < $3
+	)`
+
+// statsEvents are the creations and the completions of the tasks of user $1
+// in [$2, $3); took is the time a completed task took since its creation.
+const statsEvents = `WITH events AS (
+		SELECT id, created_at AS happened_at, 'created' AS kind, NULL::interval AS took
+		FROM tasks
+		WHERE owner_id = $1 AND created_at >= $2 AND created_at < $3
+		UNION ALL
+		SELECT id, completed_at, 'completed', completed_at - created_at
+		FROM tasks
+		WHERE owner_id = $1 AND completed_at >= $2 AND completed_at < $3
+	)`
+
+type AnalyticsPostgres struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewAnalyticsPostgres(db *sqlx.DB, log *slog.Logger) *AnalyticsPostgres {
+	return &AnalyticsPostgres{
+		db:  db,
+		log: log,
+	}
+}
+
+// EstimateReport compares estimated and logged time of the tasks the user
+// completed in [from, to).
+func (r *AnalyticsPostgres) EstimateReport(userID int64, from, to time.Time, group string, now time.Time) (model.EstimateReport, error) {
+	op := "EstimateReport"
+	var query string
+	switch group {
+	case model.EstimateGroupTag:
+		query = estimatedTasks + `
+				 SELECT COALESCE(tags.tag, '') AS key, count(*) AS tasks,
+				     sum(estimated.estimate)::bigint AS estimate, sum(estimated.actual)::bigint AS actual
+				 FROM estimated
+				 LEFT OUTER JOIN tags_in_task
+				     ON tags_in_task.task_id = estimated.id
+				 LEFT OUTER JOIN tags
+				     ON tags.id = tags_in_task.tag_id
+				 GROUP BY COALESCE(tags.tag, '')
+				 ORDER BY key`
+	case model.EstimateGroupWeek:
+		query = estimatedTasks + `
+				 SELECT to_char(date_trunc('week', completed_at), 'YYYY-MM-DD') AS key, count(*) AS tasks,
+				     sum(estimate)::bigint AS estimate, sum(actual)::bigint AS actual
+				 FROM estimated
+				 GROUP BY date_trunc('week', completed_at)
+				 ORDER BY date_trunc('week', completed_at)`
+	default:
+		return model.EstimateReport{}, fmt.Errorf("%s: unknown group %q", op, group)
+	}
+	report := model.EstimateReport{
+		From:  from,
+		To:    to,
+		Group: group,
+		Rows:  make([]model.EstimateReportRow, 0),
+	}
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return model.EstimateReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err = tx.Select(&report.Rows, query, userID, from, to, now); err != nil {
+		return model.EstimateReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+	query = estimatedTasks + `
+			 SELECT count(*) AS tasks, COALESCE(sum(estimate), 0)::bigint AS estimate,
+			     COALESCE(sum(actual), 0)::bigint AS actual
+			 FROM estimated`
+	if err = tx.Get(&report.Total, query, userID, from, to, now); err != nil {
+		return model.EstimateReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return model.EstimateReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return report, nil
+}
+
+// inZone turns a UTC column into the wall clock of the zone.
+func inZone(column, zone string) string {
+	return "((" + column + ") AT TIME ZONE 'UTC') AT TIME ZONE " + zone
+}
+
+// StatsReport sums up the tasks the user created and completed in [from, to),
+// bucketed by day or week in the location of from.
+func (r *AnalyticsPostgres) StatsReport(userID int64, from, to time.Time, bucket string, now time.Time) (model.Stats, error) {
+	op := "StatsReport"
+	step := 1
+	start := from
+	switch bucket {
+	case model.StatsBucketDay:
+	case model.StatsBucketWeek:
+		step = 7
+		start = from.AddDate(0, 0, -(int(from.Weekday())+6)%7)
+	default:
+		return model.Stats{}, fmt.Errorf("%s: unknown bucket %q", op, bucket)
+	}
+	zone := from.Location().String()
+	stats := model.Stats{
+		From:    from,
+		To:      to,
+		Bucket:  bucket,
+		Buckets: make([]model.StatsBucket, 0),
+		TopTags: make([]model.StatsTag, 0, model.MaxStatsTags),
+	}
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return model.Stats{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	var totals struct {
+		model.StatsTotals
+		MedianCompletion *int64 `db:"median_completion"`
+	}
+	query := statsEvents + `
+			 SELECT count(*) FILTER (WHERE kind = 'created') AS created,
+			     count(*) FILTER (WHERE kind = 'completed') AS completed,
+			     (percentile_cont(0.5) WITHIN GROUP (ORDER BY extract(epoch FROM took)))::bigint AS median_completion
+			 FROM events`
+	if err = tx.Get(&totals, query, userID, from.UTC(), to.UTC()); err != nil {
+		return model.Stats{}, fmt.Errorf("%s: %w", op, err)
+	}
+	stats.Total = totals.StatsTotals
+	stats.MedianCompletion = totals.MedianCompletion
+	rows := make([]model.StatsBucket, 0)
+	query = statsEvents + `
+			 SELECT to_char(date_trunc($5::text, ` + inZone("happened_at", "$4::text") + `), 'YYYY-MM-DD') AS start,
+			     count(*) FILTER (WHERE kind = 'created') AS created,
+			     count(*) FILTER (WHERE kind = 'completed') AS completed
+			 FROM events
+			 GROUP BY start`
+	if err = tx.Select(&rows, query, userID, from.UTC(), to.UTC(), zone, bucket); err != nil {
+		return model.Stats{}, fmt.Errorf("%s: %w", op, err)
+	}
+	query = statsEvents + `
+			 SELECT tags.tag,
+			     count(*) FILTER (WHERE kind = 'created') AS created,
+			     count(*) FILTER (WHERE kind = 'completed') AS completed
+			 FROM events
+			 JOIN tags_in_task
+			     ON tags_in_task.task_id = events.id
+			 JOIN tags
+			     ON tags.id = tags_in_task.tag_id
+			 GROUP BY tags.tag
+			 ORDER BY count(*) DESC, tags.tag
+			 LIMIT $4`
+	if err = tx.Select(&stats.TopTags, query, userID, from.UTC(), to.UTC(), model.MaxStatsTags); err != nil {
+		return model.Stats{}, fmt.Errorf("%s: %w", op, err)
+	}
+	// days in a row share the difference between the day and its rank
+	query = statsEvents + `,
+			 days AS (
+			     SELECT DISTINCT (` + inZone("happened_at", "$4::text") + `)::date AS day
+			     FROM events
+			     WHERE kind = 'completed'
+			 ),
+			 streaks AS (
+			     SELECT max(day) AS last, count(*) AS length
+			     FROM (SELECT day, day - (row_number() OVER (ORDER BY day))::int AS island FROM days) AS numbered
+			     GROUP BY island
+			 )
+			 SELECT COALESCE(max(length), 0) AS longest,
+			     COALESCE(max(length) FILTER (WHERE last >= (` + inZone("$5::timestamp", "$4::text") + `)::date - 1), 0) AS current
+			 FROM streaks`
+	if err = tx.Get(&stats.Streaks, query, userID, from.UTC(), to.UTC(), zone, now.UTC()); err != nil {
+		return model.Stats{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return model.Stats{}, fmt.Errorf("%s: %w", op, err)
+	}
+	counted := make(map[string]model.StatsTotals, len(rows))
+	for _, row := range rows {
+		counted[row.Start] = row.StatsTotals
+	}
+	for day := start; day.Before(to); day = day.AddDate(0, 0, step) {
+		key := day.Format("2006-01-02")
+		stats.Buckets = append(stats.Buckets, model.StatsBucket{
+			Start:       key,
+			StatsTotals: counted[key],
+		})
+	}
+	return stats, nil
+}
+
+// ActivityHeatmap counts the tasks of the user dated on each day of
+// [from, to), days without tasks included.
+func (r *AnalyticsPostgres) ActivityHeatmap(userID int64, from, to time.Time) ([]model.HeatmapDay, error) {
+	op := "ActivityHeatmap"
+	rows := make([]model.HeatmapDay, 0)
+	query := `SELECT date_trunc('day', date) AS day, count(*) AS tasks
+			  FROM tasks
+			  WHERE owner_id = $1 AND date >= $2 AND date < $3
+			  GROUP BY day`
+	if err := r.db.Select(&rows, query, userID, from, to); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	counted := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counted[row.Date.Format("2006-01-02")] = row.Tasks
+	}
+	days := make([]model.HeatmapDay, 0, 366)
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		days = append(days, model.HeatmapDay{
+			Date:  day,
+			Tasks: counted[day.Format("2006-01-02")],
+		})
+	}
+	return days, nil
+}
+
+// ProjectBurndown counts the tasks of the project still open at the end of
+// each day of [from, to), days being in the location of from.
+func (r *AnalyticsPostgres) ProjectBurndown(projectID, userID int64, from, to time.Time) ([]model.BurndownDay, error) {
+	op := "ProjectBurndown"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err = checkProjectOwner(tx, projectID, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	days := make([]model.BurndownDay, 0, model.MaxBurndownDays)
+	query := `SELECT series.day,
+			      count(tasks.id) FILTER (WHERE ` + inZone("tasks.created_at", "$5::text") + ` < series.day + interval '1 day'
+			          AND (tasks.completed_at IS NULL
+			              OR ` + inZone("tasks.completed_at", "$5::text") + ` >= series.day + interval '1 day')) AS remaining
+			  FROM generate_series($3::timestamp, $4::timestamp, interval '1 day') AS series(day)
+			  LEFT OUTER JOIN tasks
+			      ON tasks.project_id = $1 AND tasks.owner_id = $2
+			  GROUP BY series.day
+			  ORDER BY series.day`
+	// the series runs on the wall clock of the location
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	last := to.AddDate(0, 0, -1)
+	last = time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC)
+	err = tx.Select(&days, query, projectID, userID, first, last, from.Location().String())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return days, nil
+}
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"restAPI/internal/model"
+)
+
+type AttachmentPostgres struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewAttachmentPostgres(db *sqlx.DB, log *slog.Logger) *AttachmentPostgres {
+	return &AttachmentPostgres{
+		db:  db,
+		log: log,
+	}
+}
+
+// CreateAttachment saves the attachment unless it takes the user over quota bytes.
+func (r *AttachmentPostgres) CreateAttachment(attachment model.Attachment, quota int64) (int64, error) {
+	op := "CreateAttachment"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	// the user row is locked so parallel uploads can't both fit in the same free space
+	query := "SELECT id FROM users WHERE id = $1 FOR UPDATE"
+	if _, err = tx.Exec(query, attachment.OwnerID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = checkTaskOwner(tx, attachment.TaskID, attachment.OwnerID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	var used int64
+	query = "SELECT COALESCE(sum(size), 0) FROM attachments WHERE owner_id = $1"
+	if err = tx.Get(&used, query, attachment.OwnerID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if used+attachment.Size > quota {
+		return 0, fmt.Errorf("%s: %w", op, ErrQuotaExceeded)
+	}
+	var attachmentID int64
+	query = `INSERT INTO attachments (task_id, owner_id, name, content_type, size, blob_key)
+			 VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	err = tx.Get(&attachmentID, query, attachment.TaskID, attachment.OwnerID, attachment.Name,
+		attachment.ContentType, attachment.Size, attachment.Key)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return attachmentID, nil
+}
+
+func (r *AttachmentPostgres) GetAttachments(taskID, userID int64) ([]model.Attachment, error) {
+	op := "GetAttachments"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err = checkTaskOwner(tx, taskID, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	attachments := make([]model.Attachment, 0)
+	query := `SELECT id, task_id, owner_id, name, content_type, size, blob_key, created_at FROM attachments
+			  WHERE task_id = $1
+			  ORDER BY id`
+	if err = tx.Select(&attachments, query, taskID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return attachments, nil
+}
+
+func (r *AttachmentPostgres) GetAttachment(taskID, attachmentID, userID int64) (model.Attachment, error) {
+	op := "GetAttachment"
+	attachments := make([]model.Attachment, 0, 1)
+	query := `SELECT id, task_id, owner_id, name, content_type, size, blob_key, created_at FROM attachments
+			  WHERE id = $1 AND task_id = $2 AND owner_id = $3`
+	if err := r.db.Select(&attachments, query, attachmentID, taskID, userID); err != nil {
+		return model.Attachment{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(attachments) == 0 {
+		return model.Attachment{}, fmt.Errorf("%s: %w", op, ErrNoAttachment)
+	}
+	return attachments[0], nil
+}
+
+// DeleteAttachment removes the row and returns the key of the blob it pointed to.
+func (r *AttachmentPostgres) DeleteAttachment(taskID, attachmentID, userID int64) (string, error) {
+	op := "DeleteAttachment"
+	keys := make([]string, 0, 1)
+	query := "DELETE FROM attachments WHERE id = $1 AND task_id = $2 AND owner_id = $3 RETURNING blob_key"
+	if err := r.db.Select(&keys, query, attachmentID, taskID, userID); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if len(keys) == 0 {
+		return "", fmt.Errorf("%s: %w", op, ErrNoAttachment)
+	}
+	return keys[0], nil
+}
@@ -65,8 +65,9 @@ func (r *AuthPostgres) GetUser(login, password string) (model.User, error) {
 	}
 	defer tx.Rollback()
 	user := make([]model.User, 0)
+	// the users whose deletion is due are gone already for signing in
 	query := `SELECT id, firstname, lastname, login, password_hash
-			 FROM users WHERE login = $1 `
+			 FROM users WHERE login = $1 AND (delete_after IS NULL OR delete_after > now() AT TIME ZONE 'UTC')`
 	err = r.db.Select(&user, query, login)
 	if err != nil {
 		return model.User{}, fmt.Errorf("%s: %w", op, err)
@@ -85,3 +86,14 @@ func (r *AuthPostgres) GetUser(login, password string) (model.User, error) {
 	}
 	return user[0], nil
 }
+
+// CancelDeletion keeps the user from being deleted, signing in during the
+// grace period cancels the deletion.
+func (r *AuthPostgres) CancelDeletion(userID int64) error {
+	op := "CancelDeletion"
+	query := "UPDATE users SET delete_after = NULL WHERE id = $1 AND delete_after IS NOT NULL"
+	if _, err := r.db.Exec(query, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,344 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"restAPI/internal/entities"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/rank"
+)
+
+type BoardPostgres struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewBoardPostgres(db *sqlx.DB, log *slog.Logger) *BoardPostgres {
+	return &BoardPostgres{
+		db:  db,
+		log: log,
+	}
+}
+
+func defaultColumns() []model.BoardColumn {
+	todo, inProgress, done := model.TaskStatusTodo, model.TaskStatusInProgress, model.TaskStatusDone
+	return []model.BoardColumn{
+		{Name: "To do", Status: &todo},
+		{Name: "In progress", Status: &inProgress},
+		{Name: "Done", Status: &done},
+	}
+}
+
+func checkBoardOwner(ext sqlx.Ext, boardID, userID int64) error {
+	op := "checkBoardOwner"
+	var count int
+	query := "SELECT count(*) FROM boards WHERE id = $1 AND owner_id = $2"
+	err := sqlx.Get(ext, &count, query, boardID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoBoard)
+	}
+	return nil
+}
+
+func (r *BoardPostgres) CreateBoard(board model.Board) (int64, error) {
+	op := "CreateBoard"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	var boardID int64
+	query := "INSERT INTO boards (name, owner_id) VALUES ($1, $2) RETURNING id"
+	err = tx.Get(&boardID, query, board.Name, board.OwnerID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	columns := board.Columns
+	if len(columns) == 0 {
+		columns = defaultColumns()
+	}
+	ranks := rank.Spread(len(columns))
+	query = "INSERT INTO board_columns (board_id, name, status, rank) VALUES ($1, $2, $3, $4)"
+	for i, column := range columns {
+		_, err = tx.Exec(query, boardID, column.Name, column.Status, ranks[i])
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return boardID, nil
+}
+
+func (r *BoardPostgres) GetBoards(userID int64) ([]model.Board, error) {
+	op := "GetBoards"
+	boards := make([]model.Board, 0)
+	query := "SELECT id, name, owner_id FROM boards WHERE owner_id = $1 ORDER BY id"
+	err := r.db.Select(&boards, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return boards, nil
+}
+
+func (r *BoardPostgres) GetBoard(boardID, userID int64) (model.Board, error) {
+	op := "GetBoard"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return model.Board{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	boards := make([]model.Board, 0, 1)
+	query := "SELECT id, name, owner_id FROM boards WHERE id = $1 AND owner_id = $2"
+	err = tx.Select(&boards, query, boardID, userID)
+	if err != nil {
+		return model.Board{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(boards) == 0 {
+		return model.Board{}, fmt.Errorf("%s: %w", op, ErrNoBoard)
+	}
+	board := boards[0]
+	board.Columns = make([]model.BoardColumn, 0)
+	query = "SELECT id, board_id, name, status, rank FROM board_columns WHERE board_id = $1 ORDER BY rank"
+	err = tx.Select(&board.Columns, query, boardID)
+	if err != nil {
+		return model.Board{}, fmt.Errorf("%s: %w", op, err)
+	}
+	rawCards := make([]entities.CardWithTag, 0)
+	query = `SELECT board_cards.column_id, board_cards.rank AS card_rank,
+			 	tasks.id, task, date, tags.tag AS tag, owner_id, project_id, status, completed_at, estimate, parent_id, due,
+			 	priority, recurrence, hidden_until, ` +
+		progressColumns + ", " + commentColumns + `
+			 FROM board_cards
+			 JOIN tasks
+			     ON tasks.id = board_cards.task_id
+			 LEFT OUTER JOIN tags_in_task
+			     ON tasks.id = tags_in_task.task_id
+			 LEFT OUTER JOIN tags
+			     ON tags.id = tags_in_task.tag_id
+			 WHERE board_cards.board_id = $1
+			 ORDER BY board_cards.column_id, board_cards.rank, tasks.id`
+	err = tx.Select(&rawCards, query, boardID)
+	if err != nil {
+		return model.Board{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return model.Board{}, fmt.Errorf("%s: %w", op, err)
+	}
+	cards := make(map[int64][]model.BoardCard)
+	for _, rawCard := range rawCards {
+		columnCards := cards[rawCard.ColumnID]
+		if len(columnCards) == 0 || columnCards[len(columnCards)-1].TaskID != rawCard.ID {
+			columnCards = append(columnCards, model.BoardCard{
+				TaskID: rawCard.ID,
+				Rank:   rawCard.CardRank,
+				Task: model.Task{
+					ID:          rawCard.ID,
+					Text:        rawCard.Task,
+					Date:        rawCard.Date,
+					OwnerID:     rawCard.OwnerID,
+					ProjectID:   rawCard.ProjectID,
+					Status:      rawCard.Status,
+					CompletedAt: rawCard.CompletedAt,
+					Estimate:    rawCard.Estimate,
+					ParentID:    rawCard.ParentID,
+					Due:         rawCard.Due,
+					Priority:    rawCard.Priority,
+					Recurrence:  rawCard.Recurrence,
+					HiddenUntil: rawCard.HiddenUntil,
+					Progress: model.TaskProgress{
+						Done:  rawCard.ItemsDone,
+						Total: rawCard.ItemsTotal,
+					},
+					Comments: rawCard.Comments,
+				},
+			})
+		}
+		if rawCard.Tag != nil {
+			last := &columnCards[len(columnCards)-1]
+			last.Tags = append(last.Tags, *rawCard.Tag)
+		}
+		cards[rawCard.ColumnID] = columnCards
+	}
+	for i := range board.Columns {
+		board.Columns[i].Cards = cards[board.Columns[i].ID]
+		if board.Columns[i].Cards == nil {
+			board.Columns[i].Cards = []model.BoardCard{}
+		}
+	}
+	return board, nil
+}
+
+func (r *BoardPostgres) DeleteBoard(boardID, userID int64) error {
+	op := "DeleteBoard"
+	query := "DELETE FROM boards WHERE id = $1 AND owner_id = $2"
+	res, err := r.db.Exec(query, boardID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoBoard)
+	}
+	return nil
+}
+
+func (r *BoardPostgres) CreateColumn(column model.BoardColumn, userID int64) (int64, error) {
+	op := "CreateColumn"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err = checkBoardOwner(tx, column.BoardID, userID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	var last string
+	query := "SELECT COALESCE(max(rank), '') FROM board_columns WHERE board_id = $1"
+	if err = tx.Get(&last, query, column.BoardID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	key, err := rank.Between(last, "")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	var columnID int64
+	query = "INSERT INTO board_columns (board_id, name, status, rank) VALUES ($1, $2, $3, $4) RETURNING id"
+	err = tx.Get(&columnID, query, column.BoardID, column.Name, column.Status, key)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return columnID, nil
+}
+
+func (r *BoardPostgres) cardRank(ext sqlx.Ext, boardID, columnID, taskID int64) (string, error) {
+	op := "cardRank"
+	ranks := make([]string, 0, 1)
+	query := "SELECT rank FROM board_cards WHERE board_id = $1 AND column_id = $2 AND task_id = $3"
+	err := sqlx.Select(ext, &ranks, query, boardID, columnID, taskID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if len(ranks) == 0 {
+		return "", fmt.Errorf("%s: %w", op, ErrNoCard)
+	}
+	return ranks[0], nil
+}
+
+func (r *BoardPostgres) rebalanceColumn(ext sqlx.Ext, columnID int64) error {
+	op := "rebalanceColumn"
+	taskIDs := make([]int64, 0)
+	query := "SELECT task_id FROM board_cards WHERE column_id = $1 ORDER BY rank"
+	if err := sqlx.Select(ext, &taskIDs, query, columnID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	keys := rank.Spread(len(taskIDs))
+	query = "UPDATE board_cards SET rank = $1 WHERE column_id = $2 AND task_id = $3"
+	for i, taskID := range taskIDs {
+		if _, err := ext.Exec(query, keys[i], columnID, taskID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+func (r *BoardPostgres) MoveCard(boardID, taskID, userID int64, move model.CardMove) error {
+	op := "MoveCard"
+	if move.After != nil && *move.After == taskID || move.Before != nil && *move.Before == taskID {
+		return fmt.Errorf("%s: %w", op, ErrCardOrder)
+	}
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err = checkBoardOwner(tx, boardID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	// locking the column keeps concurrent moves into it from picking the same rank
+	statuses := make([]*string, 0, 1)
+	query := "SELECT status FROM board_columns WHERE id = $1 AND board_id = $2 FOR UPDATE"
+	if err = tx.Select(&statuses, query, move.ColumnID, boardID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(statuses) == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoColumn)
+	}
+	var count int
+	query = "SELECT count(*) FROM tasks WHERE id = $1 AND owner_id = $2"
+	if err = tx.Get(&count, query, taskID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoTask)
+	}
+	key, err := rank.Place(rankedList{
+		ext: tx,
+		key: func(id int64) (string, error) {
+			return r.cardRank(tx, boardID, move.ColumnID, id)
+		},
+		keys:   "SELECT rank AS key FROM board_cards WHERE column_id = $1 AND task_id <> $2",
+		listID: move.ColumnID,
+		moved:  taskID,
+	}, move.After, move.Before)
+	if errors.Is(err, rank.ErrOrder) {
+		return fmt.Errorf("%s: %w", op, ErrCardOrder)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	query = `INSERT INTO board_cards (board_id, column_id, task_id, rank) VALUES ($1, $2, $3, $4)
+			 ON CONFLICT (board_id, task_id) DO UPDATE SET column_id = EXCLUDED.column_id, rank = EXCLUDED.rank`
+	if _, err = tx.Exec(query, boardID, move.ColumnID, taskID, key); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(key) > rank.MaxLength {
+		if err = r.rebalanceColumn(tx, move.ColumnID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if status := statuses[0]; status != nil {
+		query = `UPDATE tasks
+				 SET status = $1, completed_at = CASE WHEN $1 = 'done' THEN COALESCE(completed_at, now() AT TIME ZONE 'UTC') END
+				 WHERE id = $2`
+		if _, err = tx.Exec(query, *status, taskID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *BoardPostgres) RemoveCard(boardID, taskID, userID int64) error {
+	op := "RemoveCard"
+	query := `DELETE FROM board_cards
+			  USING boards
+			  WHERE boards.id = board_cards.board_id AND board_cards.board_id = $1
+			  	AND board_cards.task_id = $2 AND boards.owner_id = $3`
+	res, err := r.db.Exec(query, boardID, taskID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoCard)
+	}
+	return nil
+}
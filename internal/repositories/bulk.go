@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"restAPI/internal/entities"
+	"restAPI/internal/model"
+)
+
+// copyTask inserts a copy of the task under parentID and returns the snapshots
+// of the new tasks, parents first. Copies start over: they are not done and
+// neither are their checklist items.
+func (r *TaskPostgres) copyTask(ext sqlx.Ext, source entities.TaskSnapshot, userID int64, parentID *int64,
+	options model.DuplicateOptions) ([]entities.TaskSnapshot, error) {
+	op := "copyTask"
+	task := model.Task{
+		Text:        source.Text,
+		Date:        source.Date,
+		OwnerID:     userID,
+		ProjectID:   source.ProjectID,
+		Estimate:    source.Estimate,
+		ParentID:    parentID,
+		Due:         source.Due,
+		Priority:    source.Priority,
+		Recurrence:  source.Recurrence,
+		HiddenUntil: source.HiddenUntil,
+	}
+	if options.Tags {
+		task.Tags = source.Tags
+	}
+	snapshots, err := r.insertTask(ext, task)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	taskID := snapshots[0].ID
+	if options.Checklist {
+		query := `INSERT INTO task_items (task_id, text, done, rank)
+				  SELECT $1, text, false, rank FROM task_items WHERE task_id = $2`
+		if _, err = ext.Exec(query, taskID, source.ID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if snapshots[0], err = r.snapshotTask(ext, taskID, userID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if !options.Subtasks {
+		return snapshots, nil
+	}
+	subtaskIDs := make([]int64, 0)
+	query := "SELECT id FROM tasks WHERE parent_id = $1 ORDER BY id"
+	if err = sqlx.Select(ext, &subtaskIDs, query, source.ID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, subtaskID := range subtaskIDs {
+		subtask, err := r.snapshotTask(ext, subtaskID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		copies, err := r.copyTask(ext, subtask, userID, &taskID, options)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		snapshots = append(snapshots, copies...)
+	}
+	return snapshots, nil
+}
+
+// DuplicateTask copies the task next to the original, under the same parent.
+func (r *TaskPostgres) DuplicateTask(taskID, userID int64, options model.DuplicateOptions) (int64, error) {
+	op := "DuplicateTask"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	source, err := r.snapshotTask(tx, taskID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	after, err := r.copyTask(tx, source, userID, source.ParentID, options)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	err = r.pushOperation(tx, userID, model.OperationCreate, entities.OperationPayload{
+		After: after,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return after[0].ID, nil
+}
+
+// MoveTasks applies the move to all the tasks or, when one of them isn't the
+// user's, to none. Moving to another day keeps the time of day of each task.
+func (r *TaskPostgres) MoveTasks(userID int64, move model.TaskMove) error {
+	op := "MoveTasks"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	taskIDs := make([]int64, 0, len(move.TaskIDs))
+	query, args, err := sqlx.In("SELECT id FROM tasks WHERE owner_id = ? AND id IN (?) ORDER BY id FOR UPDATE",
+		userID, move.TaskIDs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Select(&taskIDs, tx.Rebind(query), args...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	unique := make(map[int64]struct{}, len(move.TaskIDs))
+	for _, taskID := range move.TaskIDs {
+		unique[taskID] = struct{}{}
+	}
+	if len(taskIDs) != len(unique) {
+		return fmt.Errorf("%s: %w", op, ErrNoTask)
+	}
+	if move.ProjectID != nil {
+		if err = checkProjectOwner(tx, *move.ProjectID, userID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	payload := entities.OperationPayload{
+		Before: make([]entities.TaskSnapshot, 0, len(taskIDs)),
+		After:  make([]entities.TaskSnapshot, 0, len(taskIDs)),
+	}
+	for _, taskID := range taskIDs {
+		before, err := r.snapshotTask(tx, taskID, userID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		payload.Before = append(payload.Before, before)
+		if move.ProjectID != nil || move.WithoutProject {
+			query = "UPDATE tasks SET project_id = $1 WHERE id = $2"
+			if _, err = tx.Exec(query, move.ProjectID, taskID); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		if move.Date != nil {
+			query = "UPDATE tasks SET date = $1::date + date::time WHERE id = $2"
+			if _, err = tx.Exec(query, move.Date.Format("2006-01-02"), taskID); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		after, err := r.snapshotTask(tx, taskID, userID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		payload.After = append(payload.After, after)
+	}
+	if err = r.pushOperation(tx, userID, model.OperationMove, payload); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"fmt"
+	"restAPI/internal/entities"
+	"restAPI/internal/model"
+	"time"
+)
+
+// GetCalendarMonth counts the tasks of each day of the month in one query.
+// Days are taken from the task dates the same way GetTasksByDate does.
+func (r *TaskPostgres) GetCalendarMonth(month, year int, userID int64, filter model.TaskFilter) (model.CalendarMonth, error) {
+	op := "GetCalendarMonth"
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	where, args := filterClause("owner_id = $1 AND date >= $2 AND date < $3", filter,
+		[]interface{}{userID, from, to, model.MaxCalendarTags})
+	query := `WITH month_tasks AS (
+			      SELECT tasks.id, date_trunc('day', tasks.date) AS day, tasks.status FROM tasks
+			      WHERE ` + where + `
+			  )
+			  SELECT to_char(month_tasks.day, 'YYYY-MM-DD') AS day,
+			      count(*) AS tasks,
+			      count(*) FILTER (WHERE month_tasks.status = 'done') AS done,
+			      ARRAY(SELECT tags.tag FROM month_tasks AS same_day
+			            JOIN tags_in_task
+			                ON tags_in_task.task_id = same_day.id
+			            JOIN tags
+			                ON tags.id = tags_in_task.tag_id
+			            WHERE same_day.day = month_tasks.day
+			            GROUP BY tags.tag
+			            ORDER BY count(*) DESC, tags.tag
+			            LIMIT $4) AS top_tags
+			  FROM month_tasks
+			  GROUP BY month_tasks.day
+			  ORDER BY month_tasks.day`
+	rawDays := make([]entities.CalendarDay, 0)
+	if err := r.db.Select(&rawDays, query, args...); err != nil {
+		return model.CalendarMonth{}, fmt.Errorf("%s: %w", op, err)
+	}
+	counted := make(map[string]entities.CalendarDay, len(rawDays))
+	for _, rawDay := range rawDays {
+		counted[rawDay.Date] = rawDay
+	}
+	calendar := model.CalendarMonth{
+		Year:  year,
+		Month: month,
+		Days:  make([]model.CalendarDay, 0, 31),
+	}
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		rawDay := counted[date]
+		topTags := []string(rawDay.TopTags)
+		if topTags == nil {
+			topTags = []string{}
+		}
+		calendar.Days = append(calendar.Days, model.CalendarDay{
+			Date:    date,
+			Tasks:   rawDay.Tasks,
+			Done:    rawDay.Done,
+			TopTags: topTags,
+		})
+	}
+	return calendar, nil
+}
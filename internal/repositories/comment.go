@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"restAPI/internal/model"
+	"time"
+)
+
+// commentColumns adds the number of comments of every selected task row.
+const commentColumns = `(SELECT count(*) FROM comments WHERE comments.task_id = tasks.id) AS comment_count`
+
+type CommentPostgres struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewCommentPostgres(db *sqlx.DB, log *slog.Logger) *CommentPostgres {
+	return &CommentPostgres{
+		db:  db,
+		log: log,
+	}
+}
+
+func commentCount(ext sqlx.Ext, taskID int64) (int, error) {
+	op := "commentCount"
+	var count int
+	query := "SELECT count(*) FROM comments WHERE task_id = $1"
+	if err := sqlx.Get(ext, &count, query, taskID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return count, nil
+}
+
+func (r *CommentPostgres) CreateComment(comment model.Comment) (int64, error) {
+	op := "CreateComment"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	commentID, err := createComment(tx, comment)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return commentID, nil
+}
+
+func createComment(ext sqlx.Ext, comment model.Comment) (int64, error) {
+	op := "createComment"
+	if err := checkTaskOwner(ext, comment.TaskID, comment.AuthorID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if comment.ParentID != nil {
+		var count int
+		query := "SELECT count(*) FROM comments WHERE id = $1 AND task_id = $2"
+		if err := sqlx.Get(ext, &count, query, *comment.ParentID, comment.TaskID); err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		if count == 0 {
+			return 0, fmt.Errorf("%s: %w", op, ErrNoComment)
+		}
+	}
+	// comments brought from elsewhere keep the time they were written at
+	var createdAt *time.Time
+	if !comment.CreatedAt.IsZero() {
+		utc := comment.CreatedAt.UTC()
+		createdAt = &utc
+	}
+	var commentID int64
+	query := `INSERT INTO comments (task_id, author_id, parent_id, text, created_at)
+			  VALUES ($1, $2, $3, $4, COALESCE($5, now() AT TIME ZONE 'UTC')) RETURNING id`
+	err := sqlx.Get(ext, &commentID, query, comment.TaskID, comment.AuthorID, comment.ParentID, comment.Text, createdAt)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return commentID, nil
+}
+
+// GetComments returns a page of threads, newest last, each with all its
+// replies, and the number of threads on the task.
+func (r *CommentPostgres) GetComments(taskID, userID int64, page model.Page) ([]model.Comment, int, error) {
+	op := "GetComments"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err = checkTaskOwner(tx, taskID, userID); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	var total int
+	query := "SELECT count(*) FROM comments WHERE task_id = $1 AND parent_id IS NULL"
+	if err = tx.Get(&total, query, taskID); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	rawComments := make([]model.Comment, 0)
+	query = `WITH RECURSIVE thread AS (
+			     (SELECT * FROM comments
+			      WHERE task_id = $1 AND parent_id IS NULL
+			      ORDER BY id
+			      LIMIT $2 OFFSET $3)
+			     UNION ALL
+			     SELECT comments.* FROM comments
+			     JOIN thread
+			         ON comments.parent_id = thread.id
+			 )
+			 SELECT id, task_id, author_id, parent_id, text, created_at, edited_at FROM thread
+			 ORDER BY id`
+	if err = tx.Select(&rawComments, query, taskID, page.Limit, page.Offset); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return buildThreads(rawComments), total, nil
+}
+
+// buildThreads nests the comments under their parents. Parents have smaller
+// ids than their replies, so one pass from the end is enough.
+func buildThreads(rawComments []model.Comment) []model.Comment {
+	index := make(map[int64]int, len(rawComments))
+	for i, comment := range rawComments {
+		index[comment.ID] = i
+	}
+	for i := len(rawComments) - 1; i >= 0; i-- {
+		comment := rawComments[i]
+		if comment.ParentID == nil {
+			continue
+		}
+		parent := &rawComments[index[*comment.ParentID]]
+		parent.Replies = append([]model.Comment{comment}, parent.Replies...)
+	}
+	threads := make([]model.Comment, 0)
+	for _, comment := range rawComments {
+		if comment.ParentID == nil {
+			threads = append(threads, comment)
+		}
+	}
+	return threads
+}
+
+// commentRights returns the author of the comment and the owner of its task.
+func (r *CommentPostgres) commentRights(ext sqlx.Ext, taskID, commentID int64) (int64, int64, error) {
+	op := "commentRights"
+	rights := make([]struct {
+		AuthorID int64 `db:"author_id"`
+		OwnerID  int64 `db:"owner_id"`
+	}, 0, 1)
+	query := `SELECT comments.author_id, tasks.owner_id FROM comments
+			  JOIN tasks
+			      ON tasks.id = comments.task_id
+			  WHERE comments.id = $1 AND comments.task_id = $2`
+	if err := sqlx.Select(ext, &rights, query, commentID, taskID); err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(rights) == 0 {
+		return 0, 0, fmt.Errorf("%s: %w", op, ErrNoComment)
+	}
+	return rights[0].AuthorID, rights[0].OwnerID, nil
+}
+
+// UpdateComment changes the text of a comment, only its author may do it.
+func (r *CommentPostgres) UpdateComment(comment model.Comment, userID int64) error {
+	op := "UpdateComment"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	authorID, ownerID, err := r.commentRights(tx, comment.TaskID, comment.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if ownerID != userID && authorID != userID {
+		return fmt.Errorf("%s: %w", op, ErrNoComment)
+	}
+	if authorID != userID {
+		return fmt.Errorf("%s: %w", op, ErrCommentRights)
+	}
+	query := "UPDATE comments SET text = $1, edited_at = now() AT TIME ZONE 'UTC' WHERE id = $2"
+	if _, err = tx.Exec(query, comment.Text, comment.ID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// DeleteComment removes a comment with all its replies. The author and the
+// owner of the task may do it.
+func (r *CommentPostgres) DeleteComment(taskID, commentID, userID int64) error {
+	op := "DeleteComment"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	authorID, ownerID, err := r.commentRights(tx, taskID, commentID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if ownerID != userID && authorID != userID {
+		return fmt.Errorf("%s: %w", op, ErrNoComment)
+	}
+	query := "DELETE FROM comments WHERE id = $1"
+	if _, err = tx.Exec(query, commentID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
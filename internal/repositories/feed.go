@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+)
+
+// FeedPostgres keeps the calendar feed tokens of the users, one each. Only
+// the hashes of the tokens are stored.
+type FeedPostgres struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewFeedPostgres(db *sqlx.DB, log *slog.Logger) *FeedPostgres {
+	return &FeedPostgres{
+		db:  db,
+		log: log,
+	}
+}
+
+// SetFeedToken replaces the feed token of the user, the old one stops working.
+func (r *FeedPostgres) SetFeedToken(userID int64, tokenHash string) error {
+	op := "SetFeedToken"
+	query := `INSERT INTO feed_tokens (user_id, token_hash) VALUES ($1, $2)
+			  ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = now()`
+	if _, err := r.db.Exec(query, userID, tokenHash); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *FeedPostgres) DeleteFeedToken(userID int64) error {
+	op := "DeleteFeedToken"
+	res, err := r.db.Exec("DELETE FROM feed_tokens WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoFeed)
+	}
+	return nil
+}
+
+func (r *FeedPostgres) GetFeedUser(tokenHash string) (int64, error) {
+	op := "GetFeedUser"
+	users := make([]int64, 0, 1)
+	if err := r.db.Select(&users, "SELECT user_id FROM feed_tokens WHERE token_hash = $1", tokenHash); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(users) == 0 {
+		return 0, fmt.Errorf("%s: %w", op, ErrNoFeed)
+	}
+	return users[0], nil
+}
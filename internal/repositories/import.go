@@ -0,0 +1,261 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"restAPI/internal/entities"
+	"restAPI/internal/model"
+)
+
+// ImportPostgres keeps the import jobs and what the records of the imported
+// files became.
+type ImportPostgres struct {
+	db    *sqlx.DB
+	log   *slog.Logger
+	tasks *TaskPostgres
+	items *ItemPostgres
+}
+
+func NewImportPostgres(db *sqlx.DB, log *slog.Logger) *ImportPostgres {
+	return &ImportPostgres{
+		db:    db,
+		log:   log,
+		tasks: NewTaskPostgres(db, log),
+		items: NewItemPostgres(db, log),
+	}
+}
+
+func toImportJob(rawJob entities.ImportJob) (model.ImportJob, error) {
+	op := "toImportJob"
+	job := model.ImportJob{
+		ID:         rawJob.ID,
+		OwnerID:    rawJob.OwnerID,
+		Source:     rawJob.Source,
+		Status:     rawJob.Status,
+		Done:       rawJob.Done,
+		Total:      rawJob.Total,
+		CreatedAt:  rawJob.CreatedAt,
+		FinishedAt: rawJob.FinishedAt,
+	}
+	if rawJob.Error != nil {
+		job.Error = *rawJob.Error
+	}
+	if rawJob.Report != nil {
+		job.Report = &model.ImportJobReport{}
+		if err := json.Unmarshal(rawJob.Report, job.Report); err != nil {
+			return model.ImportJob{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return job, nil
+}
+
+// CreateImportJob starts a running job. A user has one running job at most,
+// ErrImportRunning tells another one is.
+func (r *ImportPostgres) CreateImportJob(job model.ImportJob) (int64, error) {
+	op := "CreateImportJob"
+	jobIDs := make([]int64, 0, 1)
+	query := `INSERT INTO import_jobs (owner_id, source, status, total) VALUES ($1, $2, $3, $4)
+			  ON CONFLICT DO NOTHING RETURNING id`
+	err := r.db.Select(&jobIDs, query, job.OwnerID, job.Source, model.ImportJobRunning, job.Total)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(jobIDs) == 0 {
+		return 0, fmt.Errorf("%s: %w", op, ErrImportRunning)
+	}
+	return jobIDs[0], nil
+}
+
+func (r *ImportPostgres) GetImportJob(jobID, userID int64) (model.ImportJob, error) {
+	op := "GetImportJob"
+	rawJobs := make([]entities.ImportJob, 0, 1)
+	query := `SELECT id, owner_id, source, status, done, total, report, error, created_at, finished_at
+			  FROM import_jobs WHERE id = $1 AND owner_id = $2`
+	if err := r.db.Select(&rawJobs, query, jobID, userID); err != nil {
+		return model.ImportJob{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(rawJobs) == 0 {
+		return model.ImportJob{}, fmt.Errorf("%s: %w", op, ErrNoImportJob)
+	}
+	job, err := toImportJob(rawJobs[0])
+	if err != nil {
+		return model.ImportJob{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return job, nil
+}
+
+// SetImportProgress records how many records of the file the job went through.
+func (r *ImportPostgres) SetImportProgress(jobID int64, done int) error {
+	op := "SetImportProgress"
+	query := "UPDATE import_jobs SET done = $2 WHERE id = $1 AND status = $3"
+	if _, err := r.db.Exec(query, jobID, done, model.ImportJobRunning); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// FinishImportJob ends the job with its status, its report and, for a failed
+// job, what went wrong.
+func (r *ImportPostgres) FinishImportJob(job model.ImportJob) error {
+	op := "FinishImportJob"
+	var report []byte
+	if job.Report != nil {
+		var err error
+		if report, err = json.Marshal(job.Report); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	var jobError *string
+	if job.Error != "" {
+		jobError = &job.Error
+	}
+	query := `UPDATE import_jobs SET status = $2, done = $3, report = $4, error = $5, finished_at = now() AT TIME ZONE 'UTC'
+			  WHERE id = $1 AND status = $6`
+	_, err := r.db.Exec(query, job.ID, job.Status, job.Done, report, jobError, model.ImportJobRunning)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// FailRunningImportJobs fails the jobs still running, jobs run in the server
+// and don't outlive it.
+func (r *ImportPostgres) FailRunningImportJobs(reason string) error {
+	op := "FailRunningImportJobs"
+	query := "UPDATE import_jobs SET status = $1, error = $2, finished_at = now() AT TIME ZONE 'UTC' WHERE status = $3"
+	if _, err := r.db.Exec(query, model.ImportJobFailed, reason, model.ImportJobRunning); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// GetImportRefs returns what the records of the source already imported by
+// the user became. Deleting a record drops its ref, so that it is imported
+// again.
+func (r *ImportPostgres) GetImportRefs(userID int64, source string) (map[model.ImportRef]int64, error) {
+	op := "GetImportRefs"
+	rows := make([]struct {
+		Kind       string `db:"kind"`
+		ExternalID string `db:"external_id"`
+		LocalID    int64  `db:"local_id"`
+	}, 0)
+	query := `SELECT kind, external_id, COALESCE(project_id, task_id, item_id, comment_id) AS local_id
+			  FROM import_refs WHERE owner_id = $1 AND source = $2`
+	if err := r.db.Select(&rows, query, userID, source); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	refs := make(map[model.ImportRef]int64, len(rows))
+	for _, row := range rows {
+		refs[model.ImportRef{Kind: row.Kind, ExternalID: row.ExternalID}] = row.LocalID
+	}
+	return refs, nil
+}
+
+// ImportProject creates the project imported from the record of the source
+// with its ref. A record imported already isn't created again, its local id
+// is returned with false.
+func (r *ImportPostgres) ImportProject(source string, ref model.ImportRef, project model.Project) (int64, bool, error) {
+	op := "ImportProject"
+	projectID, created, err := r.importRecord(project.OwnerID, source, ref, func(tx *sqlx.Tx) (int64, error) {
+		return createProject(tx, project)
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+	return projectID, created, nil
+}
+
+// ImportTask creates the task imported from the record of the source with its
+// ref, like ImportProject. It isn't pushed on the undo stack, the refs keep
+// track of what a job created.
+func (r *ImportPostgres) ImportTask(source string, ref model.ImportRef, task model.Task) (int64, bool, error) {
+	op := "ImportTask"
+	taskID, created, err := r.importRecord(task.OwnerID, source, ref, func(tx *sqlx.Tx) (int64, error) {
+		snapshots, err := r.tasks.insertTask(tx, task)
+		if err != nil {
+			return 0, err
+		}
+		return snapshots[0].ID, nil
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+	return taskID, created, nil
+}
+
+// ImportItem creates the checklist item imported from the record of the
+// source with its ref, like ImportProject.
+func (r *ImportPostgres) ImportItem(userID int64, source string, ref model.ImportRef, item model.TaskItem) (int64, bool, error) {
+	op := "ImportItem"
+	itemID, created, err := r.importRecord(userID, source, ref, func(tx *sqlx.Tx) (int64, error) {
+		return r.items.createItem(tx, item, userID)
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+	return itemID, created, nil
+}
+
+// ImportComment creates the comment imported from the record of the source
+// with its ref, like ImportProject.
+func (r *ImportPostgres) ImportComment(source string, ref model.ImportRef, comment model.Comment) (int64, bool, error) {
+	op := "ImportComment"
+	commentID, created, err := r.importRecord(comment.AuthorID, source, ref, func(tx *sqlx.Tx) (int64, error) {
+		return createComment(tx, comment)
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+	return commentID, created, nil
+}
+
+// importRecord creates a record with create and saves its ref in the same
+// transaction, so that a record is never created twice nor without its ref.
+// When the ref is taken already, by an import that went first, the record
+// is dropped and the local id of the ref returned.
+func (r *ImportPostgres) importRecord(userID int64, source string, ref model.ImportRef,
+	create func(tx *sqlx.Tx) (int64, error)) (int64, bool, error) {
+	op := "importRecord"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	localID, err := create(tx)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+	// a record without an id can't be told apart on the next upload
+	if ref.ExternalID == "" {
+		if err = tx.Commit(); err != nil {
+			return 0, false, fmt.Errorf("%s: %w", op, err)
+		}
+		return localID, true, nil
+	}
+	saved := make([]int64, 0, 1)
+	query := `INSERT INTO import_refs (owner_id, source, kind, external_id, project_id, task_id, item_id, comment_id)
+			  VALUES ($1, $2, $3, $4, CASE WHEN $3 = 'project' THEN $5::int END, CASE WHEN $3 = 'task' THEN $5::int END,
+			      CASE WHEN $3 = 'item' THEN $5::int END, CASE WHEN $3 = 'comment' THEN $5::int END)
+			  ON CONFLICT (owner_id, source, kind, external_id) DO NOTHING
+			  RETURNING $5::int`
+	if err = tx.Select(&saved, query, userID, source, ref.Kind, ref.ExternalID, localID); err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(saved) == 0 {
+		if err = tx.Rollback(); err != nil {
+			return 0, false, fmt.Errorf("%s: %w", op, err)
+		}
+		query = `SELECT COALESCE(project_id, task_id, item_id, comment_id) FROM import_refs
+				 WHERE owner_id = $1 AND source = $2 AND kind = $3 AND external_id = $4`
+		if err = r.db.Get(&localID, query, userID, source, ref.Kind, ref.ExternalID); err != nil {
+			return 0, false, fmt.Errorf("%s: %w", op, err)
+		}
+		return localID, false, nil
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+	return localID, true, nil
+}
@@ -0,0 +1,253 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/rank"
+)
+
+// progressColumns adds the checklist counters of every selected task row.
+const progressColumns = `(SELECT count(*) FROM task_items
+		WHERE task_items.task_id = tasks.id AND task_items.done) AS items_done,
+	(SELECT count(*) FROM task_items WHERE task_items.task_id = tasks.id) AS items_total`
+
+type ItemPostgres struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewItemPostgres(db *sqlx.DB, log *slog.Logger) *ItemPostgres {
+	return &ItemPostgres{
+		db:  db,
+		log: log,
+	}
+}
+
+func taskProgress(ext sqlx.Ext, taskID int64) (model.TaskProgress, error) {
+	op := "taskProgress"
+	var progress model.TaskProgress
+	query := "SELECT count(*) FILTER (WHERE done) AS items_done, count(*) AS items_total FROM task_items WHERE task_id = $1"
+	if err := sqlx.Get(ext, &progress, query, taskID); err != nil {
+		return model.TaskProgress{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return progress, nil
+}
+
+func checkTaskOwner(ext sqlx.Ext, taskID, userID int64) error {
+	op := "checkTaskOwner"
+	var count int
+	query := "SELECT count(*) FROM tasks WHERE id = $1 AND owner_id = $2"
+	if err := sqlx.Get(ext, &count, query, taskID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoTask)
+	}
+	return nil
+}
+
+func (r *ItemPostgres) CreateItem(item model.TaskItem, userID int64) (int64, error) {
+	op := "CreateItem"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	itemID, err := r.createItem(tx, item, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return itemID, nil
+}
+
+func (r *ItemPostgres) createItem(ext sqlx.Ext, item model.TaskItem, userID int64) (int64, error) {
+	op := "createItem"
+	// locking the task row keeps concurrent items from taking the same last rank
+	query := "SELECT id FROM tasks WHERE id = $1 AND owner_id = $2 FOR UPDATE"
+	taskIDs := make([]int64, 0, 1)
+	if err := sqlx.Select(ext, &taskIDs, query, item.TaskID, userID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(taskIDs) == 0 {
+		return 0, fmt.Errorf("%s: %w", op, ErrNoTask)
+	}
+	var last string
+	query = "SELECT COALESCE(max(rank), '') FROM task_items WHERE task_id = $1"
+	if err := sqlx.Get(ext, &last, query, item.TaskID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	key, err := rank.Between(last, "")
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	var itemID int64
+	query = "INSERT INTO task_items (task_id, text, done, rank) VALUES ($1, $2, $3, $4) RETURNING id"
+	if err = sqlx.Get(ext, &itemID, query, item.TaskID, item.Text, item.Done, key); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(key) > rank.MaxLength {
+		if err = r.rebalanceItems(ext, item.TaskID); err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return itemID, nil
+}
+
+func (r *ItemPostgres) GetItems(taskID, userID int64) ([]model.TaskItem, error) {
+	op := "GetItems"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err = checkTaskOwner(tx, taskID, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	items := make([]model.TaskItem, 0)
+	query := "SELECT id, task_id, text, done, rank FROM task_items WHERE task_id = $1 ORDER BY rank"
+	if err = tx.Select(&items, query, taskID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return items, nil
+}
+
+func (r *ItemPostgres) UpdateItem(item model.TaskItem, userID int64) error {
+	op := "UpdateItem"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err = checkTaskOwner(tx, item.TaskID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	query := "UPDATE task_items SET text = $1, done = $2 WHERE id = $3 AND task_id = $4"
+	res, err := tx.Exec(query, item.Text, item.Done, item.ID, item.TaskID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoItem)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *ItemPostgres) DeleteItem(taskID, itemID, userID int64) error {
+	op := "DeleteItem"
+	query := `DELETE FROM task_items
+			  USING tasks
+			  WHERE task_items.id = $1 AND task_items.task_id = $2
+			      AND tasks.id = task_items.task_id AND tasks.owner_id = $3`
+	res, err := r.db.Exec(query, itemID, taskID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoItem)
+	}
+	return nil
+}
+
+func (r *ItemPostgres) itemRank(ext sqlx.Ext, taskID, itemID int64) (string, error) {
+	op := "itemRank"
+	ranks := make([]string, 0, 1)
+	query := "SELECT rank FROM task_items WHERE id = $1 AND task_id = $2"
+	if err := sqlx.Select(ext, &ranks, query, itemID, taskID); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if len(ranks) == 0 {
+		return "", fmt.Errorf("%s: %w", op, ErrNoItem)
+	}
+	return ranks[0], nil
+}
+
+func (r *ItemPostgres) rebalanceItems(ext sqlx.Ext, taskID int64) error {
+	op := "rebalanceItems"
+	itemIDs := make([]int64, 0)
+	query := "SELECT id FROM task_items WHERE task_id = $1 ORDER BY rank, id"
+	if err := sqlx.Select(ext, &itemIDs, query, taskID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	keys := rank.Spread(len(itemIDs))
+	query = "UPDATE task_items SET rank = $1 WHERE id = $2"
+	for i, itemID := range itemIDs {
+		if _, err := ext.Exec(query, keys[i], itemID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+// MoveItem puts the item between after and before in the checklist, the
+// same way MoveTask does for the task list.
+func (r *ItemPostgres) MoveItem(taskID, itemID, userID int64, after, before *int64) error {
+	op := "MoveItem"
+	if after != nil && *after == itemID || before != nil && *before == itemID {
+		return fmt.Errorf("%s: %w", op, ErrItemOrder)
+	}
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	// locking the task row keeps concurrent moves in one checklist apart
+	query := "SELECT id FROM tasks WHERE id = $1 AND owner_id = $2 FOR UPDATE"
+	taskIDs := make([]int64, 0, 1)
+	if err = tx.Select(&taskIDs, query, taskID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(taskIDs) == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoTask)
+	}
+	if _, err = r.itemRank(tx, taskID, itemID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	key, err := rank.Place(rankedList{
+		ext: tx,
+		key: func(id int64) (string, error) {
+			return r.itemRank(tx, taskID, id)
+		},
+		keys:   "SELECT rank AS key FROM task_items WHERE task_id = $1 AND id <> $2",
+		listID: taskID,
+		moved:  itemID,
+	}, after, before)
+	if errors.Is(err, rank.ErrOrder) {
+		return fmt.Errorf("%s: %w", op, ErrItemOrder)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	query = "UPDATE task_items SET rank = $1 WHERE id = $2"
+	if _, err = tx.Exec(query, key, itemID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(key) > rank.MaxLength {
+		if err = r.rebalanceItems(tx, taskID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"restAPI/internal/entities"
+	"restAPI/internal/model"
+	"slices"
+	"sort"
+)
+
+// undoStackSize is how many operations are kept per user; older ones are
+// dropped when a new one is pushed.
+const undoStackSize = 20
+
+func (r *TaskPostgres) snapshotTask(ext sqlx.Ext, taskID, userID int64) (entities.TaskSnapshot, error) {
+	op := "snapshotTask"
+	snapshots := make([]entities.TaskSnapshot, 0, 1)
+	query := `SELECT id, task, date, project_id, status, completed_at, sort_key, estimate, parent_id, due,
+			      priority, recurrence, hidden_until, created_at, ical_uid FROM tasks
+			  WHERE id = $1 AND owner_id = $2`
+	err := sqlx.Select(ext, &snapshots, query, taskID, userID)
+	if err != nil {
+		return entities.TaskSnapshot{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(snapshots) == 0 {
+		return entities.TaskSnapshot{}, fmt.Errorf("%s: %w", op, ErrNoTask)
+	}
+	tags := make([]string, 0)
+	query = `SELECT tag FROM tags
+		     JOIN tags_in_task
+		         ON tags_in_task.tag_id = tags.id
+             WHERE tags_in_task.task_id = $1
+             ORDER BY tag`
+	err = sqlx.Select(ext, &tags, query, taskID)
+	if err != nil {
+		return entities.TaskSnapshot{}, fmt.Errorf("%s: %w", op, err)
+	}
+	snapshots[0].Tags = tags
+	items := make([]entities.ItemSnapshot, 0)
+	query = "SELECT id, text, done, rank FROM task_items WHERE task_id = $1 ORDER BY rank"
+	err = sqlx.Select(ext, &items, query, taskID)
+	if err != nil {
+		return entities.TaskSnapshot{}, fmt.Errorf("%s: %w", op, err)
+	}
+	snapshots[0].Items = items
+	comments := make([]entities.CommentSnapshot, 0)
+	query = "SELECT id, author_id, parent_id, text, created_at, edited_at FROM comments WHERE task_id = $1 ORDER BY id"
+	err = sqlx.Select(ext, &comments, query, taskID)
+	if err != nil {
+		return entities.TaskSnapshot{}, fmt.Errorf("%s: %w", op, err)
+	}
+	snapshots[0].Comments = comments
+	// a timer still running on the task stops with the snapshot
+	entries := make([]entities.TimeSnapshot, 0)
+	query = `SELECT id, started_at, COALESCE(stopped_at, now() AT TIME ZONE 'UTC') AS stopped_at,
+			     stopped_at IS NULL AS running
+			 FROM time_entries
+			 WHERE task_id = $1
+			 ORDER BY id`
+	err = sqlx.Select(ext, &entries, query, taskID)
+	if err != nil {
+		return entities.TaskSnapshot{}, fmt.Errorf("%s: %w", op, err)
+	}
+	snapshots[0].TimeEntries = entries
+	return snapshots[0], nil
+}
+
+func sameSnapshot(a, b entities.TaskSnapshot) bool {
+	if a.ID != b.ID || a.Text != b.Text || !a.Date.Equal(b.Date) || len(a.Tags) != len(b.Tags) {
+		return false
+	}
+	if (a.ProjectID == nil) != (b.ProjectID == nil) || a.ProjectID != nil && *a.ProjectID != *b.ProjectID {
+		return false
+	}
+	if a.Status != b.Status {
+		return false
+	}
+	if (a.Estimate == nil) != (b.Estimate == nil) || a.Estimate != nil && *a.Estimate != *b.Estimate {
+		return false
+	}
+	if (a.Due == nil) != (b.Due == nil) || a.Due != nil && !a.Due.Equal(*b.Due) {
+		return false
+	}
+	if (a.Priority == nil) != (b.Priority == nil) || a.Priority != nil && *a.Priority != *b.Priority {
+		return false
+	}
+	if (a.Recurrence == nil) != (b.Recurrence == nil) || a.Recurrence != nil && *a.Recurrence != *b.Recurrence {
+		return false
+	}
+	aTags := append([]string(nil), a.Tags...)
+	bTags := append([]string(nil), b.Tags...)
+	sort.Strings(aTags)
+	sort.Strings(bTags)
+	for i := range aTags {
+		if aTags[i] != bTags[i] {
+			return false
+		}
+	}
+	// a checklist, a thread or logged time changed since would be lost by the
+	// undo as well
+	return slices.Equal(a.Items, b.Items) &&
+		slices.EqualFunc(a.Comments, b.Comments, sameComment) &&
+		slices.EqualFunc(a.TimeEntries, b.TimeEntries, sameTimeEntry)
+}
+
+func sameComment(a, b entities.CommentSnapshot) bool {
+	if a.ID != b.ID || a.AuthorID != b.AuthorID || a.Text != b.Text || !a.CreatedAt.Equal(b.CreatedAt) {
+		return false
+	}
+	if (a.ParentID == nil) != (b.ParentID == nil) || a.ParentID != nil && *a.ParentID != *b.ParentID {
+		return false
+	}
+	return (a.EditedAt == nil) == (b.EditedAt == nil) && (a.EditedAt == nil || a.EditedAt.Equal(*b.EditedAt))
+}
+
+// sameTimeEntry leaves out when a running timer was stopped for the snapshot,
+// only that it still runs matters.
+func sameTimeEntry(a, b entities.TimeSnapshot) bool {
+	if a.ID != b.ID || !a.StartedAt.Equal(b.StartedAt) || a.Running != b.Running {
+		return false
+	}
+	return a.Running || a.StoppedAt.Equal(b.StoppedAt)
+}
+
+// checkUnchanged reports ErrUndoConflict when the task no longer looks the way
+// the operation left it.
+func (r *TaskPostgres) checkUnchanged(ext sqlx.Ext, expected entities.TaskSnapshot, userID int64) error {
+	op := "checkUnchanged"
+	current, err := r.snapshotTask(ext, expected.ID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, ErrUndoConflict)
+	}
+	if !sameSnapshot(current, expected) {
+		return fmt.Errorf("%s: %w", op, ErrUndoConflict)
+	}
+	return nil
+}
+
+func (r *TaskPostgres) restoreTask(ext sqlx.Ext, snapshot entities.TaskSnapshot, userID int64) error {
+	op := "restoreTask"
+	var count int
+	query := "SELECT count(*) FROM tasks WHERE id = $1"
+	err := sqlx.Get(ext, &count, query, snapshot.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if count != 0 {
+		return fmt.Errorf("%s: %w", op, ErrUndoConflict)
+	}
+	// the project or the parent may have been deleted since, the task then
+	// comes back without it
+	query = `INSERT INTO tasks (id, task, date, owner_id, project_id, status, completed_at, sort_key, estimate,
+			     parent_id, due, priority, recurrence, hidden_until, created_at, ical_uid)
+			 VALUES ($1, $2, $3, $4, (SELECT id FROM projects WHERE id = $5 AND owner_id = $4), $6, $7, $8, $9,
+			     (SELECT id FROM tasks WHERE id = $10 AND owner_id = $4), $11, $12, $13, $14, COALESCE($15, now() AT TIME ZONE 'UTC'), $16)`
+	_, err = ext.Exec(query, snapshot.ID, snapshot.Text, snapshot.Date, userID, snapshot.ProjectID,
+		snapshot.Status, snapshot.CompletedAt, snapshot.SortKey, snapshot.Estimate, snapshot.ParentID, snapshot.Due,
+		snapshot.Priority, snapshot.Recurrence, snapshot.HiddenUntil, snapshot.CreatedAt, snapshot.ICalUID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	err = r.insertTaskTags(ext, snapshot.ID, snapshot.Tags)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	query = "INSERT INTO task_items (id, task_id, text, done, rank) VALUES ($1, $2, $3, $4, $5)"
+	for _, item := range snapshot.Items {
+		_, err = ext.Exec(query, item.ID, snapshot.ID, item.Text, item.Done, item.Rank)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	// ordered by id, so parents always go back before their replies
+	query = `INSERT INTO comments (id, task_id, author_id, parent_id, text, created_at, edited_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	for _, comment := range snapshot.Comments {
+		_, err = ext.Exec(query, comment.ID, snapshot.ID, comment.AuthorID, comment.ParentID, comment.Text,
+			comment.CreatedAt, comment.EditedAt)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	query = "INSERT INTO time_entries (id, task_id, owner_id, started_at, stopped_at) VALUES ($1, $2, $3, $4, $5)"
+	for _, entry := range snapshot.TimeEntries {
+		_, err = ext.Exec(query, entry.ID, snapshot.ID, userID, entry.StartedAt, entry.StoppedAt)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+func (r *TaskPostgres) pushOperation(ext sqlx.Ext, userID int64, kind string, payload entities.OperationPayload) error {
+	op := "pushOperation"
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	query := "INSERT INTO task_operations (owner_id, kind, payload) VALUES ($1, $2, $3)"
+	_, err = ext.Exec(query, userID, kind, raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	query = `DELETE FROM task_operations
+			 WHERE owner_id = $1 AND id NOT IN (
+			     SELECT id FROM task_operations WHERE owner_id = $1 ORDER BY id DESC LIMIT $2
+			 )`
+	_, err = ext.Exec(query, userID, undoStackSize)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *TaskPostgres) Undo(userID int64) (model.UndoResult, error) {
+	op := "Undo"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return model.UndoResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	operations := make([]entities.Operation, 0, 1)
+	query := `SELECT id, owner_id, kind, payload, created_at FROM task_operations
+			  WHERE owner_id = $1
+			  ORDER BY id DESC
+			  LIMIT 1
+			  FOR UPDATE`
+	err = tx.Select(&operations, query, userID)
+	if err != nil {
+		return model.UndoResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(operations) == 0 {
+		return model.UndoResult{}, fmt.Errorf("%s: %w", op, ErrNothingToUndo)
+	}
+	operation := operations[0]
+	var payload entities.OperationPayload
+	if err = json.Unmarshal(operation.Payload, &payload); err != nil {
+		return model.UndoResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	result := model.UndoResult{
+		Operation: operation.Kind,
+		TaskIDs:   make([]int64, 0),
+	}
+	switch operation.Kind {
+	case model.OperationCreate:
+		// subtasks go first, deleting a parent would take them along
+		for i := len(payload.After) - 1; i >= 0; i-- {
+			after := payload.After[i]
+			if err = r.checkUnchanged(tx, after, userID); err != nil {
+				return model.UndoResult{}, fmt.Errorf("%s: %w", op, err)
+			}
+			query = "DELETE FROM tasks WHERE id = $1 AND owner_id = $2"
+			if _, err = tx.Exec(query, after.ID, userID); err != nil {
+				return model.UndoResult{}, fmt.Errorf("%s: %w", op, err)
+			}
+			result.TaskIDs = append(result.TaskIDs, after.ID)
+		}
+	case model.OperationUpdate, model.OperationMove:
+		for i, after := range payload.After {
+			if err = r.checkUnchanged(tx, after, userID); err != nil {
+				return model.UndoResult{}, fmt.Errorf("%s: %w", op, err)
+			}
+			before := payload.Before[i]
+			// a replaced task may have changed in all of these
+			query = `UPDATE tasks
+					 SET task = $1, estimate = $2, date = $3,
+					     project_id = (SELECT id FROM projects WHERE id = $4 AND owner_id = $6),
+					     status = $7, completed_at = $8,
+					     parent_id = (SELECT id FROM tasks WHERE id = $9 AND owner_id = $6),
+					     due = $10, priority = $11, recurrence = $12
+					 WHERE id = $5 AND owner_id = $6`
+			_, err = tx.Exec(query, before.Text, before.Estimate, before.Date, before.ProjectID, before.ID, userID,
+				before.Status, before.CompletedAt, before.ParentID, before.Due, before.Priority, before.Recurrence)
+			if err != nil {
+				return model.UndoResult{}, fmt.Errorf("%s: %w", op, err)
+			}
+			if err = r.tagUpdate(tx, before.ID, before.Tags); err != nil {
+				return model.UndoResult{}, fmt.Errorf("%s: %w", op, err)
+			}
+			result.TaskIDs = append(result.TaskIDs, before.ID)
+		}
+	case model.OperationDelete, model.OperationDeleteAll:
+		for _, before := range payload.Before {
+			if err = r.restoreTask(tx, before, userID); err != nil {
+				return model.UndoResult{}, fmt.Errorf("%s: %w", op, err)
+			}
+			result.TaskIDs = append(result.TaskIDs, before.ID)
+		}
+	default:
+		return model.UndoResult{}, fmt.Errorf("%s: unknown operation %q", op, operation.Kind)
+	}
+	query = "DELETE FROM task_operations WHERE id = $1"
+	if _, err = tx.Exec(query, operation.ID); err != nil {
+		return model.UndoResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return model.UndoResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"restAPI/internal/entities"
+	"testing"
+	"time"
+)
+
+// storedSnapshot is the snapshot as an operation gives it back, after going
+// through its JSON payload.
+func storedSnapshot(t *testing.T, snapshot entities.TaskSnapshot) entities.TaskSnapshot {
+	raw, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+	var stored entities.TaskSnapshot
+	require.NoError(t, json.Unmarshal(raw, &stored))
+	return stored
+}
+
+func TestSameSnapshot(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	parentID := int64(1)
+	snapshot := func() entities.TaskSnapshot {
+		return entities.TaskSnapshot{
+			ID:     1,
+			Text:   "Buy milk",
+			Tags:   []string{"shop", "home"},
+			Date:   date,
+			Status: "todo",
+			Items: []entities.ItemSnapshot{
+				{ID: 1, Text: "Skimmed", Rank: "i"},
+			},
+			Comments: []entities.CommentSnapshot{
+				{ID: 1, AuthorID: 1, Text: "Which shop?", CreatedAt: date.Add(time.Hour)},
+				{ID: 2, AuthorID: 1, ParentID: &parentID, Text: "The corner one", CreatedAt: date.Add(2 * time.Hour)},
+			},
+			TimeEntries: []entities.TimeSnapshot{
+				{ID: 1, StartedAt: date.Add(time.Hour), StoppedAt: date.Add(2 * time.Hour)},
+				{ID: 2, StartedAt: date.Add(3 * time.Hour), StoppedAt: date.Add(4 * time.Hour), Running: true},
+			},
+		}
+	}
+
+	var tests = []struct {
+		name    string
+		current func(snapshot *entities.TaskSnapshot)
+		want    bool
+	}{
+		{
+			name:    "unchanged",
+			current: func(snapshot *entities.TaskSnapshot) {},
+			want:    true,
+		}, {
+			name: "tags in another order",
+			current: func(snapshot *entities.TaskSnapshot) {
+				snapshot.Tags = []string{"home", "shop"}
+			},
+			want: true,
+		}, {
+			name: "text changed",
+			current: func(snapshot *entities.TaskSnapshot) {
+				snapshot.Text = "Buy bread"
+			},
+			want: false,
+		}, {
+			name: "item added",
+			current: func(snapshot *entities.TaskSnapshot) {
+				snapshot.Items = append(snapshot.Items, entities.ItemSnapshot{ID: 2, Text: "Oat", Rank: "r"})
+			},
+			want: false,
+		}, {
+			name: "item checked",
+			current: func(snapshot *entities.TaskSnapshot) {
+				snapshot.Items[0].Done = true
+			},
+			want: false,
+		}, {
+			name: "item deleted",
+			current: func(snapshot *entities.TaskSnapshot) {
+				snapshot.Items = make([]entities.ItemSnapshot, 0)
+			},
+			want: false,
+		}, {
+			name: "comment added",
+			current: func(snapshot *entities.TaskSnapshot) {
+				snapshot.Comments = append(snapshot.Comments, entities.CommentSnapshot{
+					ID: 3, AuthorID: 1, Text: "Got it", CreatedAt: date.Add(5 * time.Hour),
+				})
+			},
+			want: false,
+		}, {
+			name: "comment edited",
+			current: func(snapshot *entities.TaskSnapshot) {
+				editedAt := date.Add(5 * time.Hour)
+				snapshot.Comments[0].Text, snapshot.Comments[0].EditedAt = "Which shop, again?", &editedAt
+			},
+			want: false,
+		}, {
+			name: "timer still running",
+			current: func(snapshot *entities.TaskSnapshot) {
+				snapshot.TimeEntries[1].StoppedAt = date.Add(6 * time.Hour)
+			},
+			want: true,
+		}, {
+			name: "timer stopped",
+			current: func(snapshot *entities.TaskSnapshot) {
+				snapshot.TimeEntries[1].StoppedAt, snapshot.TimeEntries[1].Running = date.Add(6*time.Hour), false
+			},
+			want: false,
+		}, {
+			name: "time logged",
+			current: func(snapshot *entities.TaskSnapshot) {
+				snapshot.TimeEntries = append(snapshot.TimeEntries, entities.TimeSnapshot{
+					ID: 3, StartedAt: date.Add(7 * time.Hour), StoppedAt: date.Add(8 * time.Hour),
+				})
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := snapshot()
+			tt.current(&current)
+
+			got := sameSnapshot(current, storedSnapshot(t, snapshot()))
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"restAPI/pkg/lib/rank"
+)
+
+// rankedList reads the keys of a ranked list of the database for rank.Place.
+// keys selects them as key, with $1 the list and $2 the moved row left out.
+type rankedList struct {
+	ext    sqlx.Ext
+	key    func(id int64) (string, error)
+	keys   string
+	listID int64
+	moved  int64
+}
+
+func (l rankedList) Key(id int64) (string, error) {
+	return l.key(id)
+}
+
+func (l rankedList) Last() (string, error) {
+	return l.get("SELECT COALESCE(max(key), '') FROM (" + l.keys + ") AS list")
+}
+
+func (l rankedList) Following(key string) (string, error) {
+	return l.get("SELECT COALESCE(min(key), '') FROM ("+l.keys+") AS list WHERE key > $3", key)
+}
+
+func (l rankedList) Preceding(key string) (string, error) {
+	return l.get("SELECT COALESCE(max(key), '') FROM ("+l.keys+") AS list WHERE key < $3", key)
+}
+
+func (l rankedList) get(query string, args ...any) (string, error) {
+	op := "rankedList"
+	var key string
+	if err := sqlx.Get(l.ext, &key, query, append([]any{l.listID, l.moved}, args...)...); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	return key, nil
+}
+
+// lastSortKey returns the key that puts a new task at the end of the user list.
+func (r *TaskPostgres) lastSortKey(ext sqlx.Ext, userID int64) (string, error) {
+	op := "lastSortKey"
+	var last string
+	query := "SELECT COALESCE(max(sort_key), '') FROM tasks WHERE owner_id = $1"
+	if err := sqlx.Get(ext, &last, query, userID); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	key, err := rank.Between(last, "")
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	return key, nil
+}
+
+// rebalanceSortKeys spreads the keys of all user tasks evenly, keeping their
+// order. Tasks created before manual ordering existed have no key and go last.
+func (r *TaskPostgres) rebalanceSortKeys(ext sqlx.Ext, userID int64) error {
+	op := "rebalanceSortKeys"
+	taskIDs := make([]int64, 0)
+	query := "SELECT id FROM tasks WHERE owner_id = $1 ORDER BY sort_key NULLS LAST, id"
+	if err := sqlx.Select(ext, &taskIDs, query, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	keys := rank.Spread(len(taskIDs))
+	query = "UPDATE tasks SET sort_key = $1 WHERE id = $2"
+	for i, taskID := range taskIDs {
+		if _, err := ext.Exec(query, keys[i], taskID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+func (r *TaskPostgres) sortKeyOf(ext sqlx.Ext, taskID, userID int64) (string, error) {
+	op := "sortKeyOf"
+	keys := make([]string, 0, 1)
+	query := "SELECT sort_key FROM tasks WHERE id = $1 AND owner_id = $2"
+	if err := sqlx.Select(ext, &keys, query, taskID, userID); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if len(keys) == 0 {
+		return "", fmt.Errorf("%s: %w", op, ErrNoTask)
+	}
+	return keys[0], nil
+}
+
+// MoveTask puts the task between after and before in the manual order of the
+// user list. With only one neighbour the task lands right next to it, with
+// none it goes to the end.
+func (r *TaskPostgres) MoveTask(taskID, userID int64, after, before *int64) error {
+	op := "MoveTask"
+	if after != nil && *after == taskID || before != nil && *before == taskID {
+		return fmt.Errorf("%s: %w", op, ErrTaskOrder)
+	}
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	// the whole list is locked so concurrent moves can't pick the same key
+	query := "SELECT id FROM tasks WHERE owner_id = $1 FOR UPDATE"
+	if _, err = tx.Exec(query, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	var unranked int
+	query = "SELECT count(*) FROM tasks WHERE owner_id = $1 AND sort_key IS NULL"
+	if err = tx.Get(&unranked, query, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if unranked != 0 {
+		if err = r.rebalanceSortKeys(tx, userID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if _, err = r.sortKeyOf(tx, taskID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	key, err := rank.Place(rankedList{
+		ext: tx,
+		key: func(id int64) (string, error) {
+			return r.sortKeyOf(tx, id, userID)
+		},
+		keys:   "SELECT sort_key AS key FROM tasks WHERE owner_id = $1 AND id <> $2",
+		listID: userID,
+		moved:  taskID,
+	}, after, before)
+	if errors.Is(err, rank.ErrOrder) {
+		return fmt.Errorf("%s: %w", op, ErrTaskOrder)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	query = "UPDATE tasks SET sort_key = $1 WHERE id = $2 AND owner_id = $3"
+	if _, err = tx.Exec(query, key, taskID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(key) > rank.MaxLength {
+		if err = r.rebalanceSortKeys(tx, userID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"restAPI/internal/model"
+)
+
+type ProjectPostgres struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewProjectPostgres(db *sqlx.DB, log *slog.Logger) *ProjectPostgres {
+	return &ProjectPostgres{
+		db:  db,
+		log: log,
+	}
+}
+
+func checkProjectOwner(ext sqlx.Ext, projectID, userID int64) error {
+	op := "checkProjectOwner"
+	var count int
+	query := "SELECT count(*) FROM projects WHERE id = $1 AND owner_id = $2"
+	err := sqlx.Get(ext, &count, query, projectID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoProject)
+	}
+	return nil
+}
+
+func (r *ProjectPostgres) CreateProject(project model.Project) (int64, error) {
+	op := "CreateProject"
+	projectID, err := createProject(r.db, project)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return projectID, nil
+}
+
+func createProject(ext sqlx.Ext, project model.Project) (int64, error) {
+	op := "createProject"
+	var projectID int64
+	query := `INSERT INTO projects (name, description, colour, archived, owner_id)
+			  VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	err := sqlx.Get(ext, &projectID, query, project.Name, project.Description, project.Colour, project.Archived, project.OwnerID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return projectID, nil
+}
+
+func (r *ProjectPostgres) GetProject(projectID, userID int64) (model.Project, error) {
+	op := "GetProject"
+	projects := make([]model.Project, 0, 1)
+	query := `SELECT id, name, description, colour, archived, owner_id FROM projects
+			  WHERE id = $1 AND owner_id = $2`
+	err := r.db.Select(&projects, query, projectID, userID)
+	if err != nil {
+		return model.Project{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(projects) == 0 {
+		return model.Project{}, fmt.Errorf("%s: %w", op, ErrNoProject)
+	}
+	return projects[0], nil
+}
+
+func (r *ProjectPostgres) GetAllProjects(userID int64, withArchived bool) ([]model.Project, error) {
+	op := "GetAllProjects"
+	projects := make([]model.Project, 0)
+	query := `SELECT id, name, description, colour, archived, owner_id FROM projects
+			  WHERE owner_id = $1 AND (NOT archived OR $2)
+			  ORDER BY id`
+	err := r.db.Select(&projects, query, userID, withArchived)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return projects, nil
+}
+
+func (r *ProjectPostgres) UpdateProject(project model.Project) error {
+	op := "UpdateProject"
+	query := `UPDATE projects
+			  SET name = $1, description = $2, colour = $3, archived = $4
+			  WHERE id = $5 AND owner_id = $6`
+	res, err := r.db.Exec(query, project.Name, project.Description, project.Colour, project.Archived,
+		project.ID, project.OwnerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoProject)
+	}
+	return nil
+}
+
+func (r *ProjectPostgres) DeleteProject(projectID, userID int64) error {
+	op := "DeleteProject"
+	query := "DELETE FROM projects WHERE id = $1 AND owner_id = $2"
+	res, err := r.db.Exec(query, projectID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoProject)
+	}
+	return nil
+}
+
+func (r *ProjectPostgres) SetTaskProject(taskID, userID int64, projectID *int64) error {
+	op := "SetTaskProject"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if projectID != nil {
+		if err = checkProjectOwner(tx, *projectID, userID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	query := "UPDATE tasks SET project_id = $1 WHERE id = $2 AND owner_id = $3"
+	res, err := tx.Exec(query, projectID, taskID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoTask)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -1,11 +1,13 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"log/slog"
 	"restAPI/internal/model"
+	"time"
 )
 
 var (
@@ -15,34 +17,189 @@ var (
 	ErrNoSuchUser       = errors.New("there is no such user")
 	ErrTwoSameLoginInDb = errors.New("there is two same user logins in db")
 	ErrWrongPassword    = errors.New("wrong password")
+	ErrNothingToUndo    = errors.New("nothing to undo")
+	ErrUndoConflict     = errors.New("operation conflicts with a later change")
+	ErrNoProject        = errors.New("project not found")
+	ErrNoBoard          = errors.New("board not found")
+	ErrNoColumn         = errors.New("board column not found")
+	ErrNoCard           = errors.New("task is not on the board column")
+	ErrCardOrder        = errors.New("cards are not in this order")
+	ErrTaskOrder        = errors.New("tasks are not in this order")
+	ErrNoItem           = errors.New("checklist item not found")
+	ErrItemOrder        = errors.New("checklist items are not in this order")
+	ErrNoComment        = errors.New("comment not found")
+	ErrCommentRights    = errors.New("comment belongs to another user")
+	ErrNoAttachment     = errors.New("attachment not found")
+	ErrQuotaExceeded    = errors.New("attachment quota exceeded")
+	ErrTimerRunning     = errors.New("another timer is running")
+	ErrNoTimer          = errors.New("no timer is running on the task")
+	ErrNoTimeEntry      = errors.New("time entry not found")
+	ErrNoTemplate       = errors.New("template not found")
+	ErrNoFeed           = errors.New("feed not found")
+	ErrTaskParent       = errors.New("task can't be under itself")
+	ErrNoImportJob      = errors.New("import job not found")
+	ErrImportRunning    = errors.New("another import is running")
+	ErrNoAccountExport  = errors.New("account export not found")
+	ErrExportRunning    = errors.New("another account export is running")
+	ErrExportNotRunning = errors.New("account export is not running")
 )
 
 type Task interface {
-	DeleteAllTasks() error
+	DeleteAllTasks() ([]string, error)
 	GetAllTasks() ([]model.Task, error)
+	StreamTasks(ctx context.Context, stream model.TaskStream, fn func(task model.TaskRow) error) error
 	CreateTask(task model.Task) (int64, error)
-	DeleteTask(taskID, userID int64) error
-	DeleteAllByUser(userID int64) error
+	CreateTasks(tasks []model.Task) ([]int64, error)
+	ImportTasks(userID int64, fn func(create func(task model.Task) (int64, error)) error) error
+	DeleteTask(taskID, userID int64) ([]string, error)
+	DeleteAllByUser(userID int64) ([]string, error)
 	GetTask(taskID, userID int64) (model.Task, error)
-	GetAllByUser(userID int64) ([]model.Task, error)
-	GetTasksByDate(day, month, year int, userID int64) ([]model.Task, error)
-	GetTasksByTag(tag string, userID int64) ([]model.Task, error)
-	UpdateTask(taskID, userID int64, Text string, Tags []string) error
+	GetAllByUser(userID int64, filter model.TaskFilter) ([]model.Task, error)
+	GetTasksByDate(day, month, year int, userID int64, filter model.TaskFilter) ([]model.Task, error)
+	GetTasksByTag(tag string, userID int64, filter model.TaskFilter) ([]model.Task, error)
+	GetCalendarMonth(month, year int, userID int64, filter model.TaskFilter) (model.CalendarMonth, error)
+	GetTaskIDsByUID(userID int64, uids []string) (map[string]int64, error)
+	UpdateTask(taskID, userID int64, Text string, Tags []string, Estimate *int64) error
+	ReplaceTask(task model.Task) error
+	Undo(userID int64) (model.UndoResult, error)
+	MoveTask(taskID, userID int64, after, before *int64) error
+	DuplicateTask(taskID, userID int64, options model.DuplicateOptions) (int64, error)
+	MoveTasks(userID int64, move model.TaskMove) error
+	SnoozeTask(taskID, userID int64, until *time.Time) error
+	GetAgendaTasks(userID int64, from, to time.Time) ([]model.Task, error)
 }
 
 type Authorization interface {
 	CreateUser(user model.User) (int64, error)
 	GetUser(login, password string) (model.User, error)
+	CancelDeletion(userID int64) error
+}
+
+type Project interface {
+	CreateProject(project model.Project) (int64, error)
+	GetProject(projectID, userID int64) (model.Project, error)
+	GetAllProjects(userID int64, withArchived bool) ([]model.Project, error)
+	UpdateProject(project model.Project) error
+	DeleteProject(projectID, userID int64) error
+	SetTaskProject(taskID, userID int64, projectID *int64) error
+}
+
+type Board interface {
+	CreateBoard(board model.Board) (int64, error)
+	GetBoards(userID int64) ([]model.Board, error)
+	GetBoard(boardID, userID int64) (model.Board, error)
+	DeleteBoard(boardID, userID int64) error
+	CreateColumn(column model.BoardColumn, userID int64) (int64, error)
+	MoveCard(boardID, taskID, userID int64, move model.CardMove) error
+	RemoveCard(boardID, taskID, userID int64) error
+}
+
+type Item interface {
+	CreateItem(item model.TaskItem, userID int64) (int64, error)
+	GetItems(taskID, userID int64) ([]model.TaskItem, error)
+	UpdateItem(item model.TaskItem, userID int64) error
+	DeleteItem(taskID, itemID, userID int64) error
+	MoveItem(taskID, itemID, userID int64, after, before *int64) error
+}
+
+type Comment interface {
+	CreateComment(comment model.Comment) (int64, error)
+	GetComments(taskID, userID int64, page model.Page) ([]model.Comment, int, error)
+	UpdateComment(comment model.Comment, userID int64) error
+	DeleteComment(taskID, commentID, userID int64) error
+}
+
+type Attachment interface {
+	CreateAttachment(attachment model.Attachment, quota int64) (int64, error)
+	GetAttachments(taskID, userID int64) ([]model.Attachment, error)
+	GetAttachment(taskID, attachmentID, userID int64) (model.Attachment, error)
+	DeleteAttachment(taskID, attachmentID, userID int64) (string, error)
+}
+
+type Time interface {
+	StartTimer(taskID, userID int64, now time.Time) (int64, error)
+	StopTimer(taskID, userID int64, now time.Time) (model.TimeEntry, error)
+	CreateTimeEntry(entry model.TimeEntry) (int64, error)
+	GetTimeEntries(taskID, userID int64, now time.Time) ([]model.TimeEntry, error)
+	DeleteTimeEntry(taskID, entryID, userID int64) error
+	TimeReport(userID int64, from, to time.Time, group string, now time.Time) (model.TimeReport, error)
+}
+
+type Analytics interface {
+	EstimateReport(userID int64, from, to time.Time, group string, now time.Time) (model.EstimateReport, error)
+	StatsReport(userID int64, from, to time.Time, bucket string, now time.Time) (model.Stats, error)
+	ActivityHeatmap(userID int64, from, to time.Time) ([]model.HeatmapDay, error)
+	ProjectBurndown(projectID, userID int64, from, to time.Time) ([]model.BurndownDay, error)
+}
+
+type Template interface {
+	CreateTemplate(template model.Template) (int64, error)
+	GetTemplate(templateID, userID int64) (model.Template, error)
+	GetTemplates(userID int64) ([]model.Template, error)
+	UpdateTemplate(template model.Template) error
+	DeleteTemplate(templateID, userID int64) error
+}
+
+type Feed interface {
+	SetFeedToken(userID int64, tokenHash string) error
+	DeleteFeedToken(userID int64) error
+	GetFeedUser(tokenHash string) (int64, error)
+}
+
+type Import interface {
+	CreateImportJob(job model.ImportJob) (int64, error)
+	GetImportJob(jobID, userID int64) (model.ImportJob, error)
+	SetImportProgress(jobID int64, done int) error
+	FinishImportJob(job model.ImportJob) error
+	FailRunningImportJobs(reason string) error
+	GetImportRefs(userID int64, source string) (map[model.ImportRef]int64, error)
+	ImportProject(source string, ref model.ImportRef, project model.Project) (int64, bool, error)
+	ImportTask(source string, ref model.ImportRef, task model.Task) (int64, bool, error)
+	ImportItem(userID int64, source string, ref model.ImportRef, item model.TaskItem) (int64, bool, error)
+	ImportComment(source string, ref model.ImportRef, comment model.Comment) (int64, bool, error)
+}
+
+type Account interface {
+	CreateAccountExport(userID int64) (int64, error)
+	GetAccountExport(exportID, userID int64) (model.AccountExport, error)
+	FinishAccountExport(export model.AccountExport) ([]string, error)
+	FailRunningAccountExports(reason string) error
+	GetAccountData(userID int64, now time.Time) (model.AccountData, error)
+	ScheduleDeletion(userID int64, passwordHash string, grace time.Duration) (time.Time, error)
+	PurgeUsers() (int, []string, error)
+	DeleteOrphanTags() (int64, error)
 }
 
 type Repository struct {
 	Task
 	Authorization
+	Project
+	Board
+	Item
+	Comment
+	Attachment
+	Time
+	Analytics
+	Template
+	Feed
+	Import
+	Account
 }
 
 func New(db *sqlx.DB, log *slog.Logger) *Repository {
 	return &Repository{
 		Task:          NewTaskPostgres(db, log),
 		Authorization: NewAuthPostgres(db, log),
+		Project:       NewProjectPostgres(db, log),
+		Board:         NewBoardPostgres(db, log),
+		Item:          NewItemPostgres(db, log),
+		Comment:       NewCommentPostgres(db, log),
+		Attachment:    NewAttachmentPostgres(db, log),
+		Time:          NewTimePostgres(db, log),
+		Analytics:     NewAnalyticsPostgres(db, log),
+		Template:      NewTemplatePostgres(db, log),
+		Feed:          NewFeedPostgres(db, log),
+		Import:        NewImportPostgres(db, log),
+		Account:       NewAccountPostgres(db, log),
 	}
 }
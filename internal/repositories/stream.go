@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"restAPI/internal/entities"
+	"restAPI/internal/model"
+	"strings"
+)
+
+// streamBatch is how many rows StreamTasks fetches from its cursor at a time,
+// the memory it takes doesn't grow with the number of tasks.
+const streamBatch = 500
+
+func toTaskRow(rawTask entities.TaskRow) model.TaskRow {
+	tags := []string(rawTask.Tags)
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+	return model.TaskRow{
+		ID:          rawTask.ID,
+		OwnerID:     rawTask.OwnerID,
+		Text:        rawTask.Task,
+		Tags:        tags,
+		Date:        rawTask.Date,
+		ProjectID:   rawTask.ProjectID,
+		Status:      rawTask.Status,
+		CompletedAt: rawTask.CompletedAt,
+		Estimate:    rawTask.Estimate,
+		ParentID:    rawTask.ParentID,
+		Due:         rawTask.Due,
+		Priority:    rawTask.Priority,
+		Recurrence:  rawTask.Recurrence,
+		HiddenUntil: rawTask.HiddenUntil,
+		CreatedAt:   rawTask.CreatedAt,
+	}
+}
+
+// StreamTasks calls fn with the tasks of the stream in the order of their ids,
+// read through a cursor of the server in batches of streamBatch. The tasks
+// come from one snapshot of the database. An error of fn stops the stream and
+// is returned.
+func (r *TaskPostgres) StreamTasks(ctx context.Context, stream model.TaskStream, fn func(task model.TaskRow) error) error {
+	op := "StreamTasks"
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	where := []string{"id > $1"}
+	args := []interface{}{stream.After}
+	if stream.UserID != nil {
+		args = append(args, *stream.UserID)
+		where = append(where, fmt.Sprintf("owner_id = $%d", len(args)))
+	}
+	if stream.From != nil {
+		args = append(args, *stream.From)
+		where = append(where, fmt.Sprintf("date >= $%d", len(args)))
+	}
+	if stream.To != nil {
+		args = append(args, *stream.To)
+		where = append(where, fmt.Sprintf("date < $%d", len(args)))
+	}
+	query := `DECLARE task_stream NO SCROLL CURSOR FOR
+			  SELECT id, owner_id, task, ARRAY(
+			          SELECT tag FROM tags
+			          JOIN tags_in_task
+			              ON tags.id = tags_in_task.tag_id
+			          WHERE tags_in_task.task_id = tasks.id
+			          ORDER BY tag
+			      ) AS tags,
+			      date, project_id, status, completed_at, estimate, parent_id, due, priority, recurrence,
+			      hidden_until, created_at
+			  FROM tasks
+			  WHERE ` + strings.Join(where, " AND ") + `
+			  ORDER BY id`
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	fetch := fmt.Sprintf("FETCH %d FROM task_stream", streamBatch)
+	rawTasks := make([]entities.TaskRow, 0, streamBatch)
+	for {
+		rawTasks = rawTasks[:0]
+		if err = tx.SelectContext(ctx, &rawTasks, fetch); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		for _, rawTask := range rawTasks {
+			if err = fn(toTaskRow(rawTask)); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		if len(rawTasks) < streamBatch {
+			break
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
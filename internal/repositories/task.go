@@ -7,6 +7,10 @@ import (
 	"log/slog"
 	"restAPI/internal/entities"
 	"restAPI/internal/model"
+	"restAPI/pkg/lib/rank"
+	"slices"
+	"strings"
+	"time"
 )
 
 type TaskPostgres struct {
@@ -21,73 +25,220 @@ func NewTaskPostgres(db *sqlx.DB, log *slog.Logger) *TaskPostgres {
 	}
 }
 
-func (r *TaskPostgres) getOrCreateTagID(tag string) (int64, error) {
+func (r *TaskPostgres) getOrCreateTagID(ext sqlx.Ext, tag string) (int64, error) {
 	op := "getTagID"
 	tagID := make([]int64, 0, 1)
 	query := "SELECT id FROM tags WHERE tag = $1"
-	err := r.db.Select(&tagID, query, tag)
+	err := sqlx.Select(ext, &tagID, query, tag)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 	if len(tagID) == 1 {
 		return tagID[0], nil
 	}
-	id, err := r.insertTag(tag)
+	id, err := r.insertTag(ext, tag)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 	return id, nil
 }
 
-func (r *TaskPostgres) insertTag(tag string) (int64, error) {
+func (r *TaskPostgres) insertTag(ext sqlx.Ext, tag string) (int64, error) {
 	op := "insertTag"
 	var tagID int64
 	query := "INSERT INTO tags (tag) VALUES ($1) RETURNING id"
-	err := r.db.Get(&tagID, query, tag)
+	err := sqlx.Get(ext, &tagID, query, tag)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 	return tagID, nil
 }
 
-func (r *TaskPostgres) insertInTagInTask(taskID, tagID int64) error {
+func (r *TaskPostgres) insertInTagInTask(ext sqlx.Ext, taskID, tagID int64) error {
 	op := "insertInTagInTask"
 	query := "INSERT INTO tags_in_task (tag_id, task_id) VALUES ($1, $2)"
-	_, err := r.db.Exec(query, tagID, taskID)
+	_, err := ext.Exec(query, tagID, taskID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	return nil
 }
 
+func (r *TaskPostgres) insertTaskTags(ext sqlx.Ext, taskID int64, tags []string) error {
+	op := "insertTaskTags"
+	for _, tag := range tags {
+		tagID, err := r.getOrCreateTagID(ext, tag)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		err = r.insertInTagInTask(ext, taskID, tagID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+// insertTask adds the task with all its subtasks and returns their
+// snapshots, every parent before its subtasks. Subtasks take the date and the
+// project of their parent unless they have their own.
+func (r *TaskPostgres) insertTask(ext sqlx.Ext, task model.Task) ([]entities.TaskSnapshot, error) {
+	op := "insertTask"
+	if task.ProjectID != nil {
+		if err := checkProjectOwner(ext, *task.ProjectID, task.OwnerID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if task.ParentID != nil {
+		if err := checkTaskOwner(ext, *task.ParentID, task.OwnerID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	status := task.Status
+	if status == "" {
+		status = model.TaskStatusTodo
+	}
+	sortKey, err := r.lastSortKey(ext, task.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	// tasks brought from elsewhere keep the time they were completed at
+	var completedAt *time.Time
+	if task.CompletedAt != nil {
+		utc := task.CompletedAt.UTC()
+		completedAt = &utc
+	}
+	var taskID int64
+	query := `INSERT INTO tasks (task, date, owner_id, project_id, status, completed_at, sort_key, estimate,
+			      parent_id, due, priority, recurrence, hidden_until, ical_uid)
+			  VALUES ($1, $2, $3, $4, $5, CASE WHEN $5 = 'done' THEN COALESCE($13, now() AT TIME ZONE 'UTC') END, $6, $7,
+			      $8, $9, $10, $11, $12, $14)
+			  RETURNING id`
+	err = sqlx.Get(ext, &taskID, query, task.Text, task.Date, task.OwnerID, task.ProjectID, status, sortKey,
+		task.Estimate, task.ParentID, task.Due, task.Priority, task.Recurrence, task.HiddenUntil, completedAt, task.ICalUID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(sortKey) > rank.MaxLength {
+		if err = r.rebalanceSortKeys(ext, task.OwnerID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	err = r.insertTaskTags(ext, taskID, task.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	snapshot, err := r.snapshotTask(ext, taskID, task.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	snapshots := []entities.TaskSnapshot{snapshot}
+	for _, subtask := range task.Subtasks {
+		subtask.OwnerID = task.OwnerID
+		subtask.ParentID = &taskID
+		if subtask.Date.IsZero() {
+			subtask.Date = task.Date
+		}
+		if subtask.ProjectID == nil {
+			subtask.ProjectID = task.ProjectID
+		}
+		subSnapshots, err := r.insertTask(ext, subtask)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		snapshots = append(snapshots, subSnapshots...)
+	}
+	return snapshots, nil
+}
+
 func (r *TaskPostgres) CreateTask(task model.Task) (int64, error) {
 	op := "CreateTask"
-	tx, err := r.db.Begin()
+	taskIDs, err := r.CreateTasks([]model.Task{task})
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
+	return taskIDs[0], nil
+}
+
+// CreateTasks adds the tasks with their subtasks at once, a single undo takes
+// them all back. It returns the ids of the top level tasks.
+func (r *TaskPostgres) CreateTasks(tasks []model.Task) ([]int64, error) {
+	op := "CreateTasks"
+	if len(tasks) == 0 {
+		return []int64{}, nil
+	}
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	defer tx.Rollback()
-	var taskID int64
-	query := "INSERT INTO tasks (task, date, owner_id) VALUES ($1, $2, $3) RETURNING id"
-	err = r.db.Get(&taskID, query, task.Text, task.Date, task.OwnerID)
+	taskIDs, err := r.createTasks(tx, tasks)
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	for _, tag := range task.Tags {
-		tagID, err := r.getOrCreateTagID(tag)
+	return taskIDs, nil
+}
+
+func (r *TaskPostgres) createTasks(ext sqlx.Ext, tasks []model.Task) ([]int64, error) {
+	op := "createTasks"
+	taskIDs := make([]int64, 0, len(tasks))
+	after := make([]entities.TaskSnapshot, 0, len(tasks))
+	for _, task := range tasks {
+		snapshots, err := r.insertTask(ext, task)
 		if err != nil {
-			return 0, fmt.Errorf("%s: %w", op, err)
+			return nil, fmt.Errorf("%s: %w", op, err)
 		}
-		err = r.insertInTagInTask(taskID, tagID)
+		taskIDs = append(taskIDs, snapshots[0].ID)
+		after = append(after, snapshots...)
+	}
+	err := r.pushOperation(ext, tasks[0].OwnerID, model.OperationCreate, entities.OperationPayload{
+		After: after,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return taskIDs, nil
+}
+
+// ImportTasks creates the tasks of an import in one transaction: fn calls
+// create for each of them, parents before their subtasks. An error of fn takes
+// back all of them, otherwise a single undo does. A task whose project or
+// parent isn't found fails alone, create can be called again.
+func (r *TaskPostgres) ImportTasks(userID int64, fn func(create func(task model.Task) (int64, error)) error) error {
+	op := "ImportTasks"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	after := make([]entities.TaskSnapshot, 0)
+	create := func(task model.Task) (int64, error) {
+		task.OwnerID = userID
+		snapshots, err := r.insertTask(tx, task)
 		if err != nil {
-			return 0, fmt.Errorf("%s: %w", op, err)
+			return 0, err
 		}
-
+		after = append(after, snapshots...)
+		return snapshots[0].ID, nil
 	}
-	if tx.Commit() != nil {
-		return 0, fmt.Errorf("%s: %w", op, err)
+	if err = fn(create); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
-	return taskID, nil
+	if len(after) != 0 {
+		err = r.pushOperation(tx, userID, model.OperationCreate, entities.OperationPayload{
+			After: after,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
 }
 
 func (r *TaskPostgres) GetTask(taskID, userID int64) (model.Task, error) {
@@ -97,7 +248,9 @@ func (r *TaskPostgres) GetTask(taskID, userID int64) (model.Task, error) {
 		return model.Task{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer tx.Rollback()
-	query := "SELECT id, task, date, owner_id FROM tasks WHERE id = $1 AND owner_id = $2"
+	query := `SELECT id, task, date, owner_id, project_id, status, completed_at, estimate, parent_id, due,
+			      priority, recurrence, hidden_until FROM tasks
+			  WHERE id = $1 AND owner_id = $2`
 	task := make([]model.Task, 0, 1)
 	err = r.db.Select(&task, query, taskID, userID)
 	if err != nil {
@@ -113,256 +266,378 @@ func (r *TaskPostgres) GetTask(taskID, userID int64) (model.Task, error) {
              WHERE tags_in_task.task_id = $1`
 	err = r.db.Select(&tags, query, taskID)
 	task[0].Tags = tags
+	task[0].Progress, err = taskProgress(r.db, taskID)
+	if err != nil {
+		return model.Task{}, fmt.Errorf("%s: %w", op, err)
+	}
+	task[0].Comments, err = commentCount(r.db, taskID)
+	if err != nil {
+		return model.Task{}, fmt.Errorf("%s: %w", op, err)
+	}
 	if tx.Commit() != nil {
 		return model.Task{}, fmt.Errorf("%s: %w", op, err)
 	}
 	return task[0], nil
 }
 
-func (r *TaskPostgres) DeleteTask(taskID, userID int64) error {
+// subtaskIDs returns all the subtasks under the task, at any depth, ordered by
+// id so parents come before their subtasks.
+func (r *TaskPostgres) subtaskIDs(ext sqlx.Ext, taskID int64) ([]int64, error) {
+	op := "subtaskIDs"
+	taskIDs := make([]int64, 0)
+	query := `WITH RECURSIVE subtree AS (
+			      SELECT id FROM tasks WHERE parent_id = $1
+			      UNION ALL
+			      SELECT tasks.id FROM tasks
+			      JOIN subtree
+			          ON tasks.parent_id = subtree.id
+			  )
+			  SELECT id FROM subtree ORDER BY id`
+	if err := sqlx.Select(ext, &taskIDs, query, taskID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return taskIDs, nil
+}
+
+// DeleteTask removes the task together with its subtasks. It returns the keys
+// of the blobs of their attachments, which the database can't delete.
+func (r *TaskPostgres) DeleteTask(taskID, userID int64) ([]string, error) {
 	op := "DeleteTask"
-	tx, err := r.db.Begin()
+	tx, err := r.db.Beginx()
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer tx.Rollback()
+	subtaskIDs, err := r.subtaskIDs(tx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	taskIDs := append([]int64{taskID}, subtaskIDs...)
+	if err = lockTasks(tx, taskIDs, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	before := make([]entities.TaskSnapshot, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		snapshot, err := r.snapshotTask(tx, id, userID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		before = append(before, snapshot)
+	}
+	keys, err := deleteAttachments(tx, taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	query := "DELETE FROM tasks WHERE id = $1 AND owner_id = $2"
-	res, err := r.db.Exec(query, taskID, userID)
+	res, err := tx.Exec(query, taskID, userID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	rowsAffected, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("%s: %w", op, ErrNoTask)
+		return nil, fmt.Errorf("%s: %w", op, ErrNoTask)
 	}
-	if tx.Commit() != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	err = r.pushOperation(tx, userID, model.OperationDelete, entities.OperationPayload{
+		Before: before,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	return nil
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return keys, nil
 }
 
-func (r *TaskPostgres) DeleteAllByUser(userID int64) error {
+// DeleteAllByUser removes all the tasks of the user and returns the keys of
+// the blobs of their attachments.
+func (r *TaskPostgres) DeleteAllByUser(userID int64) ([]string, error) {
 	op := "DeleteAllByUser"
-	tx, err := r.db.Begin()
+	tx, err := r.db.Beginx()
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer tx.Rollback()
-	query := "SELECT id FROM tasks WHERE owner_id = $1"
+	query := "SELECT id FROM tasks WHERE owner_id = $1 ORDER BY id FOR UPDATE"
 	tasks := make([]int64, 0)
-	err = r.db.Select(&tasks, query, userID)
+	err = tx.Select(&tasks, query, userID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	if len(tasks) == 0 {
-		if tx.Commit() != nil {
-			return fmt.Errorf("%s: %w", op, err)
-		}
-		return nil
+		return make([]string, 0), nil
 	}
+	before := make([]entities.TaskSnapshot, 0, len(tasks))
 	for _, taskID := range tasks {
-		err = r.DeleteTask(taskID, userID)
+		snapshot, err := r.snapshotTask(tx, taskID, userID)
 		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
+			return nil, fmt.Errorf("%s: %w", op, err)
 		}
+		before = append(before, snapshot)
 	}
-	if tx.Commit() != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	keys, err := deleteAttachments(tx, tasks)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	return nil
+	query = "DELETE FROM tasks WHERE owner_id = $1"
+	_, err = tx.Exec(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	err = r.pushOperation(tx, userID, model.OperationDeleteAll, entities.OperationPayload{
+		Before: before,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return keys, nil
 }
 
-func (r *TaskPostgres) DeleteAllTasks() error {
+// DeleteAllTasks removes the tasks of all the users and returns the keys of
+// the blobs of their attachments.
+func (r *TaskPostgres) DeleteAllTasks() ([]string, error) {
 	op := "DeleteAllTasks"
-	tx, err := r.db.Begin()
+	tx, err := r.db.Beginx()
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer tx.Rollback()
+	// uploads wait for the deletion, their rows would go with the tasks
+	// without their keys being returned
+	if _, err = tx.Exec("LOCK TABLE attachments IN EXCLUSIVE MODE"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	keys := make([]string, 0)
+	if err = tx.Select(&keys, "DELETE FROM attachments RETURNING blob_key"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	query := "DELETE FROM tasks"
-	res, err := r.db.Exec(query)
+	res, err := tx.Exec(query)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("%s: %w", op, ErrEmptyTable)
+		return nil, fmt.Errorf("%s: %w", op, ErrEmptyTable)
 	}
 	query = "TRUNCATE TABLE tags_in_task"
-	res, err = r.db.Exec(query)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	if _, err = tx.Exec(query); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	query = "TRUNCATE TABLE tags"
-	res, err = r.db.Exec(query)
+	if _, err = tx.Exec(query); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return keys, nil
+}
+
+// lockTasks locks the tasks of the user, an attachment can't be added to them
+// until the transaction ends.
+func lockTasks(ext sqlx.Ext, taskIDs []int64, userID int64) error {
+	op := "lockTasks"
+	query, args, err := sqlx.In("SELECT id FROM tasks WHERE id IN (?) AND owner_id = ? ORDER BY id FOR UPDATE",
+		taskIDs, userID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	if tx.Commit() != nil {
+	locked := make([]int64, 0, len(taskIDs))
+	if err = sqlx.Select(ext, &locked, ext.Rebind(query), args...); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	return nil
 }
 
+// deleteAttachments deletes the attachments of the locked tasks ahead of them
+// and returns the keys of their blobs.
+func deleteAttachments(ext sqlx.Ext, taskIDs []int64) ([]string, error) {
+	op := "deleteAttachments"
+	query, args, err := sqlx.In("DELETE FROM attachments WHERE task_id IN (?) RETURNING blob_key", taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	keys := make([]string, 0)
+	if err = sqlx.Select(ext, &keys, ext.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return keys, nil
+}
+
 func (r *TaskPostgres) uniteTasks(rawTasks []entities.TaskWithTag) []model.Task {
-	taskMap := make(map[int64]*model.Task)
+	tasks := make([]model.Task, 0)
+	taskIndex := make(map[int64]int)
 	for _, rawTask := range rawTasks {
-		_, ok := taskMap[rawTask.ID]
+		i, ok := taskIndex[rawTask.ID]
 		if !ok {
-			taskMap[rawTask.ID] = &model.Task{
-				ID:      rawTask.ID,
-				Text:    rawTask.Task,
-				Date:    rawTask.Date,
-				OwnerID: rawTask.OwnerID,
-			}
+			i = len(tasks)
+			taskIndex[rawTask.ID] = i
+			tasks = append(tasks, model.Task{
+				ID:          rawTask.ID,
+				Text:        rawTask.Task,
+				Date:        rawTask.Date,
+				OwnerID:     rawTask.OwnerID,
+				ProjectID:   rawTask.ProjectID,
+				Status:      rawTask.Status,
+				CompletedAt: rawTask.CompletedAt,
+				Estimate:    rawTask.Estimate,
+				ParentID:    rawTask.ParentID,
+				Due:         rawTask.Due,
+				Priority:    rawTask.Priority,
+				Recurrence:  rawTask.Recurrence,
+				HiddenUntil: rawTask.HiddenUntil,
+				Progress: model.TaskProgress{
+					Done:  rawTask.ItemsDone,
+					Total: rawTask.ItemsTotal,
+				},
+				Comments: rawTask.Comments,
+			})
 		}
 		if rawTask.Tag == nil {
 			continue
 		}
-		taskMap[rawTask.ID].Tags = append(taskMap[rawTask.ID].Tags, *rawTask.Tag)
-	}
-	tasks := make([]model.Task, 0, len(taskMap))
-	for _, task := range taskMap {
-		tasks = append(tasks, *task)
+		tasks[i].Tags = append(tasks[i].Tags, *rawTask.Tag)
 	}
 	return tasks
+}
+
+// notDeferred leaves out the tasks snoozed until a later time.
+const notDeferred = "(tasks.hidden_until IS NULL OR tasks.hidden_until <= now() AT TIME ZONE 'UTC')"
 
+// filterClause appends the conditions of the list filter to where, numbering
+// the placeholders after the ones already in args.
+func filterClause(where string, filter model.TaskFilter, args []interface{}) (string, []interface{}) {
+	conditions := make([]string, 0, 2)
+	if where != "" {
+		conditions = append(conditions, where)
+	}
+	if filter.ProjectID != nil {
+		args = append(args, *filter.ProjectID)
+		conditions = append(conditions, fmt.Sprintf("tasks.project_id = $%d", len(args)))
+	}
+	if !filter.IncludeDeferred {
+		conditions = append(conditions, notDeferred)
+	}
+	return strings.Join(conditions, " AND "), args
 }
 
-func (r *TaskPostgres) GetAllTasks() ([]model.Task, error) {
-	op := "GetAllTasks"
-	tx, err := r.db.Begin()
+// selectTasks loads tasks with their tags that match where and the filter.
+func (r *TaskPostgres) selectTasks(ext sqlx.Ext, where string, filter model.TaskFilter, args ...interface{}) ([]model.Task, error) {
+	op := "selectTasks"
+	where, args = filterClause(where, filter, args)
+	rawTasks := make([]entities.TaskWithTag, 0)
+	query := `SELECT tasks.id, task, date, tags.tag AS tag, owner_id, project_id, status, completed_at, estimate, parent_id, due,
+			      priority, recurrence, hidden_until, ` +
+		progressColumns + ", " + commentColumns + ` FROM tasks
+              LEFT OUTER JOIN tags_in_task
+                  ON tasks.id = tags_in_task.task_id
+    		  LEFT OUTER JOIN tags
+    		      ON tags.id = tags_in_task.tag_id`
+	if where != "" {
+		query += " WHERE " + where
+	}
+	if filter.Sort == model.TaskSortManual {
+		query += " ORDER BY tasks.sort_key NULLS LAST, tasks.id"
+	} else {
+		query += " ORDER BY tasks.id"
+	}
+	err := sqlx.Select(ext, &rawTasks, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	defer tx.Rollback()
-	rawTasks := make([]entities.TaskWithTag, 0)
-	query := `SELECT tasks.id, task, date, tags.tag AS tag, owner_id FROM tasks 
-              LEFT OUTER JOIN tags_in_task 
-                  ON tasks.id = tags_in_task.task_id 
-    		  LEFT OUTER JOIN tags  
-    		      ON tags.id = tags_in_task.tag_id`
-	err = r.db.Select(&rawTasks, query)
-	r.log.Debug("rawTasks", slog.Any("string", rawTasks))
+	return r.uniteTasks(rawTasks), nil
+}
+
+func (r *TaskPostgres) GetAllTasks() ([]model.Task, error) {
+	op := "GetAllTasks"
+	tasks, err := r.selectTasks(r.db, "", model.TaskFilter{IncludeDeferred: true})
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	if len(rawTasks) == 0 {
+	r.log.Debug("tasks", slog.Int("count", len(tasks)))
+	if len(tasks) == 0 {
 		return nil, fmt.Errorf("%s: %w", op, ErrEmptyTable)
 	}
-	tasks := r.uniteTasks(rawTasks)
-	if tx.Commit() != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
 	return tasks, nil
 }
 
-func (r *TaskPostgres) GetAllByUser(userID int64) ([]model.Task, error) {
+func (r *TaskPostgres) GetAllByUser(userID int64, filter model.TaskFilter) ([]model.Task, error) {
 	op := "GetAllByUser"
-	tx, err := r.db.Begin()
+	tasks, err := r.selectTasks(r.db, "owner_id = $1", filter, userID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	defer tx.Rollback()
-	rawTasks := make([]entities.TaskWithTag, 0)
-	query := `SELECT tasks.id, task, date, tags.tag AS tag, owner_id FROM tasks
-			  LEFT OUTER JOIN tags_in_task
- 				ON tasks.id = tags_in_task.task_id
-			  LEFT OUTER JOIN tags
-			  	ON tags.id = tags_in_task.tag_id
-			  WHERE owner_id = $1`
-	err = r.db.Select(&rawTasks, query, userID)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
-	if len(rawTasks) == 0 {
-		if tx.Commit() != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
-		return []model.Task{}, nil
-	}
-	tasks := r.uniteTasks(rawTasks)
-	if tx.Commit() != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
 	return tasks, nil
 }
 
-func (r *TaskPostgres) GetTasksByDate(day, month, year int, userID int64) ([]model.Task, error) {
+func (r *TaskPostgres) GetTasksByDate(day, month, year int, userID int64, filter model.TaskFilter) ([]model.Task, error) {
 	op := "GetTasksByDate"
-	tx, err := r.db.Begin()
+	where := `EXTRACT(YEAR FROM date) = $1 AND EXTRACT(MONTH FROM date) = $2 AND EXTRACT(DAY FROM date) = $3
+			  AND owner_id = $4`
+	tasks, err := r.selectTasks(r.db, where, filter, year, month, day, userID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	defer tx.Rollback()
-	rawTasks := make([]entities.TaskWithTag, 0)
-	query := `SELECT tasks.id, task, date, tags.tag, owner_id AS tag FROM tasks 
-              LEFT OUTER JOIN tags_in_task 
-                  ON tasks.id = tags_in_task.task_id 
-    		  LEFT OUTER JOIN tags  
-    		      ON tags.id = tags_in_task.tag_id
-    		  WHERE EXTRACT(YEAR FROM date) = $1 AND EXTRACT(MONTH FROM date) = $2 AND EXTRACT(DAY FROM date) = $3 AND owner_id = $4`
-	err = r.db.Select(&rawTasks, query, year, month, day, userID)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
-	if len(rawTasks) == 0 {
-		if tx.Commit() != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
-		return []model.Task{}, nil
-	}
-	tasks := r.uniteTasks(rawTasks)
-	if tx.Commit() != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
 	return tasks, nil
 }
 
-func (r *TaskPostgres) GetTasksByTag(tag string, userID int64) ([]model.Task, error) {
+func (r *TaskPostgres) GetTasksByTag(tag string, userID int64, filter model.TaskFilter) ([]model.Task, error) {
 	op := "GetTasksByTag"
-	tx, err := r.db.Begin()
+	tasks, err := r.selectTasks(r.db, "tag = $1 AND owner_id = $2", filter, tag, userID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	defer tx.Rollback()
-	rawTasks := make([]entities.TaskWithTag, 0)
-	query := `SELECT tasks.id, task, date, tags.tag, owner_id AS tag FROM tasks 
-              LEFT OUTER JOIN tags_in_task 
-                  ON tasks.id = tags_in_task.task_id 
-    		  LEFT OUTER JOIN tags  
-    		      ON tags.id = tags_in_task.tag_id
-			  WHERE tag = $1 AND owner_id = $2`
-	err = r.db.Select(&rawTasks, query, tag, userID)
+	return tasks, nil
+}
+
+// GetTaskIDsByUID finds the tasks of the user imported from the calendar
+// entries with these UIDs.
+func (r *TaskPostgres) GetTaskIDsByUID(userID int64, uids []string) (map[string]int64, error) {
+	op := "GetTaskIDsByUID"
+	taskIDs := make(map[string]int64, len(uids))
+	if len(uids) == 0 {
+		return taskIDs, nil
+	}
+	query, args, err := sqlx.In("SELECT id, ical_uid FROM tasks WHERE owner_id = ? AND ical_uid IN (?)", userID, uids)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	if len(rawTasks) == 0 {
-		if tx.Commit() != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
-		return []model.Task{}, nil
-	}
-	tasks := r.uniteTasks(rawTasks)
-	if tx.Commit() != nil {
+	rows := make([]struct {
+		ID  int64  `db:"id"`
+		UID string `db:"ical_uid"`
+	}, 0, len(uids))
+	if err = r.db.Select(&rows, r.db.Rebind(query), args...); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	return tasks, nil
-
+	for _, row := range rows {
+		taskIDs[row.UID] = row.ID
+	}
+	return taskIDs, nil
 }
 
-func (r *TaskPostgres) deleteFromTagsInTask(taskID int64, tagsToDelete []string) error {
-	op := "tagUpdate"
-	query := fmt.Sprintf("DELETE FROM tags_in_task WHERE task_id = %d AND tag_id IN (?)", taskID)
-	query, args, err := sqlx.In(query, tagsToDelete)
+func (r *TaskPostgres) deleteFromTagsInTask(ext sqlx.Ext, taskID int64, tagsToDelete []string) error {
+	op := "deleteFromTagsInTask"
+	if len(tagsToDelete) == 0 {
+		return nil
+	}
+	query := `DELETE FROM tags_in_task
+			  WHERE task_id = ? AND tag_id IN (SELECT id FROM tags WHERE tag IN (?))`
+	query, args, err := sqlx.In(query, taskID, tagsToDelete)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	query = r.db.Rebind(query)
-	res, err := r.db.Exec(query, args...)
+	query = ext.Rebind(query)
+	res, err := ext.Exec(query, args...)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -376,21 +651,21 @@ func (r *TaskPostgres) deleteFromTagsInTask(taskID int64, tagsToDelete []string)
 	return nil
 }
 
-func (r *TaskPostgres) tagUpdate(taskID int64, tags []string) error {
+func (r *TaskPostgres) tagUpdate(ext sqlx.Ext, taskID int64, tags []string) error {
 	op := "tagUpdate"
 	newTagsMap := make(map[string]struct{})
 	for _, tag := range tags {
 		newTagsMap[tag] = struct{}{}
 	}
 	oldTags := make([]string, 0)
-	query := `SELECT tags.tag, tags.id FROM tags_in_task
+	query := `SELECT tags.tag FROM tags_in_task
 			 LEFT JOIN tags on tags_in_task.tag_id = tags.id
 			 WHERE tags_in_task.task_id = $1`
-	err := r.db.Select(&oldTags, query, taskID)
+	err := sqlx.Select(ext, &oldTags, query, taskID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	tagsToDelete := make([]string, len(oldTags))
+	tagsToDelete := make([]string, 0, len(oldTags))
 	for _, tag := range oldTags {
 		if _, ok := newTagsMap[tag]; ok {
 			delete(newTagsMap, tag)
@@ -398,15 +673,15 @@ func (r *TaskPostgres) tagUpdate(taskID int64, tags []string) error {
 			tagsToDelete = append(tagsToDelete, tag)
 		}
 	}
-	if err = r.deleteFromTagsInTask(taskID, tagsToDelete); err != nil {
+	if err = r.deleteFromTagsInTask(ext, taskID, tagsToDelete); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	for tag := range newTagsMap {
-		tagID, err := r.getOrCreateTagID(tag)
+		tagID, err := r.getOrCreateTagID(ext, tag)
 		if err != nil {
 			return fmt.Errorf("%s: %w", op, err)
 		}
-		err = r.insertInTagInTask(taskID, tagID)
+		err = r.insertInTagInTask(ext, taskID, tagID)
 		if err != nil {
 			return fmt.Errorf("%s: %w", op, err)
 		}
@@ -414,17 +689,21 @@ func (r *TaskPostgres) tagUpdate(taskID int64, tags []string) error {
 	return nil
 }
 
-func (r *TaskPostgres) UpdateTask(taskID, userID int64, text string, tags []string) error {
+func (r *TaskPostgres) UpdateTask(taskID, userID int64, text string, tags []string, estimate *int64) error {
 	op := "Update"
-	tx, err := r.db.Begin()
+	tx, err := r.db.Beginx()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	defer tx.Rollback()
+	before, err := r.snapshotTask(tx, taskID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 	query := `UPDATE tasks
-			  SET task = $1
-			  WHERE id = $2 AND owner_id = $3`
-	res, err := r.db.Exec(query, text, taskID, userID)
+			  SET task = $1, estimate = $2
+			  WHERE id = $3 AND owner_id = $4`
+	res, err := tx.Exec(query, text, estimate, taskID, userID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -435,12 +714,131 @@ func (r *TaskPostgres) UpdateTask(taskID, userID int64, text string, tags []stri
 	if rowsAffected == 0 {
 		return fmt.Errorf("%s: %w", op, ErrNoTask)
 	}
-	err = r.tagUpdate(taskID, tags)
+	err = r.tagUpdate(tx, taskID, tags)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	if tx.Commit() != nil {
+	after, err := r.snapshotTask(tx, taskID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	err = r.pushOperation(tx, userID, model.OperationUpdate, entities.OperationPayload{
+		Before: []entities.TaskSnapshot{before},
+		After:  []entities.TaskSnapshot{after},
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	return nil
 }
+
+// ReplaceTask overwrites the task with all the fields of task but its
+// snooze and its subtasks. A task done already keeps the time it was
+// completed at unless task gives one.
+func (r *TaskPostgres) ReplaceTask(task model.Task) error {
+	op := "ReplaceTask"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	before, err := r.snapshotTask(tx, task.ID, task.OwnerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if task.ProjectID != nil {
+		if err = checkProjectOwner(tx, *task.ProjectID, task.OwnerID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if task.ParentID != nil {
+		if err = checkTaskOwner(tx, *task.ParentID, task.OwnerID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		subtaskIDs, err := r.subtaskIDs(tx, task.ID)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if *task.ParentID == task.ID || slices.Contains(subtaskIDs, *task.ParentID) {
+			return fmt.Errorf("%s: %w", op, ErrTaskParent)
+		}
+	}
+	status := task.Status
+	if status == "" {
+		status = model.TaskStatusTodo
+	}
+	var completedAt *time.Time
+	if task.CompletedAt != nil {
+		utc := task.CompletedAt.UTC()
+		completedAt = &utc
+	}
+	query := `UPDATE tasks
+			  SET task = $1, date = $2, project_id = $3, status = $4,
+			      completed_at = CASE WHEN $4 = 'done' THEN COALESCE($5, completed_at, now() AT TIME ZONE 'UTC') END,
+			      estimate = $6, parent_id = $7, due = $8, priority = $9, recurrence = $10
+			  WHERE id = $11 AND owner_id = $12`
+	_, err = tx.Exec(query, task.Text, task.Date, task.ProjectID, status, completedAt, task.Estimate,
+		task.ParentID, task.Due, task.Priority, task.Recurrence, task.ID, task.OwnerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err = r.tagUpdate(tx, task.ID, task.Tags); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	after, err := r.snapshotTask(tx, task.ID, task.OwnerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	err = r.pushOperation(tx, task.OwnerID, model.OperationUpdate, entities.OperationPayload{
+		Before: []entities.TaskSnapshot{before},
+		After:  []entities.TaskSnapshot{after},
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// SnoozeTask hides the task from the lists until the given time, nil shows it
+// again right away.
+func (r *TaskPostgres) SnoozeTask(taskID, userID int64, until *time.Time) error {
+	op := "SnoozeTask"
+	var hiddenUntil *time.Time
+	if until != nil {
+		utc := until.UTC()
+		hiddenUntil = &utc
+	}
+	query := "UPDATE tasks SET hidden_until = $1 WHERE id = $2 AND owner_id = $3"
+	res, err := r.db.Exec(query, hiddenUntil, taskID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoTask)
+	}
+	return nil
+}
+
+// GetAgendaTasks loads at once every task an agenda of [from, to) may show:
+// the unfinished ones in progress, due before to, dated in the range or
+// repeating. Snoozed tasks are left out.
+func (r *TaskPostgres) GetAgendaTasks(userID int64, from, to time.Time) ([]model.Task, error) {
+	op := "GetAgendaTasks"
+	where := `owner_id = $1 AND status <> 'done'
+			  AND (status = 'in_progress' OR due < $3 OR date >= $2 AND date < $3 OR recurrence IS NOT NULL)`
+	tasks, err := r.selectTasks(r.db, where, model.TaskFilter{}, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"restAPI/internal/entities"
+	"restAPI/internal/model"
+)
+
+type TemplatePostgres struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewTemplatePostgres(db *sqlx.DB, log *slog.Logger) *TemplatePostgres {
+	return &TemplatePostgres{
+		db:  db,
+		log: log,
+	}
+}
+
+func toTemplate(rawTemplate entities.Template) (model.Template, error) {
+	op := "toTemplate"
+	template := model.Template{
+		ID:      rawTemplate.ID,
+		Name:    rawTemplate.Name,
+		OwnerID: rawTemplate.OwnerID,
+	}
+	if err := json.Unmarshal(rawTemplate.Tasks, &template.Tasks); err != nil {
+		return model.Template{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return template, nil
+}
+
+func (r *TemplatePostgres) CreateTemplate(template model.Template) (int64, error) {
+	op := "CreateTemplate"
+	tasks, err := json.Marshal(template.Tasks)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	var templateID int64
+	query := "INSERT INTO templates (name, owner_id, tasks) VALUES ($1, $2, $3) RETURNING id"
+	err = r.db.Get(&templateID, query, template.Name, template.OwnerID, tasks)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return templateID, nil
+}
+
+func (r *TemplatePostgres) GetTemplate(templateID, userID int64) (model.Template, error) {
+	op := "GetTemplate"
+	rawTemplates := make([]entities.Template, 0, 1)
+	query := "SELECT id, name, owner_id, tasks FROM templates WHERE id = $1 AND owner_id = $2"
+	err := r.db.Select(&rawTemplates, query, templateID, userID)
+	if err != nil {
+		return model.Template{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(rawTemplates) == 0 {
+		return model.Template{}, fmt.Errorf("%s: %w", op, ErrNoTemplate)
+	}
+	template, err := toTemplate(rawTemplates[0])
+	if err != nil {
+		return model.Template{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return template, nil
+}
+
+func (r *TemplatePostgres) GetTemplates(userID int64) ([]model.Template, error) {
+	op := "GetTemplates"
+	rawTemplates := make([]entities.Template, 0)
+	query := "SELECT id, name, owner_id, tasks FROM templates WHERE owner_id = $1 ORDER BY id"
+	err := r.db.Select(&rawTemplates, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	templates := make([]model.Template, 0, len(rawTemplates))
+	for _, rawTemplate := range rawTemplates {
+		template, err := toTemplate(rawTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func (r *TemplatePostgres) UpdateTemplate(template model.Template) error {
+	op := "UpdateTemplate"
+	tasks, err := json.Marshal(template.Tasks)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	query := "UPDATE templates SET name = $1, tasks = $2 WHERE id = $3 AND owner_id = $4"
+	res, err := r.db.Exec(query, template.Name, tasks, template.ID, template.OwnerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoTemplate)
+	}
+	return nil
+}
+
+func (r *TemplatePostgres) DeleteTemplate(templateID, userID int64) error {
+	op := "DeleteTemplate"
+	query := "DELETE FROM templates WHERE id = $1 AND owner_id = $2"
+	res, err := r.db.Exec(query, templateID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoTemplate)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"log/slog"
+	"restAPI/internal/model"
+	"time"
+)
+
+// The report queries take the user as $1, the range as $2 and $3 and now as
+// $4; entries crossing the range edges only count their part inside it.
+const (
+	clippedStart = "GREATEST(time_entries.started_at, $2)"
+	clippedStop  = "LEAST(COALESCE(time_entries.stopped_at, $4), $3)"
+	inRange      = `time_entries.owner_id = $1
+			AND time_entries.started_at < $3 AND COALESCE(time_entries.stopped_at, $4) > $2`
+)
+
+type TimePostgres struct {
+	db  *sqlx.DB
+	log *slog.Logger
+}
+
+func NewTimePostgres(db *sqlx.DB, log *slog.Logger) *TimePostgres {
+	return &TimePostgres{
+		db:  db,
+		log: log,
+	}
+}
+
+// StartTimer starts a timer on the task unless the user has one running already.
+func (r *TimePostgres) StartTimer(taskID, userID int64, now time.Time) (int64, error) {
+	op := "StartTimer"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	query := "SELECT id FROM users WHERE id = $1 FOR UPDATE"
+	if _, err = tx.Exec(query, userID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = checkTaskOwner(tx, taskID, userID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	var running int
+	query = "SELECT count(*) FROM time_entries WHERE owner_id = $1 AND stopped_at IS NULL"
+	if err = tx.Get(&running, query, userID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if running != 0 {
+		return 0, fmt.Errorf("%s: %w", op, ErrTimerRunning)
+	}
+	var entryID int64
+	query = "INSERT INTO time_entries (task_id, owner_id, started_at) VALUES ($1, $2, $3) RETURNING id"
+	if err = tx.Get(&entryID, query, taskID, userID, now); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return entryID, nil
+}
+
+func (r *TimePostgres) StopTimer(taskID, userID int64, now time.Time) (model.TimeEntry, error) {
+	op := "StopTimer"
+	entries := make([]model.TimeEntry, 0, 1)
+	query := `UPDATE time_entries SET stopped_at = $3
+			  WHERE task_id = $1 AND owner_id = $2 AND stopped_at IS NULL
+			  RETURNING id, task_id, owner_id, started_at, stopped_at,
+			      extract(epoch FROM stopped_at - started_at)::bigint AS seconds`
+	if err := r.db.Select(&entries, query, taskID, userID, now); err != nil {
+		return model.TimeEntry{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(entries) == 0 {
+		return model.TimeEntry{}, fmt.Errorf("%s: %w", op, ErrNoTimer)
+	}
+	return entries[0], nil
+}
+
+func (r *TimePostgres) CreateTimeEntry(entry model.TimeEntry) (int64, error) {
+	op := "CreateTimeEntry"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err = checkTaskOwner(tx, entry.TaskID, entry.OwnerID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	var entryID int64
+	query := "INSERT INTO time_entries (task_id, owner_id, started_at, stopped_at) VALUES ($1, $2, $3, $4) RETURNING id"
+	err = tx.Get(&entryID, query, entry.TaskID, entry.OwnerID, entry.StartedAt, entry.StoppedAt)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return entryID, nil
+}
+
+func (r *TimePostgres) GetTimeEntries(taskID, userID int64, now time.Time) ([]model.TimeEntry, error) {
+	op := "GetTimeEntries"
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err = checkTaskOwner(tx, taskID, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	entries := make([]model.TimeEntry, 0)
+	query := `SELECT id, task_id, owner_id, started_at, stopped_at,
+			      extract(epoch FROM COALESCE(stopped_at, $2) - started_at)::bigint AS seconds
+			  FROM time_entries
+			  WHERE task_id = $1
+			  ORDER BY started_at, id`
+	if err = tx.Select(&entries, query, taskID, now); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return entries, nil
+}
+
+func (r *TimePostgres) DeleteTimeEntry(taskID, entryID, userID int64) error {
+	op := "DeleteTimeEntry"
+	query := "DELETE FROM time_entries WHERE id = $1 AND task_id = $2 AND owner_id = $3"
+	res, err := r.db.Exec(query, entryID, taskID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNoTimeEntry)
+	}
+	return nil
+}
+
+// TimeReport sums the time logged by the user in [from, to).
+func (r *TimePostgres) TimeReport(userID int64, from, to time.Time, group string, now time.Time) (model.TimeReport, error) {
+	op := "TimeReport"
+	var query string
+	switch group {
+	case model.TimeGroupTask:
+		query = `SELECT tasks.task AS key, tasks.id AS task_id,
+				     sum(extract(epoch FROM ` + clippedStop + ` - ` + clippedStart + `))::bigint AS seconds
+				 FROM time_entries
+				 JOIN tasks
+				     ON tasks.id = time_entries.task_id
+				 WHERE ` + inRange + `
+				 GROUP BY tasks.id, tasks.task
+				 ORDER BY seconds DESC, tasks.id`
+	case model.TimeGroupTag:
+		query = `SELECT COALESCE(tags.tag, '') AS key,
+				     sum(extract(epoch FROM ` + clippedStop + ` - ` + clippedStart + `))::bigint AS seconds
+				 FROM time_entries
+				 LEFT OUTER JOIN tags_in_task
+				     ON tags_in_task.task_id = time_entries.task_id
+				 LEFT OUTER JOIN tags
+				     ON tags.id = tags_in_task.tag_id
+				 WHERE ` + inRange + `
+				 GROUP BY COALESCE(tags.tag, '')
+				 ORDER BY seconds DESC, key`
+	case model.TimeGroupDay:
+		// entries running over midnight are split between the days
+		query = `SELECT to_char(day, 'YYYY-MM-DD') AS key,
+				     sum(extract(epoch FROM LEAST(` + clippedStop + `, day + interval '1 day')
+				         - GREATEST(` + clippedStart + `, day)))::bigint AS seconds
+				 FROM time_entries,
+				     generate_series(date_trunc('day', ` + clippedStart + `), ` + clippedStop + `, interval '1 day') AS day
+				 WHERE ` + inRange + `
+				     AND LEAST(` + clippedStop + `, day + interval '1 day') > GREATEST(` + clippedStart + `, day)
+				 GROUP BY day
+				 ORDER BY day`
+	default:
+		return model.TimeReport{}, fmt.Errorf("%s: unknown group %q", op, group)
+	}
+	report := model.TimeReport{
+		From:  from,
+		To:    to,
+		Group: group,
+		Rows:  make([]model.TimeReportRow, 0),
+	}
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return model.TimeReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err = tx.Select(&report.Rows, query, userID, from, to, now); err != nil {
+		return model.TimeReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+	query = `SELECT COALESCE(sum(extract(epoch FROM ` + clippedStop + ` - ` + clippedStart + `)), 0)::bigint
+			 FROM time_entries
+			 WHERE ` + inRange
+	if err = tx.Get(&report.Total, query, userID, from, to, now); err != nil {
+		return model.TimeReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = tx.Commit(); err != nil {
+		return model.TimeReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return report, nil
+}
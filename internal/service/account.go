@@ -0,0 +1,350 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/blobstore"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var ErrExportLink = errors.New("incorrect or expired download link")
+
+// exportLinkTTL is how long a download link of an account archive works, a
+// new one is given every time the export is read.
+const exportLinkTTL = 15 * time.Minute
+
+// exportFailure is the error of a failed export, the errors of the storage
+// are not for the users.
+const exportFailure = "the archive couldn't be built, start another export"
+
+// AccountService builds the archives of all the data of the users in the
+// background and deletes the accounts. An archive holds the profile, the
+// projects, the tasks with all that belongs to them, as JSON and as CSV, the
+// files attached to them and the undo history. A deleted account is kept for
+// the grace period, signing in cancels the deletion.
+type AccountService struct {
+	rep      repositories.Account
+	tasks    repositories.Task
+	projects repositories.Project
+	store    blobstore.BlobStore
+	grace    time.Duration
+	now      func() time.Time
+}
+
+func NewAccountService(rep repositories.Account, tasks repositories.Task, projects repositories.Project,
+	store blobstore.BlobStore, grace time.Duration) *AccountService {
+	return &AccountService{
+		rep:      rep,
+		tasks:    tasks,
+		projects: projects,
+		store:    store,
+		grace:    grace,
+		now:      time.Now,
+	}
+}
+
+// archiveTask is a task of the archive with its checklist, comments, time
+// entries and attachments.
+type archiveTask struct {
+	ID int64 `json:"id"`
+	model.Task
+	Checklist   []model.TaskItem    `json:"checklist"`
+	Comments    []model.Comment     `json:"comments"`
+	TimeEntries []model.TimeEntry   `json:"time_entries"`
+	Attachments []archiveAttachment `json:"attachments"`
+}
+
+// archiveAttachment tells where the attached file is in the archive, File is
+// empty when its content is lost.
+type archiveAttachment struct {
+	model.Attachment
+	File string `json:"file,omitempty"`
+}
+
+// StartAccountExport builds the archive of the user in the background.
+func (s *AccountService) StartAccountExport(userID int64) (model.AccountExport, error) {
+	exportID, err := s.rep.CreateAccountExport(userID)
+	if err != nil {
+		return model.AccountExport{}, fmt.Errorf("%w", err)
+	}
+	export, err := s.rep.GetAccountExport(exportID, userID)
+	if err != nil {
+		return model.AccountExport{}, fmt.Errorf("%w", err)
+	}
+	go s.run(export)
+	return export, nil
+}
+
+// GetAccountExport returns the export, a done one with a fresh download link.
+func (s *AccountService) GetAccountExport(exportID, userID int64) (model.AccountExport, error) {
+	export, err := s.rep.GetAccountExport(exportID, userID)
+	if err != nil {
+		return model.AccountExport{}, fmt.Errorf("%w", err)
+	}
+	if export.Status == model.AccountExportDone {
+		expires := s.now().Add(exportLinkTTL).Truncate(time.Second)
+		query := url.Values{
+			"user":      {fmt.Sprint(userID)},
+			"expires":   {fmt.Sprint(expires.Unix())},
+			"signature": {exportSignature(exportID, userID, expires.Unix())},
+		}
+		export.DownloadURL = fmt.Sprintf("/account-exports/%d?%s", exportID, query.Encode())
+		expires = expires.UTC()
+		export.LinkExpiresAt = &expires
+	}
+	return export, nil
+}
+
+// OpenAccountExport opens the archive of a download link, ErrExportLink tells
+// the link is forged or expired.
+func (s *AccountService) OpenAccountExport(ctx context.Context, exportID, userID, expires int64,
+	signature string) (model.AccountExport, io.ReadSeekCloser, error) {
+	valid := hmac.Equal([]byte(signature), []byte(exportSignature(exportID, userID, expires)))
+	if !valid || s.now().Unix() >= expires {
+		return model.AccountExport{}, nil, fmt.Errorf("%w", ErrExportLink)
+	}
+	export, err := s.rep.GetAccountExport(exportID, userID)
+	if err != nil {
+		return model.AccountExport{}, nil, fmt.Errorf("%w", err)
+	}
+	if export.Status != model.AccountExportDone {
+		return model.AccountExport{}, nil, fmt.Errorf("%w", repositories.ErrNoAccountExport)
+	}
+	content, err := s.store.Open(ctx, export.Key)
+	if err != nil {
+		return model.AccountExport{}, nil, fmt.Errorf("%w", err)
+	}
+	return export, content, nil
+}
+
+// FailRunningAccountExports fails the exports a stopped server left running,
+// to be called when the server starts.
+func (s *AccountService) FailRunningAccountExports() error {
+	if err := s.rep.FailRunningAccountExports("the server stopped during the export"); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+// DeleteAccount deletes the user after the grace period, the password is
+// asked again since the deletion can't be undone once it is done.
+func (s *AccountService) DeleteAccount(userID int64, password string) (model.AccountDeletion, error) {
+	deleteAfter, err := s.rep.ScheduleDeletion(userID, generatePasswordHash(password), s.grace)
+	if err != nil {
+		return model.AccountDeletion{}, fmt.Errorf("%w", err)
+	}
+	return model.AccountDeletion{DeleteAfter: deleteAfter}, nil
+}
+
+// PurgeAccounts deletes the users whose grace period is over with all their
+// data, then the tags nobody has anymore. It returns how many users were
+// deleted, ErrBlobCleanup tells some of their files are left in the store.
+func (s *AccountService) PurgeAccounts(ctx context.Context) (int, error) {
+	purged, keys, err := s.rep.PurgeUsers()
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	if purged == 0 {
+		return 0, nil
+	}
+	// the accounts are gone, their blobs go whatever becomes of the tags
+	blobErr := removeBlobs(ctx, s.store, keys)
+	_, tagErr := s.rep.DeleteOrphanTags()
+	if err = errors.Join(blobErr, tagErr); err != nil {
+		return purged, fmt.Errorf("%w", err)
+	}
+	return purged, nil
+}
+
+// exportSignature signs a download link, the key of the tokens is prefixed
+// so that a link can't be signed as something else.
+func exportSignature(exportID, userID, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	fmt.Fprintf(mac, "account-export:%d:%d:%d", exportID, userID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// run builds the archive and records the end of the export, the archives of
+// the older exports go then. An export whose end can't be recorded stays
+// running until the server restarts.
+func (s *AccountService) run(export model.AccountExport) {
+	ctx := context.Background()
+	export.Status = model.AccountExportDone
+	key, size, err := s.buildArchive(ctx, export.OwnerID)
+	if err != nil {
+		export.Status, export.Error = model.AccountExportFailed, exportFailure
+	}
+	export.Key, export.Size = key, size
+	oldKeys, err := s.rep.FinishAccountExport(export)
+	if err != nil {
+		if key != "" {
+			_ = removeBlobs(ctx, s.store, []string{key})
+		}
+		return
+	}
+	_ = removeBlobs(ctx, s.store, oldKeys)
+}
+
+// buildArchive writes the archive of the user to a temporary file first, the
+// attached files may not fit in memory, and stores it.
+func (s *AccountService) buildArchive(ctx context.Context, userID int64) (string, int64, error) {
+	file, err := os.CreateTemp("", "account-export-*.zip")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if err = s.writeArchive(ctx, file, userID); err != nil {
+		return "", 0, err
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+	key, err := newBlobKey(userID)
+	if err != nil {
+		return "", 0, err
+	}
+	if err = s.store.Put(ctx, key, file, size, "application/zip"); err != nil {
+		return "", 0, err
+	}
+	return key, size, nil
+}
+
+func (s *AccountService) writeArchive(ctx context.Context, w io.Writer, userID int64) error {
+	now := s.now()
+	tasks, err := s.tasks.GetAllByUser(userID, model.TaskFilter{IncludeDeferred: true})
+	if err != nil {
+		return err
+	}
+	projects, err := s.projects.GetAllProjects(userID, true)
+	if err != nil {
+		return err
+	}
+	data, err := s.rep.GetAccountData(userID, now.UTC())
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	archiveTasks := make([]archiveTask, len(tasks))
+	index := make(map[int64]*archiveTask, len(tasks))
+	for i, task := range tasks {
+		archiveTasks[i] = archiveTask{
+			ID:          task.ID,
+			Task:        task,
+			Checklist:   make([]model.TaskItem, 0),
+			Comments:    make([]model.Comment, 0),
+			TimeEntries: make([]model.TimeEntry, 0),
+			Attachments: make([]archiveAttachment, 0),
+		}
+		index[task.ID] = &archiveTasks[i]
+	}
+	// the records of tasks deleted between the reads are left out
+	for _, item := range data.Items {
+		if task, found := index[item.TaskID]; found {
+			task.Checklist = append(task.Checklist, item)
+		}
+	}
+	for _, comment := range data.Comments {
+		if task, found := index[comment.TaskID]; found {
+			task.Comments = append(task.Comments, comment)
+		}
+	}
+	for _, entry := range data.TimeEntries {
+		if task, found := index[entry.TaskID]; found {
+			task.TimeEntries = append(task.TimeEntries, entry)
+		}
+	}
+	for _, attachment := range data.Attachments {
+		task, found := index[attachment.TaskID]
+		if !found {
+			continue
+		}
+		record := archiveAttachment{Attachment: attachment}
+		record.File, err = archiveFile(ctx, archive, s.store, attachment)
+		if err != nil {
+			return err
+		}
+		task.Attachments = append(task.Attachments, record)
+	}
+
+	csvData, err := tasksCSV(tasks, model.CSVColumns, model.DefaultTagDelimiter)
+	if err != nil {
+		return err
+	}
+	csvFile, err := archive.CreateHeader(&zip.FileHeader{Name: "tasks.csv", Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return err
+	}
+	if _, err = csvFile.Write(csvData); err != nil {
+		return err
+	}
+	documents := []struct {
+		name  string
+		value any
+	}{
+		{"profile.json", data.Profile},
+		{"projects.json", projects},
+		{"tasks.json", archiveTasks},
+		{"history.json", data.History},
+	}
+	for _, document := range documents {
+		file, err := archive.CreateHeader(&zip.FileHeader{Name: document.name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(document.value); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// archiveFile copies an attached file into the archive and returns its name
+// there, or nothing when the blob is lost.
+func archiveFile(ctx context.Context, archive *zip.Writer, store blobstore.BlobStore, attachment model.Attachment) (string, error) {
+	content, err := store.Open(ctx, attachment.Key)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+	// the id keeps the names apart, and the uploaded name can't leave the
+	// directory when the archive is extracted
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, attachment.Name)
+	name = fmt.Sprintf("attachments/%d-%s", attachment.ID, name)
+	file, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: attachment.CreatedAt})
+	if err != nil {
+		return "", err
+	}
+	if _, err = io.Copy(file, content); err != nil {
+		return "", err
+	}
+	return name, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/recurrence"
+	"sort"
+	"time"
+)
+
+type AgendaService struct {
+	rep repositories.Task
+	now func() time.Time
+}
+
+func NewAgendaService(rep repositories.Task) *AgendaService {
+	return &AgendaService{
+		rep: rep,
+		now: time.Now,
+	}
+}
+
+// wallDay drops the time of day. Task dates are the wall clock times the user
+// gave, so agenda days are compared on the wall clock as well.
+func wallDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// GetAgenda lays out days days starting today in loc.
+func (s *AgendaService) GetAgenda(userID int64, days int, loc *time.Location) (model.Agenda, error) {
+	from := wallDay(s.now().In(loc))
+	to := from.AddDate(0, 0, days)
+	tasks, err := s.rep.GetAgendaTasks(userID, from, to)
+	if err != nil {
+		return model.Agenda{}, fmt.Errorf("%w", err)
+	}
+	agenda := model.Agenda{
+		InProgress: make([]model.Task, 0),
+		Days:       make([]model.AgendaDay, days),
+	}
+	for i := range agenda.Days {
+		agenda.Days[i] = model.AgendaDay{
+			Date:      from.AddDate(0, 0, i).Format("2006-01-02"),
+			Overdue:   make([]model.Task, 0),
+			Due:       make([]model.Task, 0),
+			Scheduled: make([]model.Task, 0),
+			Recurring: make([]model.AgendaOccurrence, 0),
+		}
+	}
+	dayOf := func(t time.Time) *model.AgendaDay {
+		return &agenda.Days[int(wallDay(t).Sub(from).Hours()/24)]
+	}
+	for _, task := range tasks {
+		if task.Status == model.TaskStatusInProgress {
+			agenda.InProgress = append(agenda.InProgress, task)
+		}
+		if task.Due != nil {
+			switch {
+			case task.Due.Before(from):
+				agenda.Days[0].Overdue = append(agenda.Days[0].Overdue, task)
+			case task.Due.Before(to):
+				day := dayOf(*task.Due)
+				day.Due = append(day.Due, task)
+			}
+		}
+		if task.Recurrence != nil {
+			rule, err := recurrence.Parse(*task.Recurrence)
+			if err == nil {
+				for _, at := range rule.Between(task.Date, from, to) {
+					day := dayOf(at)
+					day.Recurring = append(day.Recurring, model.AgendaOccurrence{
+						At:   at,
+						Task: task,
+					})
+				}
+				continue
+			}
+		}
+		if !task.Date.Before(from) && task.Date.Before(to) {
+			day := dayOf(task.Date)
+			day.Scheduled = append(day.Scheduled, task)
+		}
+	}
+	for i := range agenda.Days {
+		day := &agenda.Days[i]
+		sortTasks(day.Overdue, func(task model.Task) time.Time { return *task.Due })
+		sortTasks(day.Due, func(task model.Task) time.Time { return *task.Due })
+		sortTasks(day.Scheduled, func(task model.Task) time.Time { return task.Date })
+		sort.SliceStable(day.Recurring, func(i, j int) bool {
+			return day.Recurring[i].At.Before(day.Recurring[j].At)
+		})
+	}
+	return agenda, nil
+}
+
+// sortTasks orders the tasks by the time at, keeping tasks with the same time
+// in the order they came.
+func sortTasks(tasks []model.Task, at func(model.Task) time.Time) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return at(tasks[i]).Before(at(tasks[j]))
+	})
+}
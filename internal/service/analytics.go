@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/svgchart"
+	"time"
+)
+
+type AnalyticsService struct {
+	rep repositories.Analytics
+	now func() time.Time
+}
+
+func NewAnalyticsService(rep repositories.Analytics) *AnalyticsService {
+	return &AnalyticsService{
+		rep: rep,
+		now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+func (s *AnalyticsService) EstimateReport(userID int64, from, to time.Time, group string) (model.EstimateReport, error) {
+	report, err := s.rep.EstimateReport(userID, from.UTC(), to.UTC(), group, s.now())
+	if err != nil {
+		return model.EstimateReport{}, fmt.Errorf("%w", err)
+	}
+	return report, nil
+}
+
+// StatsReport takes from and to as days in loc, the days they are in UTC
+// don't matter.
+func (s *AnalyticsService) StatsReport(userID int64, from, to time.Time, bucket string, loc *time.Location) (model.Stats, error) {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc)
+	stats, err := s.rep.StatsReport(userID, from, to, bucket, s.now())
+	if err != nil {
+		return model.Stats{}, fmt.Errorf("%w", err)
+	}
+	return stats, nil
+}
+
+// ActivityHeatmap draws the tasks dated in the year as an SVG grid.
+func (s *AnalyticsService) ActivityHeatmap(userID int64, year int) ([]byte, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	days, err := s.rep.ActivityHeatmap(userID, from, from.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	chart := make([]svgchart.Day, 0, len(days))
+	for _, day := range days {
+		chart = append(chart, svgchart.Day{Date: day.Date, Count: day.Tasks})
+	}
+	return svgchart.Heatmap(chart), nil
+}
+
+// ProjectBurndown draws the open tasks of the project over the last days,
+// today in loc included, as an SVG chart.
+func (s *AnalyticsService) ProjectBurndown(projectID, userID int64, days int, loc *time.Location) ([]byte, error) {
+	now := s.now().In(loc)
+	to := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc)
+	remaining, err := s.rep.ProjectBurndown(projectID, userID, to.AddDate(0, 0, -days), to)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	chart := make([]svgchart.Point, 0, len(remaining))
+	for _, day := range remaining {
+		chart = append(chart, svgchart.Point{Date: day.Date, Remaining: day.Remaining})
+	}
+	return svgchart.Burndown(chart), nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/blobstore"
+)
+
+var ErrBlobCleanup = errors.New("some blobs were not removed")
+
+type AttachmentService struct {
+	rep   repositories.Attachment
+	store blobstore.BlobStore
+	quota int64
+}
+
+func NewAttachmentService(rep repositories.Attachment, store blobstore.BlobStore, quota int64) *AttachmentService {
+	return &AttachmentService{
+		rep:   rep,
+		store: store,
+		quota: quota,
+	}
+}
+
+func newBlobKey(userID int64) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d/%s", userID, hex.EncodeToString(random)), nil
+}
+
+// removeBlobs deletes every blob it can and reports the ones it couldn't.
+func removeBlobs(ctx context.Context, store blobstore.BlobStore, keys []string) error {
+	errs := make([]error, 0)
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%w: %w", ErrBlobCleanup, errors.Join(errs...))
+	}
+	return nil
+}
+
+// CreateAttachment stores data and records it on the task. The content type
+// is sniffed from the data, the one sent by the client is not trusted.
+func (s *AttachmentService) CreateAttachment(ctx context.Context, attachment model.Attachment, data []byte) (int64, error) {
+	key, err := newBlobKey(attachment.OwnerID)
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	attachment.Key = key
+	attachment.Size = int64(len(data))
+	attachment.ContentType = http.DetectContentType(data)
+	err = s.store.Put(ctx, key, bytes.NewReader(data), attachment.Size, attachment.ContentType)
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	id, err := s.rep.CreateAttachment(attachment, s.quota)
+	if err != nil {
+		// nothing points to the blob yet, it can go right away
+		if cleanupErr := s.store.Delete(ctx, key); cleanupErr != nil {
+			return 0, fmt.Errorf("%w", errors.Join(err, cleanupErr))
+		}
+		return 0, fmt.Errorf("%w", err)
+	}
+	return id, nil
+}
+
+func (s *AttachmentService) GetAttachments(taskID, userID int64) ([]model.Attachment, error) {
+	attachments, err := s.rep.GetAttachments(taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return attachments, nil
+}
+
+func (s *AttachmentService) OpenAttachment(ctx context.Context, taskID, attachmentID, userID int64) (model.Attachment, io.ReadSeekCloser, error) {
+	attachment, err := s.rep.GetAttachment(taskID, attachmentID, userID)
+	if err != nil {
+		return model.Attachment{}, nil, fmt.Errorf("%w", err)
+	}
+	content, err := s.store.Open(ctx, attachment.Key)
+	if err != nil {
+		return model.Attachment{}, nil, fmt.Errorf("%w", err)
+	}
+	return attachment, content, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, taskID, attachmentID, userID int64) error {
+	key, err := s.rep.DeleteAttachment(taskID, attachmentID, userID)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	if err = removeBlobs(ctx, s.store, []string{key}); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
@@ -48,6 +48,9 @@ func (s *AuthService) GenerateToken(login, password string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("%w", err)
 	}
+	if err = s.rep.CancelDeletion(user.ID); err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &tokenClaims{
 		jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(12 * time.Hour)),
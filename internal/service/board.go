@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+)
+
+type BoardService struct {
+	rep repositories.Board
+}
+
+func NewBoardService(rep repositories.Board) *BoardService {
+	return &BoardService{
+		rep: rep,
+	}
+}
+
+func (s *BoardService) CreateBoard(board model.Board) (int64, error) {
+	id, err := s.rep.CreateBoard(board)
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	return id, nil
+}
+
+func (s *BoardService) GetBoards(userID int64) ([]model.Board, error) {
+	boards, err := s.rep.GetBoards(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return boards, nil
+}
+
+func (s *BoardService) GetBoard(boardID, userID int64) (model.Board, error) {
+	board, err := s.rep.GetBoard(boardID, userID)
+	if err != nil {
+		return model.Board{}, fmt.Errorf("%w", err)
+	}
+	return board, nil
+}
+
+func (s *BoardService) DeleteBoard(boardID, userID int64) error {
+	err := s.rep.DeleteBoard(boardID, userID)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *BoardService) CreateColumn(column model.BoardColumn, userID int64) (int64, error) {
+	id, err := s.rep.CreateColumn(column, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	return id, nil
+}
+
+func (s *BoardService) MoveCard(boardID, taskID, userID int64, move model.CardMove) error {
+	err := s.rep.MoveCard(boardID, taskID, userID, move)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *BoardService) RemoveCard(boardID, taskID, userID int64) error {
+	err := s.rep.RemoveCard(boardID, taskID, userID)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"fmt"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+)
+
+type CommentService struct {
+	rep repositories.Comment
+}
+
+func NewCommentService(rep repositories.Comment) *CommentService {
+	return &CommentService{
+		rep: rep,
+	}
+}
+
+func (s *CommentService) CreateComment(comment model.Comment) (int64, error) {
+	id, err := s.rep.CreateComment(comment)
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	return id, nil
+}
+
+func (s *CommentService) GetComments(taskID, userID int64, page model.Page) ([]model.Comment, int, error) {
+	comments, total, err := s.rep.GetComments(taskID, userID, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w", err)
+	}
+	return comments, total, nil
+}
+
+func (s *CommentService) UpdateComment(comment model.Comment, userID int64) error {
+	err := s.rep.UpdateComment(comment, userID)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *CommentService) DeleteComment(taskID, commentID, userID int64) error {
+	err := s.rep.DeleteComment(taskID, commentID, userID)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
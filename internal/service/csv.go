@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"restAPI/internal/model"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrCSV = errors.New("incorrect csv")
+
+// csvTimeLayout writes task dates, without a zone like they are stored.
+const csvTimeLayout = "2006-01-02 15:04:05"
+
+// csvTimeLayouts are the dates read from CSV files, the way spreadsheets
+// tend to write them.
+var csvTimeLayouts = []string{csvTimeLayout, "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+// csvValue writes the column of the task as a CSV cell.
+func csvValue(task model.Task, column, tagDelimiter string) string {
+	switch column {
+	case "id":
+		return strconv.FormatInt(task.ID, 10)
+	case "text":
+		return task.Text
+	case "status":
+		return task.Status
+	case "date":
+		return task.Date.Format(csvTimeLayout)
+	case "due":
+		if task.Due != nil {
+			return task.Due.Format(csvTimeLayout)
+		}
+	case "priority":
+		if task.Priority != nil {
+			return *task.Priority
+		}
+	case "tags":
+		return strings.Join(task.Tags, tagDelimiter)
+	case "project_id":
+		if task.ProjectID != nil {
+			return strconv.FormatInt(*task.ProjectID, 10)
+		}
+	case "parent_id":
+		if task.ParentID != nil {
+			return strconv.FormatInt(*task.ParentID, 10)
+		}
+	case "estimate":
+		if task.Estimate != nil {
+			return strconv.FormatInt(*task.Estimate, 10)
+		}
+	case "recurrence":
+		if task.Recurrence != nil {
+			return *task.Recurrence
+		}
+	case "completed_at":
+		if task.CompletedAt != nil {
+			return task.CompletedAt.UTC().Format(time.RFC3339)
+		}
+	}
+	return ""
+}
+
+// setCSVValue reads a CSV cell into the column of the task. The id column is
+// left out, imported rows always make new tasks.
+func setCSVValue(task *model.Task, column, value, tagDelimiter string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	var err error
+	switch column {
+	case "text":
+		task.Text = value
+	case "status":
+		task.Status = strings.ToLower(value)
+	case "date":
+		task.Date, err = parseWallTime(value)
+	case "due":
+		var due time.Time
+		due, err = parseWallTime(value)
+		task.Due = &due
+	case "priority":
+		priority := strings.ToLower(value)
+		task.Priority = &priority
+	case "tags":
+		for _, tag := range strings.Split(value, tagDelimiter) {
+			if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(task.Tags, tag) {
+				task.Tags = append(task.Tags, tag)
+			}
+		}
+	case "project_id":
+		task.ProjectID, err = parseID(value)
+	case "parent_id":
+		task.ParentID, err = parseID(value)
+	case "estimate":
+		var estimate int64
+		estimate, err = strconv.ParseInt(value, 10, 64)
+		task.Estimate = &estimate
+	case "recurrence":
+		recurrence := strings.ToUpper(value)
+		task.Recurrence = &recurrence
+	case "completed_at":
+		var completed time.Time
+		if completed, err = time.Parse(time.RFC3339, value); err != nil {
+			completed, err = parseWallTime(value)
+		}
+		completed = completed.UTC()
+		task.CompletedAt = &completed
+	}
+	if err != nil {
+		return fmt.Errorf("incorrect %s %q", column, value)
+	}
+	return nil
+}
+
+// parseWallTime reads a date without a zone, as the wall clock time it gives.
+func parseWallTime(value string) (time.Time, error) {
+	for _, layout := range csvTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no layout matches %q", value)
+}
+
+func parseID(value string) (*int64, error) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("incorrect id %q", value)
+	}
+	return &id, nil
+}
+
+// csvHeader tells the column of every header, "" for the ignored ones.
+// Headers are named after the columns unless mapping says otherwise.
+func csvHeader(header []string, mapping map[string]string) ([]string, error) {
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		column, ok := mapping[name]
+		if !ok {
+			column = strings.ToLower(name)
+			if !slices.Contains(model.CSVColumns, column) {
+				continue
+			}
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("%w: column %s given twice", ErrCSV, column)
+		}
+		seen[column] = true
+		columns[i] = column
+	}
+	if !seen["text"] {
+		return nil, fmt.Errorf("%w: no text column", ErrCSV)
+	}
+	return columns, nil
+}
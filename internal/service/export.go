@@ -0,0 +1,150 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/ical"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// calendarName is the name calendar apps give the exported tasks.
+const calendarName = "Tasks"
+
+var (
+	todoStatuses = map[string]string{
+		model.TaskStatusTodo:       ical.StatusNeedsAction,
+		model.TaskStatusInProgress: ical.StatusInProcess,
+		model.TaskStatusDone:       ical.StatusCompleted,
+	}
+	todoPriorities = map[string]int{
+		model.TaskPriorityHigh:   1,
+		model.TaskPriorityMedium: 5,
+		model.TaskPriorityLow:    9,
+	}
+)
+
+type ExportService struct {
+	rep repositories.Task
+	now func() time.Time
+}
+
+func NewExportService(rep repositories.Task) *ExportService {
+	return &ExportService{
+		rep: rep,
+		now: time.Now,
+	}
+}
+
+// ExportICS writes all the tasks of the user, done and snoozed ones
+// included, as an iCalendar file of VTODO entries.
+func (s *ExportService) ExportICS(userID int64) ([]byte, error) {
+	tasks, err := s.rep.GetAllByUser(userID, model.TaskFilter{IncludeDeferred: true})
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	todos := make([]ical.Todo, 0, len(tasks))
+	for _, task := range tasks {
+		todo := ical.Todo{
+			UID:        taskUID(task.ID),
+			Summary:    task.Text,
+			Categories: task.Tags,
+			Status:     todoStatuses[task.Status],
+			Start:      &task.Date,
+			Due:        task.Due,
+			Completed:  task.CompletedAt,
+		}
+		if task.Priority != nil {
+			todo.Priority = todoPriorities[*task.Priority]
+		}
+		if task.Recurrence != nil {
+			todo.RRule = *task.Recurrence
+		}
+		if task.ParentID != nil {
+			todo.RelatedTo = taskUID(*task.ParentID)
+		}
+		todos = append(todos, todo)
+	}
+	var b bytes.Buffer
+	if err = ical.Encode(&b, calendarName, todos, s.now()); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// ExportCSV writes all the tasks of the user, done and snoozed ones included,
+// as a CSV file with the columns, in their order, under a header row. Tags
+// are joined with tagDelimiter.
+func (s *ExportService) ExportCSV(userID int64, columns []string, tagDelimiter string) ([]byte, error) {
+	tasks, err := s.rep.GetAllByUser(userID, model.TaskFilter{IncludeDeferred: true})
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	data, err := tasksCSV(tasks, columns, tagDelimiter)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return data, nil
+}
+
+func tasksCSV(tasks []model.Task, columns []string, tagDelimiter string) ([]byte, error) {
+	var b bytes.Buffer
+	writer := csv.NewWriter(&b)
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+	record := make([]string, len(columns))
+	for _, task := range tasks {
+		for i, column := range columns {
+			record[i] = csvValue(task, column, tagDelimiter)
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// ExportTodoTxt writes all the tasks of the user, done and snoozed ones
+// included, as a todo.txt file with a line per task.
+func (s *ExportService) ExportTodoTxt(userID int64) ([]byte, error) {
+	tasks, err := s.rep.GetAllByUser(userID, model.TaskFilter{IncludeDeferred: true})
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	var b bytes.Buffer
+	for _, task := range tasks {
+		b.WriteString(todoLine(task).String())
+		b.WriteByte('\n')
+	}
+	return b.Bytes(), nil
+}
+
+func taskUID(taskID int64) string {
+	return fmt.Sprintf("task-%d@restapi", taskID)
+}
+
+// ownTaskID reads the task ID back from a UID given by taskUID.
+func ownTaskID(uid string) (int64, bool) {
+	id, found := strings.CutPrefix(uid, "task-")
+	if !found {
+		return 0, false
+	}
+	id, found = strings.CutSuffix(id, "@restapi")
+	if !found {
+		return 0, false
+	}
+	taskID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || taskUID(taskID) != uid {
+		return 0, false
+	}
+	return taskID, true
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"restAPI/internal/repositories"
+)
+
+// feedTokenSize is the number of random bytes in a feed token.
+const feedTokenSize = 32
+
+type FeedService struct {
+	rep repositories.Feed
+}
+
+func NewFeedService(rep repositories.Feed) *FeedService {
+	return &FeedService{
+		rep: rep,
+	}
+}
+
+// RotateFeedToken gives the user a new feed token, the old one stops working.
+// The token is only known to the caller, it can't be read back.
+func (s *FeedService) RotateFeedToken(userID int64) (string, error) {
+	secret := make([]byte, feedTokenSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+	if err := s.rep.SetFeedToken(userID, hashFeedToken(token)); err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+	return token, nil
+}
+
+func (s *FeedService) RevokeFeedToken(userID int64) error {
+	if err := s.rep.DeleteFeedToken(userID); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+// FeedUser finds the user a feed token belongs to.
+func (s *FeedService) FeedUser(token string) (int64, error) {
+	userID, err := s.rep.GetFeedUser(hashFeedToken(token))
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	return userID, nil
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,605 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/ical"
+	"restAPI/pkg/lib/recurrence"
+	"restAPI/pkg/lib/todotxt"
+	"restAPI/pkg/lib/verification"
+	"time"
+)
+
+var taskStatuses = map[string]string{
+	ical.StatusNeedsAction: model.TaskStatusTodo,
+	ical.StatusInProcess:   model.TaskStatusInProgress,
+	ical.StatusCompleted:   model.TaskStatusDone,
+}
+
+// errImportInvalid rolls back an atomic import with a row that fails when it
+// is created.
+var errImportInvalid = errors.New("invalid row in an atomic import")
+
+// ImportService creates the imported tasks through the task service, like
+// the tasks created one by one.
+type ImportService struct {
+	tasks Task
+	now   func() time.Time
+}
+
+func NewImportService(tasks Task) *ImportService {
+	return &ImportService{
+		tasks: tasks,
+		now:   time.Now,
+	}
+}
+
+// ImportICS creates a task for every VTODO of the calendar, and for every
+// VEVENT with events. Times are read in loc. Entries whose UID the user
+// already has, from an earlier import or an export of their own tasks, are
+// duplicates, as are the entries repeating a UID of the calendar. A parent
+// given by RELATED-TO is created before its subtasks.
+func (s *ImportService) ImportICS(userID int64, calendar io.Reader, events bool, loc *time.Location) (model.ImportReport, error) {
+	entries, err := ical.Decode(calendar, loc)
+	if err != nil {
+		return model.ImportReport{}, fmt.Errorf("%w", err)
+	}
+	known, err := s.knownUIDs(userID, entries)
+	if err != nil {
+		return model.ImportReport{}, fmt.Errorf("%w", err)
+	}
+
+	items := make([]model.ImportItem, len(entries))
+	err = s.tasks.ImportTasks(userID, func(create func(task model.Task) (int64, error)) error {
+		for _, i := range parentsFirst(entries) {
+			entry := entries[i]
+			item := model.ImportItem{Ref: entry.UID, Text: entry.Summary}
+			if item.Ref == "" {
+				item.Ref = fmt.Sprintf("#%d", i+1)
+			}
+			if taskID, ok := known[entry.UID]; ok && entry.UID != "" {
+				item.Status, item.TaskID = model.ImportDuplicate, taskID
+				items[i] = item
+				continue
+			}
+			switch {
+			case entry.Component == ical.ComponentEvent && !events:
+				item.Status, item.Reason = model.ImportSkipped, "events are not imported"
+			case entry.Status == ical.StatusCancelled:
+				item.Status, item.Reason = model.ImportSkipped, "cancelled"
+			default:
+				task, reason, ok := s.entryTask(userID, entry, loc)
+				if !ok {
+					item.Status, item.Reason = model.ImportInvalid, reason
+					break
+				}
+				if entry.RelatedTo != "" {
+					if parentID, found := known[entry.RelatedTo]; found {
+						task.ParentID = &parentID
+					} else {
+						if reason != "" {
+							reason += "; "
+						}
+						reason += "parent " + entry.RelatedTo + " not found, imported without it"
+					}
+				}
+				taskID, err := create(task)
+				if err != nil {
+					return err
+				}
+				if entry.UID != "" {
+					known[entry.UID] = taskID
+				}
+				item.Status, item.TaskID, item.Reason = model.ImportCreated, taskID, reason
+			}
+			items[i] = item
+		}
+		return nil
+	})
+	if err != nil {
+		return model.ImportReport{}, fmt.Errorf("%w", err)
+	}
+
+	report := model.ImportReport{Items: make([]model.ImportItem, 0, len(items))}
+	for _, item := range items {
+		report.Add(item)
+	}
+	return report, nil
+}
+
+// csvRow is a row of an imported CSV file, its item has no status until it
+// is known what becomes of the row.
+type csvRow struct {
+	item model.ImportItem
+	task model.Task
+}
+
+// ImportCSV creates a task for every row of the CSV file under its header,
+// rows without a date are dated now in loc. All the rows are read before
+// any task is created, an atomic import of a file with invalid rows creates
+// none. Rows may still fail when they are created, on a project or parent
+// that isn't found; an atomic import then creates none either.
+func (s *ImportService) ImportCSV(userID int64, file io.Reader, options model.CSVImport, loc *time.Location) (model.ImportReport, error) {
+	tagDelimiter := options.TagDelimiter
+	if tagDelimiter == "" {
+		tagDelimiter = model.DefaultTagDelimiter
+	}
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return model.ImportReport{}, fmt.Errorf("%w: no header", ErrCSV)
+	}
+	if err != nil {
+		return model.ImportReport{}, fmt.Errorf("%w: %w", ErrCSV, err)
+	}
+	columns, err := csvHeader(header, options.Mapping)
+	if err != nil {
+		return model.ImportReport{}, fmt.Errorf("%w", err)
+	}
+
+	rows := make([]csvRow, 0)
+	invalid := false
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return model.ImportReport{}, fmt.Errorf("%w: %w", ErrCSV, err)
+		}
+		line, _ := reader.FieldPos(0)
+		task, reason, ok := s.csvTask(userID, record, columns, tagDelimiter, loc)
+		row := csvRow{
+			item: model.ImportItem{Ref: fmt.Sprintf("row %d", line), Text: task.Text, Reason: reason},
+			task: task,
+		}
+		if !ok {
+			row.item.Status = model.ImportInvalid
+			invalid = true
+		}
+		rows = append(rows, row)
+	}
+
+	switch {
+	case options.Atomic && invalid:
+		skipRows(rows, "not imported, the file has invalid rows")
+	case options.DryRun:
+		for i := range rows {
+			if rows[i].item.Status == "" {
+				rows[i].item.Status = model.ImportCreated
+				rows[i].item.Task = &rows[i].task
+			}
+		}
+	default:
+		if err = s.createRows(userID, rows, options.Atomic); err != nil {
+			return model.ImportReport{}, fmt.Errorf("%w", err)
+		}
+	}
+
+	report := model.ImportReport{DryRun: options.DryRun, Items: make([]model.ImportItem, 0, len(rows))}
+	for _, row := range rows {
+		report.Add(row.item)
+	}
+	return report, nil
+}
+
+// csvTask makes the task of a CSV row. When ok is false, reason tells why it
+// can't be imported.
+func (s *ImportService) csvTask(userID int64, record, columns []string, tagDelimiter string, loc *time.Location) (task model.Task, reason string, ok bool) {
+	task = model.Task{
+		Tags:    make([]string, 0),
+		OwnerID: userID,
+		Date:    wallClock(s.now().In(loc)),
+	}
+	for i, value := range record {
+		if i >= len(columns) || columns[i] == "" {
+			continue
+		}
+		if err := setCSVValue(&task, columns[i], value, tagDelimiter); err != nil {
+			return task, err.Error(), false
+		}
+	}
+	if task.Text == "" {
+		return task, "no text", false
+	}
+	if !verification.Task(task) {
+		return task, "incorrect task information", false
+	}
+	return task, "", true
+}
+
+// createRows creates the tasks of the rows not known to be invalid in one
+// transaction. When a row fails an atomic import, none is created and the
+// rows are reported as not imported.
+func (s *ImportService) createRows(userID int64, rows []csvRow, atomic bool) error {
+	var failed string
+	err := s.tasks.ImportTasks(userID, func(create func(task model.Task) (int64, error)) error {
+		for i := range rows {
+			row := &rows[i]
+			if row.item.Status != "" {
+				continue
+			}
+			taskID, reason, err := importTask(create, row.task)
+			if err != nil {
+				return err
+			}
+			if reason == "" {
+				row.item.Status, row.item.TaskID = model.ImportCreated, taskID
+				continue
+			}
+			row.item.Status, row.item.Reason = model.ImportInvalid, reason
+			if atomic {
+				failed = row.item.Ref
+				return errImportInvalid
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errImportInvalid) {
+		for i := range rows {
+			if rows[i].item.Status == model.ImportCreated {
+				rows[i].item.Status, rows[i].item.TaskID = "", 0
+			}
+		}
+		skipRows(rows, "not imported, "+failed+" is invalid")
+		return nil
+	}
+	return err
+}
+
+// skipRows reports the rows not known to be invalid as skipped for reason.
+func skipRows(rows []csvRow, reason string) {
+	for i := range rows {
+		if rows[i].item.Status == "" {
+			rows[i].item.Status, rows[i].item.Reason = model.ImportSkipped, reason
+		}
+	}
+}
+
+// ImportTodoTxt creates a task for every line of the todo.txt file, lines
+// without a creation date are dated now in loc. A line with the id of a task
+// of the user is a duplicate, like the lines of a file exported from here.
+func (s *ImportService) ImportTodoTxt(userID int64, file io.Reader, loc *time.Location) (model.ImportReport, error) {
+	numbers, lines, err := readTodoLines(file)
+	if err != nil {
+		return model.ImportReport{}, fmt.Errorf("%w", err)
+	}
+	tasks, err := s.tasks.GetAllByUser(userID, model.TaskFilter{IncludeDeferred: true})
+	if err != nil {
+		return model.ImportReport{}, fmt.Errorf("%w", err)
+	}
+	known := make(map[int64]bool, len(tasks))
+	for _, task := range tasks {
+		known[task.ID] = true
+	}
+
+	report := model.ImportReport{Items: make([]model.ImportItem, 0, len(lines))}
+	err = s.tasks.ImportTasks(userID, func(create func(task model.Task) (int64, error)) error {
+		for i, line := range lines {
+			item := model.ImportItem{Ref: fmt.Sprintf("line %d", numbers[i])}
+			task, reason, ok := todoTask(userID, line)
+			item.Text = task.Text
+			switch {
+			case !ok:
+				item.Status, item.Reason = model.ImportInvalid, reason
+			case known[task.ID]:
+				item.Status, item.TaskID = model.ImportDuplicate, task.ID
+			default:
+				task.ID = 0
+				if task.Date.IsZero() {
+					task.Date = wallClock(s.now().In(loc))
+				}
+				var err error
+				item.TaskID, item.Reason, err = importTask(create, task)
+				if err != nil {
+					return err
+				}
+				item.Status = model.ImportCreated
+				if item.Reason != "" {
+					item.Status = model.ImportInvalid
+				}
+			}
+			report.Add(item)
+		}
+		return nil
+	})
+	if err != nil {
+		return model.ImportReport{}, fmt.Errorf("%w", err)
+	}
+	return report, nil
+}
+
+// SyncTodoTxt brings the tasks of the user in line with the todo.txt file.
+// A line with the id of a task of the user updates the task where they
+// differ, the other lines are created like ImportTodoTxt does. Tasks without
+// a line are reported as missing and kept. A dry run only tells what would
+// be done.
+func (s *ImportService) SyncTodoTxt(userID int64, file io.Reader, dryRun bool, loc *time.Location) (model.SyncReport, error) {
+	numbers, lines, err := readTodoLines(file)
+	if err != nil {
+		return model.SyncReport{}, fmt.Errorf("%w", err)
+	}
+	tasks, err := s.tasks.GetAllByUser(userID, model.TaskFilter{IncludeDeferred: true})
+	if err != nil {
+		return model.SyncReport{}, fmt.Errorf("%w", err)
+	}
+	current := make(map[int64]model.Task, len(tasks))
+	for _, task := range tasks {
+		current[task.ID] = task
+	}
+
+	items := make([]model.ImportItem, 0, len(lines))
+	// the new lines are created together once the tasks are updated
+	created := make(map[int]model.Task)
+	seen := make(map[int64]int, len(lines))
+	for i, line := range lines {
+		item := model.ImportItem{Ref: fmt.Sprintf("line %d", numbers[i])}
+		task, reason, ok := todoTask(userID, line)
+		item.Text = task.Text
+		existing, found := current[task.ID]
+		switch {
+		case !ok:
+			item.Status, item.Reason = model.ImportInvalid, reason
+		case found && seen[task.ID] != 0:
+			item.Status = model.ImportInvalid
+			item.Reason = fmt.Sprintf("task %d is on line %d already", task.ID, seen[task.ID])
+		case found:
+			seen[task.ID] = numbers[i]
+			item.TaskID = task.ID
+			// the task goes through the format too, so that only what the
+			// line can tell is compared
+			written, err := lineTask(todoLine(existing))
+			if err != nil {
+				return model.SyncReport{}, fmt.Errorf("%w", err)
+			}
+			item.Changes = taskChanges(written, task)
+			if len(item.Changes) == 0 {
+				item.Status, item.Changes = model.ImportUnchanged, nil
+				break
+			}
+			if task.Date.IsZero() {
+				task.Date = existing.Date
+			}
+			item.Status = model.ImportUpdated
+			if !dryRun {
+				item.Reason, err = s.replaceTask(task)
+				if err != nil {
+					return model.SyncReport{}, fmt.Errorf("%w", err)
+				}
+				if item.Reason != "" {
+					item.Status, item.Changes = model.ImportInvalid, nil
+				}
+			}
+		default:
+			task.ID = 0
+			if task.Date.IsZero() {
+				task.Date = wallClock(s.now().In(loc))
+			}
+			item.Status = model.ImportCreated
+			if dryRun {
+				item.Task = &task
+				break
+			}
+			created[len(items)] = task
+		}
+		items = append(items, item)
+	}
+	if len(created) != 0 {
+		err = s.tasks.ImportTasks(userID, func(create func(task model.Task) (int64, error)) error {
+			for i := range items {
+				task, found := created[i]
+				if !found {
+					continue
+				}
+				var err error
+				items[i].TaskID, items[i].Reason, err = importTask(create, task)
+				if err != nil {
+					return err
+				}
+				if items[i].Reason != "" {
+					items[i].Status = model.ImportInvalid
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return model.SyncReport{}, fmt.Errorf("%w", err)
+		}
+	}
+	report := model.SyncReport{DryRun: dryRun, Items: make([]model.ImportItem, 0, len(items))}
+	for _, item := range items {
+		report.Add(item)
+	}
+	report.Missing = make([]int64, 0)
+	for _, task := range tasks {
+		if seen[task.ID] == 0 {
+			report.Missing = append(report.Missing, task.ID)
+		}
+	}
+	return report, nil
+}
+
+// todoTask reads the task of a todo.txt line. When ok is false, reason tells
+// why it can't be imported.
+func todoTask(userID int64, text string) (task model.Task, reason string, ok bool) {
+	line, err := todotxt.Parse(text)
+	if err != nil {
+		return model.Task{}, err.Error(), false
+	}
+	task, err = lineTask(line)
+	if err != nil {
+		return model.Task{}, err.Error(), false
+	}
+	task.OwnerID = userID
+	if task.Text == "" {
+		return task, "no text", false
+	}
+	if !verification.Task(task) {
+		return task, "incorrect task information", false
+	}
+	return task, "", true
+}
+
+// importTask creates an imported task with create. A task whose project or
+// parent isn't found isn't created, reason tells it.
+func importTask(create func(task model.Task) (int64, error), task model.Task) (taskID int64, reason string, err error) {
+	taskID, err = create(task)
+	switch {
+	case errors.Is(err, repositories.ErrNoProject):
+		return 0, "there no project with this project_id", nil
+	case errors.Is(err, repositories.ErrNoTask):
+		return 0, "there no task with this parent_id", nil
+	}
+	return taskID, "", err
+}
+
+// replaceTask updates a synced task. A task that can't take its project or
+// parent isn't updated, reason tells why.
+func (s *ImportService) replaceTask(task model.Task) (reason string, err error) {
+	err = s.tasks.ReplaceTask(task)
+	switch {
+	case errors.Is(err, repositories.ErrNoProject):
+		return "there no project with this project_id", nil
+	case errors.Is(err, repositories.ErrNoTask):
+		return "there no task with this parent_id", nil
+	case errors.Is(err, repositories.ErrTaskParent):
+		return "the task can't be under itself", nil
+	}
+	return "", err
+}
+
+// knownUIDs maps the UIDs of the entries, and of their parents, that the user
+// already has a task for to the task.
+func (s *ImportService) knownUIDs(userID int64, entries []ical.Entry) (map[string]int64, error) {
+	uids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.UID != "" {
+			uids = append(uids, entry.UID)
+		}
+	}
+	known, err := s.tasks.GetTaskIDsByUID(userID, uids)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		for _, uid := range []string{entry.UID, entry.RelatedTo} {
+			taskID, ok := ownTaskID(uid)
+			if _, found := known[uid]; !ok || found {
+				continue
+			}
+			_, err = s.tasks.GetTask(taskID, userID)
+			if errors.Is(err, repositories.ErrNoTask) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			known[uid] = taskID
+		}
+	}
+	return known, nil
+}
+
+// entryTask makes the task of the entry. reason tells what of the entry was
+// left out, or why it can't be imported at all when ok is false.
+func (s *ImportService) entryTask(userID int64, entry ical.Entry, loc *time.Location) (task model.Task, reason string, ok bool) {
+	if entry.Summary == "" {
+		return model.Task{}, "no summary", false
+	}
+	task = model.Task{
+		Text:    entry.Summary,
+		Tags:    make([]string, 0, len(entry.Categories)),
+		OwnerID: userID,
+		Status:  taskStatuses[entry.Status],
+	}
+	seen := make(map[string]bool, len(entry.Categories))
+	for _, category := range entry.Categories {
+		if category != "" && !seen[category] {
+			seen[category] = true
+			task.Tags = append(task.Tags, category)
+		}
+	}
+	switch {
+	case entry.Start != nil:
+		task.Date = wallClock(*entry.Start)
+	case entry.Due != nil:
+		task.Date = wallClock(*entry.Due)
+	default:
+		task.Date = wallClock(s.now().In(loc))
+	}
+	if entry.Due != nil {
+		due := wallClock(*entry.Due)
+		task.Due = &due
+	}
+	if task.Status == model.TaskStatusDone && entry.Completed != nil {
+		completed := entry.Completed.UTC()
+		task.CompletedAt = &completed
+	}
+	if entry.Priority != 0 {
+		var priority string
+		switch {
+		case entry.Priority < 5:
+			priority = model.TaskPriorityHigh
+		case entry.Priority == 5:
+			priority = model.TaskPriorityMedium
+		default:
+			priority = model.TaskPriorityLow
+		}
+		task.Priority = &priority
+	}
+	if entry.RRule != "" {
+		if rule, err := recurrence.Parse(entry.RRule); err == nil {
+			rrule := rule.String()
+			task.Recurrence = &rrule
+		} else {
+			reason = "recurrence " + entry.RRule + " is not supported, imported without it"
+		}
+	}
+	if entry.UID != "" {
+		uid := entry.UID
+		task.ICalUID = &uid
+	}
+	if !verification.Task(task) {
+		return model.Task{}, "incorrect task information", false
+	}
+	return task, reason, true
+}
+
+// wallClock keeps the wall clock time of t, the way task dates are stored.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// parentsFirst orders the entries so that a parent in the calendar comes
+// before its subtasks, the others keep the order of the calendar.
+func parentsFirst(entries []ical.Entry) []int {
+	index := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		if _, found := index[entry.UID]; entry.UID != "" && !found {
+			index[entry.UID] = i
+		}
+	}
+	order := make([]int, 0, len(entries))
+	visited := make([]bool, len(entries))
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+		if parent, found := index[entries[i].RelatedTo]; found {
+			visit(parent)
+		}
+		order = append(order, i)
+	}
+	for i := range entries {
+		visit(i)
+	}
+	return order
+}
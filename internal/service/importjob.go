@@ -0,0 +1,281 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/todoist"
+	"restAPI/pkg/lib/trello"
+	"time"
+)
+
+var ErrExportFile = errors.New("incorrect export file")
+
+// progressStep is how many records a job goes through between the updates
+// of its progress.
+const progressStep = 50
+
+// ImportJobService imports the export files of other apps in the background.
+// Each record is created together with its ref, which tells it was imported
+// already.
+type ImportJobService struct {
+	jobs repositories.Import
+	now  func() time.Time
+}
+
+func NewImportJobService(jobs repositories.Import) *ImportJobService {
+	return &ImportJobService{
+		jobs: jobs,
+		now:  time.Now,
+	}
+}
+
+// jobBatch holds the records of an export file, read into the model. Records
+// refer to the ones they belong to by their refs, which come before them.
+type jobBatch struct {
+	projects []jobProject
+	tasks    []jobTask
+	items    []jobItem
+	comments []jobComment
+	// problems are the records left out when the file was read
+	problems []jobProblem
+}
+
+// jobRecord is a record of the file, ref is its ID in the file. reason tells
+// what of the record was left out.
+type jobRecord struct {
+	ref    string
+	text   string
+	reason string
+}
+
+type jobProject struct {
+	jobRecord
+	project model.Project
+}
+
+type jobTask struct {
+	jobRecord
+	project string
+	parent  string
+	task    model.Task
+}
+
+type jobItem struct {
+	jobRecord
+	task string
+	item model.TaskItem
+}
+
+type jobComment struct {
+	jobRecord
+	task    string
+	comment model.Comment
+}
+
+type jobProblem struct {
+	kind string
+	item model.ImportItem
+}
+
+func (b jobBatch) size() int {
+	return len(b.projects) + len(b.tasks) + len(b.items) + len(b.comments) + len(b.problems)
+}
+
+// StartImportJob reads the export file of the source and imports it in the
+// background. Times without a zone are read in loc. The records imported by
+// an earlier job are duplicates, so the same file can be uploaded again.
+func (s *ImportJobService) StartImportJob(userID int64, source string, file io.Reader, loc *time.Location) (model.ImportJob, error) {
+	var batch jobBatch
+	switch source {
+	case model.ImportSourceTodoist:
+		export, err := todoist.Decode(file)
+		if err != nil {
+			return model.ImportJob{}, fmt.Errorf("%w: %w", ErrExportFile, err)
+		}
+		batch = todoistBatch(export, loc, s.now())
+	case model.ImportSourceTrello:
+		board, err := trello.Decode(file)
+		if err != nil {
+			return model.ImportJob{}, fmt.Errorf("%w: %w", ErrExportFile, err)
+		}
+		batch = trelloBatch(board, loc, s.now())
+	default:
+		return model.ImportJob{}, fmt.Errorf("%w: unknown source %q", ErrExportFile, source)
+	}
+
+	jobID, err := s.jobs.CreateImportJob(model.ImportJob{OwnerID: userID, Source: source, Total: batch.size()})
+	if err != nil {
+		return model.ImportJob{}, fmt.Errorf("%w", err)
+	}
+	job, err := s.jobs.GetImportJob(jobID, userID)
+	if err != nil {
+		return model.ImportJob{}, fmt.Errorf("%w", err)
+	}
+	go s.run(job, batch)
+	return job, nil
+}
+
+func (s *ImportJobService) GetImportJob(jobID, userID int64) (model.ImportJob, error) {
+	job, err := s.jobs.GetImportJob(jobID, userID)
+	if err != nil {
+		return model.ImportJob{}, fmt.Errorf("%w", err)
+	}
+	return job, nil
+}
+
+// FailRunningImportJobs fails the jobs a stopped server left running, to be
+// called when the server starts.
+func (s *ImportJobService) FailRunningImportJobs() error {
+	if err := s.jobs.FailRunningImportJobs("the server stopped during the import"); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+// jobFailure is the error of a failed job, the errors of the storage are not
+// for the users.
+const jobFailure = "the import stopped on an error, upload the file again to go on from there"
+
+// run imports the batch and records the end of the job. The job fails at the
+// first error of the storage, what it created by then is kept with its refs,
+// so that uploading the file again goes on from there. A job whose end can't
+// be recorded stays running until the server restarts.
+func (s *ImportJobService) run(job model.ImportJob, batch jobBatch) {
+	report := model.ImportJobReport{Problems: make([]model.ImportItem, 0)}
+	job.Status = model.ImportJobDone
+	if err := s.importBatch(&job, batch, &report); err != nil {
+		job.Status, job.Error = model.ImportJobFailed, jobFailure
+	}
+	job.Report = &report
+	_ = s.jobs.FinishImportJob(job)
+}
+
+func (s *ImportJobService) importBatch(job *model.ImportJob, batch jobBatch, report *model.ImportJobReport) error {
+	userID := job.OwnerID
+	refs, err := s.jobs.GetImportRefs(userID, job.Source)
+	if err != nil {
+		return err
+	}
+	local := func(kind, ref string) (int64, bool) {
+		localID, found := refs[model.ImportRef{Kind: kind, ExternalID: ref}]
+		return localID, ref != "" && found
+	}
+
+	// step imports a record with create, unless it was imported already.
+	// create tells whether the record was created with its ref, or why it is
+	// skipped when it can't be.
+	step := func(kind string, record jobRecord, create func(ref model.ImportRef) (int64, bool, string, error)) error {
+		item := model.ImportItem{Ref: kind + " " + record.ref, Text: record.text, Status: model.ImportDuplicate}
+		ref := model.ImportRef{Kind: kind, ExternalID: record.ref}
+		localID, found := local(kind, record.ref)
+		if !found {
+			var created bool
+			var skip string
+			if localID, created, skip, err = create(ref); err != nil {
+				return err
+			}
+			switch {
+			case skip != "":
+				item.Status, item.Reason = model.ImportSkipped, skip
+			case created:
+				item.Status, item.Reason = model.ImportCreated, record.reason
+			}
+			if skip == "" {
+				refs[ref] = localID
+			}
+		}
+		if kind == model.ImportKindTask && item.Status != model.ImportSkipped {
+			item.TaskID = localID
+		}
+		report.Add(kind, item)
+		return s.progress(job)
+	}
+
+	for _, record := range batch.projects {
+		err = step(model.ImportKindProject, record.jobRecord, func(ref model.ImportRef) (int64, bool, string, error) {
+			project := record.project
+			project.OwnerID = userID
+			projectID, created, err := s.jobs.ImportProject(job.Source, ref, project)
+			return projectID, created, "", err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, record := range batch.tasks {
+		err = step(model.ImportKindTask, record.jobRecord, func(ref model.ImportRef) (int64, bool, string, error) {
+			task := record.task
+			task.OwnerID = userID
+			if projectID, found := local(model.ImportKindProject, record.project); found {
+				task.ProjectID = &projectID
+			}
+			if parentID, found := local(model.ImportKindTask, record.parent); found {
+				task.ParentID = &parentID
+			}
+			taskID, created, err := s.jobs.ImportTask(job.Source, ref, task)
+			if errors.Is(err, repositories.ErrNoProject) || errors.Is(err, repositories.ErrNoTask) {
+				return 0, false, "its project or parent was deleted during the import", nil
+			}
+			return taskID, created, "", err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, record := range batch.items {
+		err = step(model.ImportKindItem, record.jobRecord, func(ref model.ImportRef) (int64, bool, string, error) {
+			taskID, found := local(model.ImportKindTask, record.task)
+			if !found {
+				return 0, false, "its task isn't imported", nil
+			}
+			item := record.item
+			item.TaskID = taskID
+			itemID, created, err := s.jobs.ImportItem(userID, job.Source, ref, item)
+			if errors.Is(err, repositories.ErrNoTask) {
+				return 0, false, "its task was deleted during the import", nil
+			}
+			return itemID, created, "", err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, record := range batch.comments {
+		err = step(model.ImportKindComment, record.jobRecord, func(ref model.ImportRef) (int64, bool, string, error) {
+			taskID, found := local(model.ImportKindTask, record.task)
+			if !found {
+				return 0, false, "its task isn't imported", nil
+			}
+			comment := record.comment
+			comment.TaskID, comment.AuthorID = taskID, userID
+			commentID, created, err := s.jobs.ImportComment(job.Source, ref, comment)
+			if errors.Is(err, repositories.ErrNoTask) {
+				return 0, false, "its task was deleted during the import", nil
+			}
+			return commentID, created, "", err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, problem := range batch.problems {
+		report.Add(problem.kind, problem.item)
+		if err = s.progress(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// progress counts a record gone through and records it every progressStep
+// records.
+func (s *ImportJobService) progress(job *model.ImportJob) error {
+	job.Done++
+	if job.Done%progressStep != 0 {
+		return nil
+	}
+	return s.jobs.SetImportProgress(job.ID, job.Done)
+}
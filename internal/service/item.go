@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+)
+
+type ItemService struct {
+	rep repositories.Item
+}
+
+func NewItemService(rep repositories.Item) *ItemService {
+	return &ItemService{
+		rep: rep,
+	}
+}
+
+func (s *ItemService) CreateItem(item model.TaskItem, userID int64) (int64, error) {
+	id, err := s.rep.CreateItem(item, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	return id, nil
+}
+
+func (s *ItemService) GetItems(taskID, userID int64) ([]model.TaskItem, error) {
+	items, err := s.rep.GetItems(taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return items, nil
+}
+
+func (s *ItemService) UpdateItem(item model.TaskItem, userID int64) error {
+	err := s.rep.UpdateItem(item, userID)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *ItemService) DeleteItem(taskID, itemID, userID int64) error {
+	err := s.rep.DeleteItem(taskID, itemID, userID)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *ItemService) MoveItem(taskID, itemID, userID int64, after, before *int64) error {
+	err := s.rep.MoveItem(taskID, itemID, userID, after, before)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
@@ -10,8 +10,11 @@
 package mock_service
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
 	model "restAPI/internal/model"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -96,19 +99,34 @@ func (mr *MockTaskMockRecorder) DeleteTask(taskID, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTask)(nil).DeleteTask), taskID, userID)
 }
 
+// DuplicateTask mocks base method.
+func (m *MockTask) DuplicateTask(taskID, userID int64, options model.DuplicateOptions) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DuplicateTask", taskID, userID, options)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DuplicateTask indicates an expected call of DuplicateTask.
+func (mr *MockTaskMockRecorder) DuplicateTask(taskID, userID, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateTask", reflect.TypeOf((*MockTask)(nil).DuplicateTask), taskID, userID, options)
+}
+
 // GetAllByUser mocks base method.
-func (m *MockTask) GetAllByUser(userID int64) ([]model.Task, error) {
+func (m *MockTask) GetAllByUser(userID int64, filter model.TaskFilter) ([]model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllByUser", userID)
+	ret := m.ctrl.Call(m, "GetAllByUser", userID, filter)
 	ret0, _ := ret[0].([]model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllByUser indicates an expected call of GetAllByUser.
-func (mr *MockTaskMockRecorder) GetAllByUser(userID any) *gomock.Call {
+func (mr *MockTaskMockRecorder) GetAllByUser(userID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUser", reflect.TypeOf((*MockTask)(nil).GetAllByUser), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUser", reflect.TypeOf((*MockTask)(nil).GetAllByUser), userID, filter)
 }
 
 // GetAllTasks mocks base method.
@@ -126,6 +144,21 @@ func (mr *MockTaskMockRecorder) GetAllTasks() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTask)(nil).GetAllTasks))
 }
 
+// GetCalendarMonth mocks base method.
+func (m *MockTask) GetCalendarMonth(month, year int, userID int64, filter model.TaskFilter) (model.CalendarMonth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarMonth", month, year, userID, filter)
+	ret0, _ := ret[0].(model.CalendarMonth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarMonth indicates an expected call of GetCalendarMonth.
+func (mr *MockTaskMockRecorder) GetCalendarMonth(month, year, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarMonth", reflect.TypeOf((*MockTask)(nil).GetCalendarMonth), month, year, userID, filter)
+}
+
 // GetTask mocks base method.
 func (m *MockTask) GetTask(taskID, userID int64) (model.Task, error) {
 	m.ctrl.T.Helper()
@@ -141,48 +174,162 @@ func (mr *MockTaskMockRecorder) GetTask(taskID, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTask)(nil).GetTask), taskID, userID)
 }
 
+// GetTaskIDsByUID mocks base method.
+func (m *MockTask) GetTaskIDsByUID(userID int64, uids []string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskIDsByUID", userID, uids)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskIDsByUID indicates an expected call of GetTaskIDsByUID.
+func (mr *MockTaskMockRecorder) GetTaskIDsByUID(userID, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskIDsByUID", reflect.TypeOf((*MockTask)(nil).GetTaskIDsByUID), userID, uids)
+}
+
 // GetTasksByDate mocks base method.
-func (m *MockTask) GetTasksByDate(day, month, year int, userID int64) ([]model.Task, error) {
+func (m *MockTask) GetTasksByDate(day, month, year int, userID int64, filter model.TaskFilter) ([]model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasksByDate", day, month, year, userID)
+	ret := m.ctrl.Call(m, "GetTasksByDate", day, month, year, userID, filter)
 	ret0, _ := ret[0].([]model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasksByDate indicates an expected call of GetTasksByDate.
-func (mr *MockTaskMockRecorder) GetTasksByDate(day, month, year, userID any) *gomock.Call {
+func (mr *MockTaskMockRecorder) GetTasksByDate(day, month, year, userID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByDate", reflect.TypeOf((*MockTask)(nil).GetTasksByDate), day, month, year, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByDate", reflect.TypeOf((*MockTask)(nil).GetTasksByDate), day, month, year, userID, filter)
 }
 
 // GetTasksByTag mocks base method.
-func (m *MockTask) GetTasksByTag(tag string, userID int64) ([]model.Task, error) {
+func (m *MockTask) GetTasksByTag(tag string, userID int64, filter model.TaskFilter) ([]model.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasksByTag", tag, userID)
+	ret := m.ctrl.Call(m, "GetTasksByTag", tag, userID, filter)
 	ret0, _ := ret[0].([]model.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasksByTag indicates an expected call of GetTasksByTag.
-func (mr *MockTaskMockRecorder) GetTasksByTag(tag, userID any) *gomock.Call {
+func (mr *MockTaskMockRecorder) GetTasksByTag(tag, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByTag", reflect.TypeOf((*MockTask)(nil).GetTasksByTag), tag, userID, filter)
+}
+
+// ImportTasks mocks base method.
+func (m *MockTask) ImportTasks(userID int64, fn func(func(model.Task) (int64, error)) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTasks", userID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportTasks indicates an expected call of ImportTasks.
+func (mr *MockTaskMockRecorder) ImportTasks(userID, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTasks", reflect.TypeOf((*MockTask)(nil).ImportTasks), userID, fn)
+}
+
+// MoveTask mocks base method.
+func (m *MockTask) MoveTask(taskID, userID int64, after, before *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", taskID, userID, after, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockTaskMockRecorder) MoveTask(taskID, userID, after, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockTask)(nil).MoveTask), taskID, userID, after, before)
+}
+
+// MoveTasks mocks base method.
+func (m *MockTask) MoveTasks(userID int64, move model.TaskMove) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTasks", userID, move)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTasks indicates an expected call of MoveTasks.
+func (mr *MockTaskMockRecorder) MoveTasks(userID, move any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTasks", reflect.TypeOf((*MockTask)(nil).MoveTasks), userID, move)
+}
+
+// ReplaceTask mocks base method.
+func (m *MockTask) ReplaceTask(task model.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTask", task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTask indicates an expected call of ReplaceTask.
+func (mr *MockTaskMockRecorder) ReplaceTask(task any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTask", reflect.TypeOf((*MockTask)(nil).ReplaceTask), task)
+}
+
+// SnoozeTask mocks base method.
+func (m *MockTask) SnoozeTask(taskID, userID int64, until *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnoozeTask", taskID, userID, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SnoozeTask indicates an expected call of SnoozeTask.
+func (mr *MockTaskMockRecorder) SnoozeTask(taskID, userID, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeTask", reflect.TypeOf((*MockTask)(nil).SnoozeTask), taskID, userID, until)
+}
+
+// StreamTasks mocks base method.
+func (m *MockTask) StreamTasks(ctx context.Context, stream model.TaskStream, fn func(model.TaskRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamTasks", ctx, stream, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamTasks indicates an expected call of StreamTasks.
+func (mr *MockTaskMockRecorder) StreamTasks(ctx, stream, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamTasks", reflect.TypeOf((*MockTask)(nil).StreamTasks), ctx, stream, fn)
+}
+
+// Undo mocks base method.
+func (m *MockTask) Undo(userID int64) (model.UndoResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Undo", userID)
+	ret0, _ := ret[0].(model.UndoResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Undo indicates an expected call of Undo.
+func (mr *MockTaskMockRecorder) Undo(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByTag", reflect.TypeOf((*MockTask)(nil).GetTasksByTag), tag, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockTask)(nil).Undo), userID)
 }
 
 // UpdateTask mocks base method.
-func (m *MockTask) UpdateTask(taskID, userID int64, Text string, Tags []string) error {
+func (m *MockTask) UpdateTask(taskID, userID int64, Text string, Tags []string, Estimate *int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", taskID, userID, Text, Tags)
+	ret := m.ctrl.Call(m, "UpdateTask", taskID, userID, Text, Tags, Estimate)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskMockRecorder) UpdateTask(taskID, userID, Text, Tags any) *gomock.Call {
+func (mr *MockTaskMockRecorder) UpdateTask(taskID, userID, Text, Tags, Estimate any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTask)(nil).UpdateTask), taskID, userID, Text, Tags)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTask)(nil).UpdateTask), taskID, userID, Text, Tags, Estimate)
 }
 
 // MockAuthorization is a mock of Authorization interface.
@@ -252,3 +399,1255 @@ func (mr *MockAuthorizationMockRecorder) ParseToken(inputToken any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAuthorization)(nil).ParseToken), inputToken)
 }
+
+// MockProject is a mock of Project interface.
+type MockProject struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectMockRecorder
+}
+
+// MockProjectMockRecorder is the mock recorder for MockProject.
+type MockProjectMockRecorder struct {
+	mock *MockProject
+}
+
+// NewMockProject creates a new mock instance.
+func NewMockProject(ctrl *gomock.Controller) *MockProject {
+	mock := &MockProject{ctrl: ctrl}
+	mock.recorder = &MockProjectMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProject) EXPECT() *MockProjectMockRecorder {
+	return m.recorder
+}
+
+// CreateProject mocks base method.
+func (m *MockProject) CreateProject(project model.Project) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", project)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockProjectMockRecorder) CreateProject(project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockProject)(nil).CreateProject), project)
+}
+
+// DeleteProject mocks base method.
+func (m *MockProject) DeleteProject(projectID, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", projectID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockProjectMockRecorder) DeleteProject(projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockProject)(nil).DeleteProject), projectID, userID)
+}
+
+// GetAllProjects mocks base method.
+func (m *MockProject) GetAllProjects(userID int64, withArchived bool) ([]model.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllProjects", userID, withArchived)
+	ret0, _ := ret[0].([]model.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllProjects indicates an expected call of GetAllProjects.
+func (mr *MockProjectMockRecorder) GetAllProjects(userID, withArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProjects", reflect.TypeOf((*MockProject)(nil).GetAllProjects), userID, withArchived)
+}
+
+// GetProject mocks base method.
+func (m *MockProject) GetProject(projectID, userID int64) (model.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProject", projectID, userID)
+	ret0, _ := ret[0].(model.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProject indicates an expected call of GetProject.
+func (mr *MockProjectMockRecorder) GetProject(projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockProject)(nil).GetProject), projectID, userID)
+}
+
+// GetProjectTasks mocks base method.
+func (m *MockProject) GetProjectTasks(projectID, userID int64, filter model.TaskFilter) ([]model.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectTasks", projectID, userID, filter)
+	ret0, _ := ret[0].([]model.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectTasks indicates an expected call of GetProjectTasks.
+func (mr *MockProjectMockRecorder) GetProjectTasks(projectID, userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectTasks", reflect.TypeOf((*MockProject)(nil).GetProjectTasks), projectID, userID, filter)
+}
+
+// SetTaskProject mocks base method.
+func (m *MockProject) SetTaskProject(taskID, userID int64, projectID *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskProject", taskID, userID, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskProject indicates an expected call of SetTaskProject.
+func (mr *MockProjectMockRecorder) SetTaskProject(taskID, userID, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskProject", reflect.TypeOf((*MockProject)(nil).SetTaskProject), taskID, userID, projectID)
+}
+
+// UpdateProject mocks base method.
+func (m *MockProject) UpdateProject(project model.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockProjectMockRecorder) UpdateProject(project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProject)(nil).UpdateProject), project)
+}
+
+// MockBoard is a mock of Board interface.
+type MockBoard struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardMockRecorder
+}
+
+// MockBoardMockRecorder is the mock recorder for MockBoard.
+type MockBoardMockRecorder struct {
+	mock *MockBoard
+}
+
+// NewMockBoard creates a new mock instance.
+func NewMockBoard(ctrl *gomock.Controller) *MockBoard {
+	mock := &MockBoard{ctrl: ctrl}
+	mock.recorder = &MockBoardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoard) EXPECT() *MockBoardMockRecorder {
+	return m.recorder
+}
+
+// CreateBoard mocks base method.
+func (m *MockBoard) CreateBoard(board model.Board) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoard", board)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoard indicates an expected call of CreateBoard.
+func (mr *MockBoardMockRecorder) CreateBoard(board any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoard", reflect.TypeOf((*MockBoard)(nil).CreateBoard), board)
+}
+
+// CreateColumn mocks base method.
+func (m *MockBoard) CreateColumn(column model.BoardColumn, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateColumn", column, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateColumn indicates an expected call of CreateColumn.
+func (mr *MockBoardMockRecorder) CreateColumn(column, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateColumn", reflect.TypeOf((*MockBoard)(nil).CreateColumn), column, userID)
+}
+
+// DeleteBoard mocks base method.
+func (m *MockBoard) DeleteBoard(boardID, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoard", boardID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoard indicates an expected call of DeleteBoard.
+func (mr *MockBoardMockRecorder) DeleteBoard(boardID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoard", reflect.TypeOf((*MockBoard)(nil).DeleteBoard), boardID, userID)
+}
+
+// GetBoard mocks base method.
+func (m *MockBoard) GetBoard(boardID, userID int64) (model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", boardID, userID)
+	ret0, _ := ret[0].(model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockBoardMockRecorder) GetBoard(boardID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockBoard)(nil).GetBoard), boardID, userID)
+}
+
+// GetBoards mocks base method.
+func (m *MockBoard) GetBoards(userID int64) ([]model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoards", userID)
+	ret0, _ := ret[0].([]model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoards indicates an expected call of GetBoards.
+func (mr *MockBoardMockRecorder) GetBoards(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoards", reflect.TypeOf((*MockBoard)(nil).GetBoards), userID)
+}
+
+// MoveCard mocks base method.
+func (m *MockBoard) MoveCard(boardID, taskID, userID int64, move model.CardMove) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCard", boardID, taskID, userID, move)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCard indicates an expected call of MoveCard.
+func (mr *MockBoardMockRecorder) MoveCard(boardID, taskID, userID, move any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCard", reflect.TypeOf((*MockBoard)(nil).MoveCard), boardID, taskID, userID, move)
+}
+
+// RemoveCard mocks base method.
+func (m *MockBoard) RemoveCard(boardID, taskID, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCard", boardID, taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCard indicates an expected call of RemoveCard.
+func (mr *MockBoardMockRecorder) RemoveCard(boardID, taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCard", reflect.TypeOf((*MockBoard)(nil).RemoveCard), boardID, taskID, userID)
+}
+
+// MockItem is a mock of Item interface.
+type MockItem struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemMockRecorder
+}
+
+// MockItemMockRecorder is the mock recorder for MockItem.
+type MockItemMockRecorder struct {
+	mock *MockItem
+}
+
+// NewMockItem creates a new mock instance.
+func NewMockItem(ctrl *gomock.Controller) *MockItem {
+	mock := &MockItem{ctrl: ctrl}
+	mock.recorder = &MockItemMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItem) EXPECT() *MockItemMockRecorder {
+	return m.recorder
+}
+
+// CreateItem mocks base method.
+func (m *MockItem) CreateItem(item model.TaskItem, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItem", item, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItem indicates an expected call of CreateItem.
+func (mr *MockItemMockRecorder) CreateItem(item, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockItem)(nil).CreateItem), item, userID)
+}
+
+// DeleteItem mocks base method.
+func (m *MockItem) DeleteItem(taskID, itemID, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", taskID, itemID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockItemMockRecorder) DeleteItem(taskID, itemID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockItem)(nil).DeleteItem), taskID, itemID, userID)
+}
+
+// GetItems mocks base method.
+func (m *MockItem) GetItems(taskID, userID int64) ([]model.TaskItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", taskID, userID)
+	ret0, _ := ret[0].([]model.TaskItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockItemMockRecorder) GetItems(taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockItem)(nil).GetItems), taskID, userID)
+}
+
+// MoveItem mocks base method.
+func (m *MockItem) MoveItem(taskID, itemID, userID int64, after, before *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItem", taskID, itemID, userID, after, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveItem indicates an expected call of MoveItem.
+func (mr *MockItemMockRecorder) MoveItem(taskID, itemID, userID, after, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItem", reflect.TypeOf((*MockItem)(nil).MoveItem), taskID, itemID, userID, after, before)
+}
+
+// UpdateItem mocks base method.
+func (m *MockItem) UpdateItem(item model.TaskItem, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", item, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockItemMockRecorder) UpdateItem(item, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItem)(nil).UpdateItem), item, userID)
+}
+
+// MockComment is a mock of Comment interface.
+type MockComment struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentMockRecorder
+}
+
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
+}
+
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockComment) CreateComment(comment model.Comment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", comment)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentMockRecorder) CreateComment(comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockComment)(nil).CreateComment), comment)
+}
+
+// DeleteComment mocks base method.
+func (m *MockComment) DeleteComment(taskID, commentID, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", taskID, commentID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentMockRecorder) DeleteComment(taskID, commentID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockComment)(nil).DeleteComment), taskID, commentID, userID)
+}
+
+// GetComments mocks base method.
+func (m *MockComment) GetComments(taskID, userID int64, page model.Page) ([]model.Comment, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", taskID, userID, page)
+	ret0, _ := ret[0].([]model.Comment)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentMockRecorder) GetComments(taskID, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockComment)(nil).GetComments), taskID, userID, page)
+}
+
+// UpdateComment mocks base method.
+func (m *MockComment) UpdateComment(comment model.Comment, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", comment, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockCommentMockRecorder) UpdateComment(comment, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockComment)(nil).UpdateComment), comment, userID)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// CreateAttachment mocks base method.
+func (m *MockAttachment) CreateAttachment(ctx context.Context, attachment model.Attachment, data []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, attachment, data)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockAttachmentMockRecorder) CreateAttachment(ctx, attachment, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockAttachment)(nil).CreateAttachment), ctx, attachment, data)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachment) DeleteAttachment(ctx context.Context, taskID, attachmentID, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, taskID, attachmentID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentMockRecorder) DeleteAttachment(ctx, taskID, attachmentID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachment)(nil).DeleteAttachment), ctx, taskID, attachmentID, userID)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAttachment) GetAttachments(taskID, userID int64) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", taskID, userID)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAttachmentMockRecorder) GetAttachments(taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAttachment)(nil).GetAttachments), taskID, userID)
+}
+
+// OpenAttachment mocks base method.
+func (m *MockAttachment) OpenAttachment(ctx context.Context, taskID, attachmentID, userID int64) (model.Attachment, io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAttachment", ctx, taskID, attachmentID, userID)
+	ret0, _ := ret[0].(model.Attachment)
+	ret1, _ := ret[1].(io.ReadSeekCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenAttachment indicates an expected call of OpenAttachment.
+func (mr *MockAttachmentMockRecorder) OpenAttachment(ctx, taskID, attachmentID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAttachment", reflect.TypeOf((*MockAttachment)(nil).OpenAttachment), ctx, taskID, attachmentID, userID)
+}
+
+// MockTime is a mock of Time interface.
+type MockTime struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeMockRecorder
+}
+
+// MockTimeMockRecorder is the mock recorder for MockTime.
+type MockTimeMockRecorder struct {
+	mock *MockTime
+}
+
+// NewMockTime creates a new mock instance.
+func NewMockTime(ctrl *gomock.Controller) *MockTime {
+	mock := &MockTime{ctrl: ctrl}
+	mock.recorder = &MockTimeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTime) EXPECT() *MockTimeMockRecorder {
+	return m.recorder
+}
+
+// CreateTimeEntry mocks base method.
+func (m *MockTime) CreateTimeEntry(entry model.TimeEntry) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTimeEntry", entry)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTimeEntry indicates an expected call of CreateTimeEntry.
+func (mr *MockTimeMockRecorder) CreateTimeEntry(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTimeEntry", reflect.TypeOf((*MockTime)(nil).CreateTimeEntry), entry)
+}
+
+// DeleteTimeEntry mocks base method.
+func (m *MockTime) DeleteTimeEntry(taskID, entryID, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTimeEntry", taskID, entryID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTimeEntry indicates an expected call of DeleteTimeEntry.
+func (mr *MockTimeMockRecorder) DeleteTimeEntry(taskID, entryID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimeEntry", reflect.TypeOf((*MockTime)(nil).DeleteTimeEntry), taskID, entryID, userID)
+}
+
+// GetTimeEntries mocks base method.
+func (m *MockTime) GetTimeEntries(taskID, userID int64) ([]model.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeEntries", taskID, userID)
+	ret0, _ := ret[0].([]model.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeEntries indicates an expected call of GetTimeEntries.
+func (mr *MockTimeMockRecorder) GetTimeEntries(taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeEntries", reflect.TypeOf((*MockTime)(nil).GetTimeEntries), taskID, userID)
+}
+
+// StartTimer mocks base method.
+func (m *MockTime) StartTimer(taskID, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", taskID, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockTimeMockRecorder) StartTimer(taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockTime)(nil).StartTimer), taskID, userID)
+}
+
+// StopTimer mocks base method.
+func (m *MockTime) StopTimer(taskID, userID int64) (model.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", taskID, userID)
+	ret0, _ := ret[0].(model.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockTimeMockRecorder) StopTimer(taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockTime)(nil).StopTimer), taskID, userID)
+}
+
+// TimeReport mocks base method.
+func (m *MockTime) TimeReport(userID int64, from, to time.Time, group string) (model.TimeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeReport", userID, from, to, group)
+	ret0, _ := ret[0].(model.TimeReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TimeReport indicates an expected call of TimeReport.
+func (mr *MockTimeMockRecorder) TimeReport(userID, from, to, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeReport", reflect.TypeOf((*MockTime)(nil).TimeReport), userID, from, to, group)
+}
+
+// MockAnalytics is a mock of Analytics interface.
+type MockAnalytics struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsMockRecorder
+}
+
+// MockAnalyticsMockRecorder is the mock recorder for MockAnalytics.
+type MockAnalyticsMockRecorder struct {
+	mock *MockAnalytics
+}
+
+// NewMockAnalytics creates a new mock instance.
+func NewMockAnalytics(ctrl *gomock.Controller) *MockAnalytics {
+	mock := &MockAnalytics{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalytics) EXPECT() *MockAnalyticsMockRecorder {
+	return m.recorder
+}
+
+// ActivityHeatmap mocks base method.
+func (m *MockAnalytics) ActivityHeatmap(userID int64, year int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivityHeatmap", userID, year)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivityHeatmap indicates an expected call of ActivityHeatmap.
+func (mr *MockAnalyticsMockRecorder) ActivityHeatmap(userID, year any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivityHeatmap", reflect.TypeOf((*MockAnalytics)(nil).ActivityHeatmap), userID, year)
+}
+
+// EstimateReport mocks base method.
+func (m *MockAnalytics) EstimateReport(userID int64, from, to time.Time, group string) (model.EstimateReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateReport", userID, from, to, group)
+	ret0, _ := ret[0].(model.EstimateReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateReport indicates an expected call of EstimateReport.
+func (mr *MockAnalyticsMockRecorder) EstimateReport(userID, from, to, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateReport", reflect.TypeOf((*MockAnalytics)(nil).EstimateReport), userID, from, to, group)
+}
+
+// ProjectBurndown mocks base method.
+func (m *MockAnalytics) ProjectBurndown(projectID, userID int64, days int, loc *time.Location) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectBurndown", projectID, userID, days, loc)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectBurndown indicates an expected call of ProjectBurndown.
+func (mr *MockAnalyticsMockRecorder) ProjectBurndown(projectID, userID, days, loc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectBurndown", reflect.TypeOf((*MockAnalytics)(nil).ProjectBurndown), projectID, userID, days, loc)
+}
+
+// StatsReport mocks base method.
+func (m *MockAnalytics) StatsReport(userID int64, from, to time.Time, bucket string, loc *time.Location) (model.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatsReport", userID, from, to, bucket, loc)
+	ret0, _ := ret[0].(model.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatsReport indicates an expected call of StatsReport.
+func (mr *MockAnalyticsMockRecorder) StatsReport(userID, from, to, bucket, loc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatsReport", reflect.TypeOf((*MockAnalytics)(nil).StatsReport), userID, from, to, bucket, loc)
+}
+
+// MockAgenda is a mock of Agenda interface.
+type MockAgenda struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgendaMockRecorder
+}
+
+// MockAgendaMockRecorder is the mock recorder for MockAgenda.
+type MockAgendaMockRecorder struct {
+	mock *MockAgenda
+}
+
+// NewMockAgenda creates a new mock instance.
+func NewMockAgenda(ctrl *gomock.Controller) *MockAgenda {
+	mock := &MockAgenda{ctrl: ctrl}
+	mock.recorder = &MockAgendaMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgenda) EXPECT() *MockAgendaMockRecorder {
+	return m.recorder
+}
+
+// GetAgenda mocks base method.
+func (m *MockAgenda) GetAgenda(userID int64, days int, loc *time.Location) (model.Agenda, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgenda", userID, days, loc)
+	ret0, _ := ret[0].(model.Agenda)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgenda indicates an expected call of GetAgenda.
+func (mr *MockAgendaMockRecorder) GetAgenda(userID, days, loc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgenda", reflect.TypeOf((*MockAgenda)(nil).GetAgenda), userID, days, loc)
+}
+
+// MockTemplate is a mock of Template interface.
+type MockTemplate struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateMockRecorder
+}
+
+// MockTemplateMockRecorder is the mock recorder for MockTemplate.
+type MockTemplateMockRecorder struct {
+	mock *MockTemplate
+}
+
+// NewMockTemplate creates a new mock instance.
+func NewMockTemplate(ctrl *gomock.Controller) *MockTemplate {
+	mock := &MockTemplate{ctrl: ctrl}
+	mock.recorder = &MockTemplateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplate) EXPECT() *MockTemplateMockRecorder {
+	return m.recorder
+}
+
+// CreateTemplate mocks base method.
+func (m *MockTemplate) CreateTemplate(template model.Template) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", template)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockTemplateMockRecorder) CreateTemplate(template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockTemplate)(nil).CreateTemplate), template)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockTemplate) DeleteTemplate(templateID, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", templateID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockTemplateMockRecorder) DeleteTemplate(templateID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockTemplate)(nil).DeleteTemplate), templateID, userID)
+}
+
+// GetTemplate mocks base method.
+func (m *MockTemplate) GetTemplate(templateID, userID int64) (model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", templateID, userID)
+	ret0, _ := ret[0].(model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockTemplateMockRecorder) GetTemplate(templateID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockTemplate)(nil).GetTemplate), templateID, userID)
+}
+
+// GetTemplates mocks base method.
+func (m *MockTemplate) GetTemplates(userID int64) ([]model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplates", userID)
+	ret0, _ := ret[0].([]model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplates indicates an expected call of GetTemplates.
+func (mr *MockTemplateMockRecorder) GetTemplates(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplates", reflect.TypeOf((*MockTemplate)(nil).GetTemplates), userID)
+}
+
+// InstantiateTemplate mocks base method.
+func (m *MockTemplate) InstantiateTemplate(templateID, userID int64, instance model.TemplateInstance) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstantiateTemplate", templateID, userID, instance)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstantiateTemplate indicates an expected call of InstantiateTemplate.
+func (mr *MockTemplateMockRecorder) InstantiateTemplate(templateID, userID, instance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstantiateTemplate", reflect.TypeOf((*MockTemplate)(nil).InstantiateTemplate), templateID, userID, instance)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockTemplate) UpdateTemplate(template model.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockTemplateMockRecorder) UpdateTemplate(template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockTemplate)(nil).UpdateTemplate), template)
+}
+
+// MockExport is a mock of Export interface.
+type MockExport struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportMockRecorder
+}
+
+// MockExportMockRecorder is the mock recorder for MockExport.
+type MockExportMockRecorder struct {
+	mock *MockExport
+}
+
+// NewMockExport creates a new mock instance.
+func NewMockExport(ctrl *gomock.Controller) *MockExport {
+	mock := &MockExport{ctrl: ctrl}
+	mock.recorder = &MockExportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExport) EXPECT() *MockExportMockRecorder {
+	return m.recorder
+}
+
+// ExportCSV mocks base method.
+func (m *MockExport) ExportCSV(userID int64, columns []string, tagDelimiter string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCSV", userID, columns, tagDelimiter)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCSV indicates an expected call of ExportCSV.
+func (mr *MockExportMockRecorder) ExportCSV(userID, columns, tagDelimiter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCSV", reflect.TypeOf((*MockExport)(nil).ExportCSV), userID, columns, tagDelimiter)
+}
+
+// ExportICS mocks base method.
+func (m *MockExport) ExportICS(userID int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportICS", userID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportICS indicates an expected call of ExportICS.
+func (mr *MockExportMockRecorder) ExportICS(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportICS", reflect.TypeOf((*MockExport)(nil).ExportICS), userID)
+}
+
+// ExportTodoTxt mocks base method.
+func (m *MockExport) ExportTodoTxt(userID int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTodoTxt", userID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTodoTxt indicates an expected call of ExportTodoTxt.
+func (mr *MockExportMockRecorder) ExportTodoTxt(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTodoTxt", reflect.TypeOf((*MockExport)(nil).ExportTodoTxt), userID)
+}
+
+// MockFeed is a mock of Feed interface.
+type MockFeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedMockRecorder
+}
+
+// MockFeedMockRecorder is the mock recorder for MockFeed.
+type MockFeedMockRecorder struct {
+	mock *MockFeed
+}
+
+// NewMockFeed creates a new mock instance.
+func NewMockFeed(ctrl *gomock.Controller) *MockFeed {
+	mock := &MockFeed{ctrl: ctrl}
+	mock.recorder = &MockFeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeed) EXPECT() *MockFeedMockRecorder {
+	return m.recorder
+}
+
+// FeedUser mocks base method.
+func (m *MockFeed) FeedUser(token string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeedUser", token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FeedUser indicates an expected call of FeedUser.
+func (mr *MockFeedMockRecorder) FeedUser(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeedUser", reflect.TypeOf((*MockFeed)(nil).FeedUser), token)
+}
+
+// RevokeFeedToken mocks base method.
+func (m *MockFeed) RevokeFeedToken(userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFeedToken", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFeedToken indicates an expected call of RevokeFeedToken.
+func (mr *MockFeedMockRecorder) RevokeFeedToken(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFeedToken", reflect.TypeOf((*MockFeed)(nil).RevokeFeedToken), userID)
+}
+
+// RotateFeedToken mocks base method.
+func (m *MockFeed) RotateFeedToken(userID int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateFeedToken", userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateFeedToken indicates an expected call of RotateFeedToken.
+func (mr *MockFeedMockRecorder) RotateFeedToken(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateFeedToken", reflect.TypeOf((*MockFeed)(nil).RotateFeedToken), userID)
+}
+
+// MockImport is a mock of Import interface.
+type MockImport struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportMockRecorder
+}
+
+// MockImportMockRecorder is the mock recorder for MockImport.
+type MockImportMockRecorder struct {
+	mock *MockImport
+}
+
+// NewMockImport creates a new mock instance.
+func NewMockImport(ctrl *gomock.Controller) *MockImport {
+	mock := &MockImport{ctrl: ctrl}
+	mock.recorder = &MockImportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImport) EXPECT() *MockImportMockRecorder {
+	return m.recorder
+}
+
+// ImportCSV mocks base method.
+func (m *MockImport) ImportCSV(userID int64, file io.Reader, options model.CSVImport, loc *time.Location) (model.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCSV", userID, file, options, loc)
+	ret0, _ := ret[0].(model.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportCSV indicates an expected call of ImportCSV.
+func (mr *MockImportMockRecorder) ImportCSV(userID, file, options, loc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCSV", reflect.TypeOf((*MockImport)(nil).ImportCSV), userID, file, options, loc)
+}
+
+// ImportICS mocks base method.
+func (m *MockImport) ImportICS(userID int64, calendar io.Reader, events bool, loc *time.Location) (model.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportICS", userID, calendar, events, loc)
+	ret0, _ := ret[0].(model.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportICS indicates an expected call of ImportICS.
+func (mr *MockImportMockRecorder) ImportICS(userID, calendar, events, loc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportICS", reflect.TypeOf((*MockImport)(nil).ImportICS), userID, calendar, events, loc)
+}
+
+// ImportTodoTxt mocks base method.
+func (m *MockImport) ImportTodoTxt(userID int64, file io.Reader, loc *time.Location) (model.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTodoTxt", userID, file, loc)
+	ret0, _ := ret[0].(model.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTodoTxt indicates an expected call of ImportTodoTxt.
+func (mr *MockImportMockRecorder) ImportTodoTxt(userID, file, loc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTodoTxt", reflect.TypeOf((*MockImport)(nil).ImportTodoTxt), userID, file, loc)
+}
+
+// SyncTodoTxt mocks base method.
+func (m *MockImport) SyncTodoTxt(userID int64, file io.Reader, dryRun bool, loc *time.Location) (model.SyncReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncTodoTxt", userID, file, dryRun, loc)
+	ret0, _ := ret[0].(model.SyncReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncTodoTxt indicates an expected call of SyncTodoTxt.
+func (mr *MockImportMockRecorder) SyncTodoTxt(userID, file, dryRun, loc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncTodoTxt", reflect.TypeOf((*MockImport)(nil).SyncTodoTxt), userID, file, dryRun, loc)
+}
+
+// MockImportJob is a mock of ImportJob interface.
+type MockImportJob struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportJobMockRecorder
+}
+
+// MockImportJobMockRecorder is the mock recorder for MockImportJob.
+type MockImportJobMockRecorder struct {
+	mock *MockImportJob
+}
+
+// NewMockImportJob creates a new mock instance.
+func NewMockImportJob(ctrl *gomock.Controller) *MockImportJob {
+	mock := &MockImportJob{ctrl: ctrl}
+	mock.recorder = &MockImportJobMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportJob) EXPECT() *MockImportJobMockRecorder {
+	return m.recorder
+}
+
+// FailRunningImportJobs mocks base method.
+func (m *MockImportJob) FailRunningImportJobs() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailRunningImportJobs")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailRunningImportJobs indicates an expected call of FailRunningImportJobs.
+func (mr *MockImportJobMockRecorder) FailRunningImportJobs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailRunningImportJobs", reflect.TypeOf((*MockImportJob)(nil).FailRunningImportJobs))
+}
+
+// GetImportJob mocks base method.
+func (m *MockImportJob) GetImportJob(jobID, userID int64) (model.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", jobID, userID)
+	ret0, _ := ret[0].(model.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockImportJobMockRecorder) GetImportJob(jobID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockImportJob)(nil).GetImportJob), jobID, userID)
+}
+
+// StartImportJob mocks base method.
+func (m *MockImportJob) StartImportJob(userID int64, source string, file io.Reader, loc *time.Location) (model.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImportJob", userID, source, file, loc)
+	ret0, _ := ret[0].(model.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImportJob indicates an expected call of StartImportJob.
+func (mr *MockImportJobMockRecorder) StartImportJob(userID, source, file, loc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImportJob", reflect.TypeOf((*MockImportJob)(nil).StartImportJob), userID, source, file, loc)
+}
+
+// MockAccount is a mock of Account interface.
+type MockAccount struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountMockRecorder
+}
+
+// MockAccountMockRecorder is the mock recorder for MockAccount.
+type MockAccountMockRecorder struct {
+	mock *MockAccount
+}
+
+// NewMockAccount creates a new mock instance.
+func NewMockAccount(ctrl *gomock.Controller) *MockAccount {
+	mock := &MockAccount{ctrl: ctrl}
+	mock.recorder = &MockAccountMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccount) EXPECT() *MockAccountMockRecorder {
+	return m.recorder
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccount) DeleteAccount(userID int64, password string) (model.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", userID, password)
+	ret0, _ := ret[0].(model.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccountMockRecorder) DeleteAccount(userID, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccount)(nil).DeleteAccount), userID, password)
+}
+
+// FailRunningAccountExports mocks base method.
+func (m *MockAccount) FailRunningAccountExports() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailRunningAccountExports")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailRunningAccountExports indicates an expected call of FailRunningAccountExports.
+func (mr *MockAccountMockRecorder) FailRunningAccountExports() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailRunningAccountExports", reflect.TypeOf((*MockAccount)(nil).FailRunningAccountExports))
+}
+
+// GetAccountExport mocks base method.
+func (m *MockAccount) GetAccountExport(exportID, userID int64) (model.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountExport", exportID, userID)
+	ret0, _ := ret[0].(model.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountExport indicates an expected call of GetAccountExport.
+func (mr *MockAccountMockRecorder) GetAccountExport(exportID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountExport", reflect.TypeOf((*MockAccount)(nil).GetAccountExport), exportID, userID)
+}
+
+// OpenAccountExport mocks base method.
+func (m *MockAccount) OpenAccountExport(ctx context.Context, exportID, userID, expires int64, signature string) (model.AccountExport, io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAccountExport", ctx, exportID, userID, expires, signature)
+	ret0, _ := ret[0].(model.AccountExport)
+	ret1, _ := ret[1].(io.ReadSeekCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenAccountExport indicates an expected call of OpenAccountExport.
+func (mr *MockAccountMockRecorder) OpenAccountExport(ctx, exportID, userID, expires, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAccountExport", reflect.TypeOf((*MockAccount)(nil).OpenAccountExport), ctx, exportID, userID, expires, signature)
+}
+
+// PurgeAccounts mocks base method.
+func (m *MockAccount) PurgeAccounts(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAccounts", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeAccounts indicates an expected call of PurgeAccounts.
+func (mr *MockAccountMockRecorder) PurgeAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAccounts", reflect.TypeOf((*MockAccount)(nil).PurgeAccounts), ctx)
+}
+
+// StartAccountExport mocks base method.
+func (m *MockAccount) StartAccountExport(userID int64) (model.AccountExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAccountExport", userID)
+	ret0, _ := ret[0].(model.AccountExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartAccountExport indicates an expected call of StartAccountExport.
+func (mr *MockAccountMockRecorder) StartAccountExport(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAccountExport", reflect.TypeOf((*MockAccount)(nil).StartAccountExport), userID)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+)
+
+type ProjectService struct {
+	rep     repositories.Project
+	taskRep repositories.Task
+}
+
+func NewProjectService(rep repositories.Project, taskRep repositories.Task) *ProjectService {
+	return &ProjectService{
+		rep:     rep,
+		taskRep: taskRep,
+	}
+}
+
+func (s *ProjectService) CreateProject(project model.Project) (int64, error) {
+	id, err := s.rep.CreateProject(project)
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	return id, nil
+}
+
+func (s *ProjectService) GetProject(projectID, userID int64) (model.Project, error) {
+	project, err := s.rep.GetProject(projectID, userID)
+	if err != nil {
+		return model.Project{}, fmt.Errorf("%w", err)
+	}
+	return project, nil
+}
+
+func (s *ProjectService) GetAllProjects(userID int64, withArchived bool) ([]model.Project, error) {
+	projects, err := s.rep.GetAllProjects(userID, withArchived)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return projects, nil
+}
+
+func (s *ProjectService) UpdateProject(project model.Project) error {
+	err := s.rep.UpdateProject(project)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *ProjectService) DeleteProject(projectID, userID int64) error {
+	err := s.rep.DeleteProject(projectID, userID)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *ProjectService) GetProjectTasks(projectID, userID int64, filter model.TaskFilter) ([]model.Task, error) {
+	_, err := s.rep.GetProject(projectID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	filter.ProjectID = &projectID
+	tasks, err := s.taskRep.GetAllByUser(userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return tasks, nil
+}
+
+func (s *ProjectService) SetTaskProject(taskID, userID int64, projectID *int64) error {
+	err := s.rep.SetTaskProject(taskID, userID, projectID)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
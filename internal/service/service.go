@@ -1,9 +1,13 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"io"
 	"restAPI/internal/model"
 	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/blobstore"
+	"time"
 )
 
 var (
@@ -15,14 +19,24 @@ var (
 type Task interface {
 	DeleteAllTasks() error
 	GetAllTasks() ([]model.Task, error)
+	StreamTasks(ctx context.Context, stream model.TaskStream, fn func(task model.TaskRow) error) error
 	CreateTask(task model.Task) (int64, error)
+	ImportTasks(userID int64, fn func(create func(task model.Task) (int64, error)) error) error
 	DeleteTask(taskID, userID int64) error
 	DeleteAllByUser(userID int64) error
 	GetTask(taskID, userID int64) (model.Task, error)
-	GetAllByUser(userID int64) ([]model.Task, error)
-	GetTasksByDate(day, month, year int, userID int64) ([]model.Task, error)
-	GetTasksByTag(tag string, userID int64) ([]model.Task, error)
-	UpdateTask(taskID, userID int64, Text string, Tags []string) error
+	GetAllByUser(userID int64, filter model.TaskFilter) ([]model.Task, error)
+	GetTasksByDate(day, month, year int, userID int64, filter model.TaskFilter) ([]model.Task, error)
+	GetTasksByTag(tag string, userID int64, filter model.TaskFilter) ([]model.Task, error)
+	GetCalendarMonth(month, year int, userID int64, filter model.TaskFilter) (model.CalendarMonth, error)
+	GetTaskIDsByUID(userID int64, uids []string) (map[string]int64, error)
+	UpdateTask(taskID, userID int64, Text string, Tags []string, Estimate *int64) error
+	ReplaceTask(task model.Task) error
+	Undo(userID int64) (model.UndoResult, error)
+	MoveTask(taskID, userID int64, after, before *int64) error
+	DuplicateTask(taskID, userID int64, options model.DuplicateOptions) (int64, error)
+	MoveTasks(userID int64, move model.TaskMove) error
+	SnoozeTask(taskID, userID int64, until *time.Time) error
 }
 
 type Authorization interface {
@@ -31,14 +45,151 @@ type Authorization interface {
 	ParseToken(inputToken string) (int64, error)
 }
 
+type Project interface {
+	CreateProject(project model.Project) (int64, error)
+	GetProject(projectID, userID int64) (model.Project, error)
+	GetAllProjects(userID int64, withArchived bool) ([]model.Project, error)
+	UpdateProject(project model.Project) error
+	DeleteProject(projectID, userID int64) error
+	GetProjectTasks(projectID, userID int64, filter model.TaskFilter) ([]model.Task, error)
+	SetTaskProject(taskID, userID int64, projectID *int64) error
+}
+
+type Board interface {
+	CreateBoard(board model.Board) (int64, error)
+	GetBoards(userID int64) ([]model.Board, error)
+	GetBoard(boardID, userID int64) (model.Board, error)
+	DeleteBoard(boardID, userID int64) error
+	CreateColumn(column model.BoardColumn, userID int64) (int64, error)
+	MoveCard(boardID, taskID, userID int64, move model.CardMove) error
+	RemoveCard(boardID, taskID, userID int64) error
+}
+
+type Item interface {
+	CreateItem(item model.TaskItem, userID int64) (int64, error)
+	GetItems(taskID, userID int64) ([]model.TaskItem, error)
+	UpdateItem(item model.TaskItem, userID int64) error
+	DeleteItem(taskID, itemID, userID int64) error
+	MoveItem(taskID, itemID, userID int64, after, before *int64) error
+}
+
+type Comment interface {
+	CreateComment(comment model.Comment) (int64, error)
+	GetComments(taskID, userID int64, page model.Page) ([]model.Comment, int, error)
+	UpdateComment(comment model.Comment, userID int64) error
+	DeleteComment(taskID, commentID, userID int64) error
+}
+
+type Attachment interface {
+	CreateAttachment(ctx context.Context, attachment model.Attachment, data []byte) (int64, error)
+	GetAttachments(taskID, userID int64) ([]model.Attachment, error)
+	OpenAttachment(ctx context.Context, taskID, attachmentID, userID int64) (model.Attachment, io.ReadSeekCloser, error)
+	DeleteAttachment(ctx context.Context, taskID, attachmentID, userID int64) error
+}
+
+type Time interface {
+	StartTimer(taskID, userID int64) (int64, error)
+	StopTimer(taskID, userID int64) (model.TimeEntry, error)
+	CreateTimeEntry(entry model.TimeEntry) (int64, error)
+	GetTimeEntries(taskID, userID int64) ([]model.TimeEntry, error)
+	DeleteTimeEntry(taskID, entryID, userID int64) error
+	TimeReport(userID int64, from, to time.Time, group string) (model.TimeReport, error)
+}
+
+type Analytics interface {
+	EstimateReport(userID int64, from, to time.Time, group string) (model.EstimateReport, error)
+	StatsReport(userID int64, from, to time.Time, bucket string, loc *time.Location) (model.Stats, error)
+	ActivityHeatmap(userID int64, year int) ([]byte, error)
+	ProjectBurndown(projectID, userID int64, days int, loc *time.Location) ([]byte, error)
+}
+
+type Agenda interface {
+	GetAgenda(userID int64, days int, loc *time.Location) (model.Agenda, error)
+}
+
+type Template interface {
+	CreateTemplate(template model.Template) (int64, error)
+	GetTemplate(templateID, userID int64) (model.Template, error)
+	GetTemplates(userID int64) ([]model.Template, error)
+	UpdateTemplate(template model.Template) error
+	DeleteTemplate(templateID, userID int64) error
+	InstantiateTemplate(templateID, userID int64, instance model.TemplateInstance) ([]int64, error)
+}
+
+type Export interface {
+	ExportICS(userID int64) ([]byte, error)
+	ExportCSV(userID int64, columns []string, tagDelimiter string) ([]byte, error)
+	ExportTodoTxt(userID int64) ([]byte, error)
+}
+
+type Feed interface {
+	RotateFeedToken(userID int64) (string, error)
+	RevokeFeedToken(userID int64) error
+	FeedUser(token string) (int64, error)
+}
+
+type Import interface {
+	ImportICS(userID int64, calendar io.Reader, events bool, loc *time.Location) (model.ImportReport, error)
+	ImportCSV(userID int64, file io.Reader, options model.CSVImport, loc *time.Location) (model.ImportReport, error)
+	ImportTodoTxt(userID int64, file io.Reader, loc *time.Location) (model.ImportReport, error)
+	SyncTodoTxt(userID int64, file io.Reader, dryRun bool, loc *time.Location) (model.SyncReport, error)
+}
+
+type ImportJob interface {
+	StartImportJob(userID int64, source string, file io.Reader, loc *time.Location) (model.ImportJob, error)
+	GetImportJob(jobID, userID int64) (model.ImportJob, error)
+	FailRunningImportJobs() error
+}
+
+type Account interface {
+	StartAccountExport(userID int64) (model.AccountExport, error)
+	GetAccountExport(exportID, userID int64) (model.AccountExport, error)
+	OpenAccountExport(ctx context.Context, exportID, userID, expires int64, signature string) (model.AccountExport, io.ReadSeekCloser, error)
+	FailRunningAccountExports() error
+	DeleteAccount(userID int64, password string) (model.AccountDeletion, error)
+	PurgeAccounts(ctx context.Context) (int, error)
+}
+
 type Service struct {
 	Task
 	Authorization
+	Project
+	Board
+	Item
+	Comment
+	Attachment
+	Time
+	Analytics
+	Template
+	Agenda
+	Export
+	Feed
+	Import
+	ImportJob
+	Account
 }
 
-func New(rep *repositories.Repository) *Service {
+func New(rep *repositories.Repository, store blobstore.BlobStore, quota int64, deletionGrace time.Duration) *Service {
+	task := NewTaskService(rep.Task, store)
+	project := NewProjectService(rep.Project, rep.Task)
+	item := NewItemService(rep.Item)
+	comment := NewCommentService(rep.Comment)
 	return &Service{
-		Task:          NewTaskService(rep.Task),
+		Task:          task,
 		Authorization: NewAuthService(rep.Authorization),
+		Project:       project,
+		Board:         NewBoardService(rep.Board),
+		Item:          item,
+		Comment:       comment,
+		Attachment:    NewAttachmentService(rep.Attachment, store, quota),
+		Time:          NewTimeService(rep.Time),
+		Analytics:     NewAnalyticsService(rep.Analytics),
+		Template:      NewTemplateService(rep.Template, rep.Task),
+		Agenda:        NewAgendaService(rep.Task),
+		Export:        NewExportService(rep.Task),
+		Feed:          NewFeedService(rep.Feed),
+		Import:        NewImportService(task),
+		ImportJob:     NewImportJobService(rep.Import),
+		Account:       NewAccountService(rep.Account, rep.Task, rep.Project, store, deletionGrace),
 	}
 }
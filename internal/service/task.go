@@ -1,18 +1,25 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"restAPI/internal/model"
 	"restAPI/internal/repositories"
+	"restAPI/pkg/lib/blobstore"
+	"time"
 )
 
+// TaskService removes the blobs of attachments together with their tasks.
+// Undo brings such tasks back without attachments.
 type TaskService struct {
-	rep repositories.Task
+	rep   repositories.Task
+	store blobstore.BlobStore
 }
 
-func NewTaskService(rep repositories.Task) *TaskService {
+func NewTaskService(rep repositories.Task, store blobstore.BlobStore) *TaskService {
 	return &TaskService{
-		rep: rep,
+		rep:   rep,
+		store: store,
 	}
 }
 
@@ -24,6 +31,15 @@ func (s *TaskService) CreateTask(task model.Task) (int64, error) {
 	return id, nil
 }
 
+// ImportTasks creates the tasks of an import all at once, fn calls create for
+// each of them and an error of fn creates none.
+func (s *TaskService) ImportTasks(userID int64, fn func(create func(task model.Task) (int64, error)) error) error {
+	if err := s.rep.ImportTasks(userID, fn); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
 func (s *TaskService) GetTask(taskID, userID int64) (model.Task, error) {
 	task, err := s.rep.GetTask(taskID, userID)
 	if err != nil {
@@ -32,8 +48,8 @@ func (s *TaskService) GetTask(taskID, userID int64) (model.Task, error) {
 	return task, nil
 }
 
-func (s *TaskService) GetAllByUser(userID int64) ([]model.Task, error) {
-	tasks, err := s.rep.GetAllByUser(userID)
+func (s *TaskService) GetAllByUser(userID int64, filter model.TaskFilter) ([]model.Task, error) {
+	tasks, err := s.rep.GetAllByUser(userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("%w", err)
 	}
@@ -41,26 +57,35 @@ func (s *TaskService) GetAllByUser(userID int64) ([]model.Task, error) {
 }
 
 func (s *TaskService) DeleteTask(taskID, userID int64) error {
-	err := s.rep.DeleteTask(taskID, userID)
+	keys, err := s.rep.DeleteTask(taskID, userID)
 	if err != nil {
 		return fmt.Errorf("%w", err)
 	}
+	if err = removeBlobs(context.Background(), s.store, keys); err != nil {
+		return fmt.Errorf("%w", err)
+	}
 	return nil
 }
 
 func (s *TaskService) DeleteAllByUser(userID int64) error {
-	err := s.rep.DeleteAllByUser(userID)
+	keys, err := s.rep.DeleteAllByUser(userID)
 	if err != nil {
 		return fmt.Errorf("%w", err)
 	}
+	if err = removeBlobs(context.Background(), s.store, keys); err != nil {
+		return fmt.Errorf("%w", err)
+	}
 	return nil
 }
 
 func (s *TaskService) DeleteAllTasks() error {
-	err := s.rep.DeleteAllTasks()
+	keys, err := s.rep.DeleteAllTasks()
 	if err != nil {
 		return fmt.Errorf("%w", err)
 	}
+	if err = removeBlobs(context.Background(), s.store, keys); err != nil {
+		return fmt.Errorf("%w", err)
+	}
 	return nil
 }
 
@@ -72,27 +97,100 @@ func (s *TaskService) GetAllTasks() ([]model.Task, error) {
 	return tasks, nil
 }
 
-func (s *TaskService) GetTasksByDate(day, month, year int, userID int64) ([]model.Task, error) {
-	tasks, err := s.rep.GetTasksByDate(day, month, year, userID)
+// StreamTasks calls fn with the tasks of all the users selected by the
+// stream, one at a time, for the exports too large to be held in memory.
+func (s *TaskService) StreamTasks(ctx context.Context, stream model.TaskStream, fn func(task model.TaskRow) error) error {
+	if err := s.rep.StreamTasks(ctx, stream, fn); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *TaskService) GetTasksByDate(day, month, year int, userID int64, filter model.TaskFilter) ([]model.Task, error) {
+	tasks, err := s.rep.GetTasksByDate(day, month, year, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("%w", err)
 	}
 	return tasks, nil
 }
 
-func (s *TaskService) GetTasksByTag(tag string, userID int64) ([]model.Task, error) {
-	tasks, err := s.rep.GetTasksByTag(tag, userID)
+func (s *TaskService) GetTasksByTag(tag string, userID int64, filter model.TaskFilter) ([]model.Task, error) {
+	tasks, err := s.rep.GetTasksByTag(tag, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("%w", err)
 	}
 	return tasks, nil
 }
 
-func (s *TaskService) UpdateTask(taskID, userID int64, Text string, Tags []string) error {
-	err := s.rep.UpdateTask(taskID, userID, Text, Tags)
+func (s *TaskService) GetCalendarMonth(month, year int, userID int64, filter model.TaskFilter) (model.CalendarMonth, error) {
+	calendar, err := s.rep.GetCalendarMonth(month, year, userID, filter)
+	if err != nil {
+		return model.CalendarMonth{}, fmt.Errorf("%w", err)
+	}
+	return calendar, nil
+}
+
+func (s *TaskService) GetTaskIDsByUID(userID int64, uids []string) (map[string]int64, error) {
+	taskIDs, err := s.rep.GetTaskIDsByUID(userID, uids)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return taskIDs, nil
+}
+
+func (s *TaskService) ReplaceTask(task model.Task) error {
+	err := s.rep.ReplaceTask(task)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *TaskService) UpdateTask(taskID, userID int64, Text string, Tags []string, Estimate *int64) error {
+	err := s.rep.UpdateTask(taskID, userID, Text, Tags, Estimate)
 	if err != nil {
 		return fmt.Errorf("%w", err)
 	}
 	return nil
 
 }
+
+func (s *TaskService) Undo(userID int64) (model.UndoResult, error) {
+	result, err := s.rep.Undo(userID)
+	if err != nil {
+		return model.UndoResult{}, fmt.Errorf("%w", err)
+	}
+	return result, nil
+}
+
+func (s *TaskService) MoveTask(taskID, userID int64, after, before *int64) error {
+	err := s.rep.MoveTask(taskID, userID, after, before)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *TaskService) DuplicateTask(taskID, userID int64, options model.DuplicateOptions) (int64, error) {
+	taskID, err := s.rep.DuplicateTask(taskID, userID, options)
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	return taskID, nil
+}
+
+func (s *TaskService) MoveTasks(userID int64, move model.TaskMove) error {
+	err := s.rep.MoveTasks(userID, move)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *TaskService) SnoozeTask(taskID, userID int64, until *time.Time) error {
+	err := s.rep.SnoozeTask(taskID, userID, until)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
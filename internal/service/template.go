@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"time"
+)
+
+var ErrTemplateVariable = errors.New("template variable is not given")
+
+var templateVariable = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+type TemplateService struct {
+	rep     repositories.Template
+	taskRep repositories.Task
+	now     func() time.Time
+}
+
+func NewTemplateService(rep repositories.Template, taskRep repositories.Task) *TemplateService {
+	return &TemplateService{
+		rep:     rep,
+		taskRep: taskRep,
+		now:     time.Now,
+	}
+}
+
+func (s *TemplateService) CreateTemplate(template model.Template) (int64, error) {
+	id, err := s.rep.CreateTemplate(template)
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	return id, nil
+}
+
+func (s *TemplateService) GetTemplate(templateID, userID int64) (model.Template, error) {
+	template, err := s.rep.GetTemplate(templateID, userID)
+	if err != nil {
+		return model.Template{}, fmt.Errorf("%w", err)
+	}
+	return template, nil
+}
+
+func (s *TemplateService) GetTemplates(userID int64) ([]model.Template, error) {
+	templates, err := s.rep.GetTemplates(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return templates, nil
+}
+
+func (s *TemplateService) UpdateTemplate(template model.Template) error {
+	err := s.rep.UpdateTemplate(template)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *TemplateService) DeleteTemplate(templateID, userID int64) error {
+	err := s.rep.DeleteTemplate(templateID, userID)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+// InstantiateTemplate creates the tasks of the template with the variables
+// filled in, all of them or none. It returns the ids of the top level tasks.
+func (s *TemplateService) InstantiateTemplate(templateID, userID int64, instance model.TemplateInstance) ([]int64, error) {
+	template, err := s.rep.GetTemplate(templateID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	now := s.now()
+	if instance.Start.IsZero() {
+		instance.Start = now
+	}
+	tasks, err := templateTasks(template.Tasks, userID, now, instance)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	taskIDs, err := s.taskRep.CreateTasks(tasks)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return taskIDs, nil
+}
+
+func templateTasks(source []model.TemplateTask, userID int64, now time.Time, instance model.TemplateInstance) ([]model.Task, error) {
+	tasks := make([]model.Task, 0, len(source))
+	for _, templateTask := range source {
+		text, err := fillVariables(templateTask.Text, instance.Variables)
+		if err != nil {
+			return nil, err
+		}
+		tags := make([]string, 0, len(templateTask.Tags))
+		for _, tag := range templateTask.Tags {
+			tag, err = fillVariables(tag, instance.Variables)
+			if err != nil {
+				return nil, err
+			}
+			tags = append(tags, tag)
+		}
+		task := model.Task{
+			Text:      text,
+			Tags:      tags,
+			Date:      now,
+			OwnerID:   userID,
+			ProjectID: instance.ProjectID,
+		}
+		if templateTask.DueOffset != nil {
+			due := instance.Start.AddDate(0, 0, *templateTask.DueOffset)
+			task.Due = &due
+		}
+		if task.Subtasks, err = templateTasks(templateTask.Subtasks, userID, now, instance); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// fillVariables puts the values in place of the {{variables}} of the text.
+func fillVariables(text string, variables map[string]string) (string, error) {
+	var missing string
+	text = templateVariable.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateVariable.FindStringSubmatch(match)[1]
+		value, ok := variables[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return value
+	})
+	if missing != "" {
+		return "", fmt.Errorf("%w: %s", ErrTemplateVariable, missing)
+	}
+	return text, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+	"restAPI/internal/model"
+	"restAPI/internal/repositories"
+	"time"
+)
+
+// TimeService keeps every timestamp in UTC, the time entries table stores them
+// without a zone.
+type TimeService struct {
+	rep repositories.Time
+	now func() time.Time
+}
+
+func NewTimeService(rep repositories.Time) *TimeService {
+	return &TimeService{
+		rep: rep,
+		now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+func (s *TimeService) StartTimer(taskID, userID int64) (int64, error) {
+	id, err := s.rep.StartTimer(taskID, userID, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	return id, nil
+}
+
+func (s *TimeService) StopTimer(taskID, userID int64) (model.TimeEntry, error) {
+	entry, err := s.rep.StopTimer(taskID, userID, s.now())
+	if err != nil {
+		return model.TimeEntry{}, fmt.Errorf("%w", err)
+	}
+	return entry, nil
+}
+
+func (s *TimeService) CreateTimeEntry(entry model.TimeEntry) (int64, error) {
+	entry.StartedAt = entry.StartedAt.UTC()
+	if entry.StoppedAt != nil {
+		stoppedAt := entry.StoppedAt.UTC()
+		entry.StoppedAt = &stoppedAt
+	}
+	id, err := s.rep.CreateTimeEntry(entry)
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	return id, nil
+}
+
+func (s *TimeService) GetTimeEntries(taskID, userID int64) ([]model.TimeEntry, error) {
+	entries, err := s.rep.GetTimeEntries(taskID, userID, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	return entries, nil
+}
+
+func (s *TimeService) DeleteTimeEntry(taskID, entryID, userID int64) error {
+	err := s.rep.DeleteTimeEntry(taskID, entryID, userID)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	return nil
+}
+
+func (s *TimeService) TimeReport(userID int64, from, to time.Time, group string) (model.TimeReport, error) {
+	report, err := s.rep.TimeReport(userID, from.UTC(), to.UTC(), group, s.now())
+	if err != nil {
+		return model.TimeReport{}, fmt.Errorf("%w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"fmt"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/quickadd"
+	"restAPI/pkg/lib/todoist"
+	"restAPI/pkg/lib/verification"
+	"slices"
+	"time"
+)
+
+// todoistPriorities maps the priorities of Todoist, 4 is its highest and 1
+// the normal one.
+var todoistPriorities = map[int]string{
+	4: model.TaskPriorityHigh,
+	3: model.TaskPriorityMedium,
+	2: model.TaskPriorityLow,
+}
+
+// todoistBatch reads a Todoist export. Sub-projects become projects of their
+// own and the tasks of the inbox have none. Labels become tags, sub-tasks
+// subtasks, and notes and descriptions comments. Deleted records are left out.
+func todoistBatch(export todoist.Export, loc *time.Location, now time.Time) jobBatch {
+	var batch jobBatch
+	inbox := make(map[todoist.ID]bool)
+	for _, project := range export.Projects {
+		if project.IsDeleted {
+			continue
+		}
+		if project.InboxProject {
+			inbox[project.ID] = true
+			continue
+		}
+		record := jobProject{
+			jobRecord: jobRecord{ref: string(project.ID), text: project.Name},
+			project: model.Project{
+				Name:     project.Name,
+				Colour:   todoist.Colors[project.Color],
+				Archived: project.IsArchived,
+			},
+		}
+		if !verification.Project(record.project) {
+			batch.problems = append(batch.problems, invalidRecord(model.ImportKindProject, record.jobRecord,
+				"incorrect project information"))
+			continue
+		}
+		batch.projects = append(batch.projects, record)
+	}
+
+	items := make([]todoist.Item, 0, len(export.Items))
+	for _, item := range export.Items {
+		if !item.IsDeleted {
+			items = append(items, item)
+		}
+	}
+	for _, item := range subtasksLast(items) {
+		record, ok := todoistTask(item, loc, now)
+		if !ok {
+			batch.problems = append(batch.problems, invalidRecord(model.ImportKindTask, record.jobRecord, record.reason))
+			continue
+		}
+		if !inbox[item.ProjectID] {
+			record.project = string(item.ProjectID)
+		}
+		batch.tasks = append(batch.tasks, record)
+	}
+
+	comments := make([]jobComment, 0, len(export.Notes))
+	for _, item := range items {
+		if item.Description != "" {
+			comments = append(comments, todoistComment(string(item.ID)+"/description",
+				string(item.ID), item.Description, item.AddedAt))
+		}
+	}
+	for _, note := range export.Notes {
+		if !note.IsDeleted {
+			comments = append(comments, todoistComment(string(note.ID), string(note.ItemID), note.Content, note.PostedAt))
+		}
+	}
+	for _, record := range comments {
+		if !verification.Comment(record.comment) {
+			batch.problems = append(batch.problems, invalidRecord(model.ImportKindComment, record.jobRecord,
+				"incorrect comment information"))
+			continue
+		}
+		batch.comments = append(batch.comments, record)
+	}
+	return batch
+}
+
+// todoistTask reads the task of an item. When ok is false, the reason of the
+// record tells why it can't be imported.
+func todoistTask(item todoist.Item, loc *time.Location, now time.Time) (record jobTask, ok bool) {
+	record.jobRecord = jobRecord{ref: string(item.ID), text: item.Content}
+	if item.ParentID != nil {
+		record.parent = string(*item.ParentID)
+	}
+	task := model.Task{
+		Text:   item.Content,
+		Tags:   make([]string, 0, len(item.Labels)),
+		Status: model.TaskStatusTodo,
+	}
+	for _, label := range item.Labels {
+		if label != "" && !slices.Contains(task.Tags, label) {
+			task.Tags = append(task.Tags, label)
+		}
+	}
+	if priority, found := todoistPriorities[item.Priority]; found {
+		task.Priority = &priority
+	}
+	if item.Checked {
+		task.Status = model.TaskStatusDone
+		if item.CompletedAt != nil {
+			completed := item.CompletedAt.UTC()
+			task.CompletedAt = &completed
+		}
+	}
+	task.Date = wallClock(now.In(loc))
+	if item.AddedAt != nil {
+		task.Date = wallClock(item.AddedAt.In(loc))
+	}
+	if item.Due != nil {
+		if due, err := item.Due.Time(loc); err == nil {
+			due = wallClock(due)
+			task.Date, task.Due = due, &due
+		} else {
+			record.reason = fmt.Sprintf("due date %s is not supported, imported without it", item.Due.Date)
+		}
+		if item.Due.IsRecurring {
+			// the due strings are typed like the quick add lines, the word
+			// before keeps the line from having no text
+			result, err := quickadd.Parse("task "+item.Due.String, now.In(loc))
+			if err == nil && result.Recurrence != "" {
+				task.Recurrence = &result.Recurrence
+			} else {
+				record.reason = fmt.Sprintf("recurrence %q is not supported, imported without it", item.Due.String)
+			}
+		}
+	}
+	if task.Text == "" {
+		record.reason = "no content"
+		return record, false
+	}
+	if !verification.Task(task) {
+		record.reason = "incorrect task information"
+		return record, false
+	}
+	record.task = task
+	return record, true
+}
+
+func todoistComment(ref, itemID, text string, postedAt *time.Time) jobComment {
+	record := jobComment{
+		jobRecord: jobRecord{ref: ref, text: text},
+		task:      itemID,
+		comment:   model.Comment{Text: text},
+	}
+	if postedAt != nil {
+		record.comment.CreatedAt = *postedAt
+	}
+	return record
+}
+
+// subtasksLast orders the items so that a parent comes before its sub-tasks,
+// the others keep their order.
+func subtasksLast(items []todoist.Item) []todoist.Item {
+	index := make(map[todoist.ID]int, len(items))
+	for i, item := range items {
+		index[item.ID] = i
+	}
+	ordered := make([]todoist.Item, 0, len(items))
+	// an item is marked before its parent is placed, so that a cycle of
+	// parents, which Todoist doesn't allow, ends
+	marked := make([]bool, len(items))
+	var place func(i int)
+	place = func(i int) {
+		if marked[i] {
+			return
+		}
+		marked[i] = true
+		if parent := items[i].ParentID; parent != nil {
+			if p, found := index[*parent]; found {
+				place(p)
+			}
+		}
+		ordered = append(ordered, items[i])
+	}
+	for i := range items {
+		place(i)
+	}
+	return ordered
+}
+
+func invalidRecord(kind string, record jobRecord, reason string) jobProblem {
+	return jobProblem{kind: kind, item: model.ImportItem{
+		Ref:    kind + " " + record.ref,
+		Text:   record.text,
+		Status: model.ImportInvalid,
+		Reason: reason,
+	}}
+}
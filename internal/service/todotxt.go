@@ -0,0 +1,336 @@
+package service
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/recurrence"
+	"restAPI/pkg/lib/todotxt"
+	"restAPI/pkg/lib/verification"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrTodoTxt = errors.New("incorrect todo.txt file")
+
+// maxTodoLine bounds a line of a todo.txt file.
+const maxTodoLine = 64 << 10
+
+// The extensions keep the fields todo.txt has no place for, so that a task
+// comes back the same from its line.
+const (
+	todoID        = "id"
+	todoParent    = "parent"
+	todoProject   = "project_id"
+	todoStatus    = "status"
+	todoDue       = "due"
+	todoRecurring = "rec"
+	todoEstimate  = "est"
+	// todoAt is the time of day of the date, todoDoneAt the time in UTC the
+	// task was completed at, the line only has their days
+	todoAt     = "at"
+	todoDoneAt = "done_at"
+)
+
+const (
+	todoDateLayout  = "2006-01-02"
+	todoClockLayout = "15:04:05"
+)
+
+var (
+	linePriorities = map[string]byte{
+		model.TaskPriorityHigh:   'A',
+		model.TaskPriorityMedium: 'B',
+		model.TaskPriorityLow:    'C',
+	}
+	// todoRecurrences are the units of the rec:2w extensions of todo.txt apps
+	todoRecurrences = map[byte]string{
+		'd': recurrence.Daily,
+		'w': recurrence.Weekly,
+		'm': recurrence.Monthly,
+		'y': recurrence.Yearly,
+	}
+	// tagEscaper keeps a tag in one word, todo.txt tags end at a space
+	tagEscaper   = strings.NewReplacer("%", "%25", " ", "%20", "\t", "%09")
+	tagUnescaper = strings.NewReplacer("%25", "%", "%20", " ", "%09", "\t")
+)
+
+// todoLine writes the task as a todo.txt line. Tags starting with a plus are
+// written as projects, the others as contexts.
+func todoLine(task model.Task) todotxt.Task {
+	line := todotxt.Task{Done: task.Status == model.TaskStatusDone}
+	if task.Priority != nil {
+		line.Priority = linePriorities[*task.Priority]
+	}
+	created := wallDay(task.Date)
+	line.Created = &created
+	words := []string{strings.Join(strings.Fields(task.Text), " ")}
+	for _, tag := range task.Tags {
+		if project, found := strings.CutPrefix(tag, "+"); found {
+			words = append(words, "+"+tagEscaper.Replace(project))
+		} else {
+			words = append(words, "@"+tagEscaper.Replace(tag))
+		}
+	}
+	extension := func(key, value string) {
+		words = append(words, key+":"+value)
+	}
+	if !task.Date.Equal(created) {
+		extension(todoAt, task.Date.Format(todoClockLayout))
+	}
+	if line.Done && task.CompletedAt != nil {
+		completedAt := task.CompletedAt.UTC()
+		completed := wallDay(completedAt)
+		line.Completed = &completed
+		if !completedAt.Equal(completed) {
+			extension(todoDoneAt, completedAt.Format(todoClockLayout))
+		}
+	}
+	if task.Status == model.TaskStatusInProgress {
+		extension(todoStatus, task.Status)
+	}
+	if task.Due != nil {
+		if task.Due.Equal(wallDay(*task.Due)) {
+			extension(todoDue, task.Due.Format(todoDateLayout))
+		} else {
+			extension(todoDue, task.Due.Format(todoDateLayout+"T"+todoClockLayout))
+		}
+	}
+	if task.Recurrence != nil {
+		extension(todoRecurring, *task.Recurrence)
+	}
+	if task.Estimate != nil {
+		extension(todoEstimate, strconv.FormatInt(*task.Estimate, 10))
+	}
+	if task.ProjectID != nil {
+		extension(todoProject, strconv.FormatInt(*task.ProjectID, 10))
+	}
+	if task.ParentID != nil {
+		extension(todoParent, strconv.FormatInt(*task.ParentID, 10))
+	}
+	if task.ID != 0 {
+		extension(todoID, strconv.FormatInt(task.ID, 10))
+	}
+	line.Description = strings.Join(words, " ")
+	return line
+}
+
+// lineTask reads the task of a todo.txt line, the id extension gives its ID.
+// Without a creation date the date of the task stays zero, without a
+// completion date so does the time it was completed at. Extensions this
+// package doesn't write are kept in the text.
+func lineTask(line todotxt.Task) (model.Task, error) {
+	task := model.Task{Status: model.TaskStatusTodo, Tags: make([]string, 0)}
+	if line.Done {
+		task.Status = model.TaskStatusDone
+	}
+	if line.Priority != 0 {
+		// the priorities after C are low as well
+		priority := model.TaskPriorityLow
+		switch line.Priority {
+		case 'A':
+			priority = model.TaskPriorityHigh
+		case 'B':
+			priority = model.TaskPriorityMedium
+		}
+		task.Priority = &priority
+	}
+	if line.Created != nil {
+		task.Date = *line.Created
+	}
+	if line.Done && line.Completed != nil {
+		completed := *line.Completed
+		task.CompletedAt = &completed
+	}
+
+	text := make([]string, 0)
+	for _, word := range strings.Fields(line.Description) {
+		if len(word) > 1 && (word[0] == '+' || word[0] == '@') {
+			tag := tagUnescaper.Replace(word[1:])
+			if word[0] == '+' {
+				tag = "+" + tag
+			}
+			if !slices.Contains(task.Tags, tag) {
+				task.Tags = append(task.Tags, tag)
+			}
+			continue
+		}
+		extension, ok := todotxt.ParseExtension(word)
+		if !ok {
+			text = append(text, word)
+			continue
+		}
+		known, err := setExtension(&task, extension)
+		if err != nil {
+			return model.Task{}, fmt.Errorf("incorrect %s %q", extension.Key, extension.Value)
+		}
+		if !known {
+			text = append(text, word)
+		}
+	}
+	task.Text = strings.Join(text, " ")
+	return task, nil
+}
+
+// setExtension reads the extension into the task, known tells whether the
+// extension is one of the task fields.
+func setExtension(task *model.Task, extension todotxt.Extension) (known bool, err error) {
+	value := extension.Value
+	switch extension.Key {
+	case todoID:
+		var id *int64
+		if id, err = parseID(value); err == nil {
+			task.ID = *id
+		}
+	case todoParent:
+		task.ParentID, err = parseID(value)
+	case todoProject:
+		task.ProjectID, err = parseID(value)
+	case todoStatus:
+		if !verification.TaskStatus(value) {
+			return true, errors.New("unknown status")
+		}
+		if task.Status != model.TaskStatusDone {
+			task.Status = value
+		}
+	case todoDue:
+		var due time.Time
+		due, err = parseWallTime(value)
+		task.Due = &due
+	case todoRecurring:
+		task.Recurrence, err = parseRecurrence(value)
+	case todoEstimate:
+		var estimate int64
+		estimate, err = strconv.ParseInt(value, 10, 64)
+		task.Estimate = &estimate
+	case todoAt:
+		var clock time.Duration
+		if clock, err = parseClock(value); err == nil && !task.Date.IsZero() {
+			task.Date = task.Date.Add(clock)
+		}
+	case todoDoneAt:
+		var clock time.Duration
+		if clock, err = parseClock(value); err == nil && task.CompletedAt != nil {
+			completed := task.CompletedAt.Add(clock)
+			task.CompletedAt = &completed
+		}
+	default:
+		return false, nil
+	}
+	return true, err
+}
+
+// parseClock reads a time of day as the time since midnight.
+func parseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse(todoClockLayout, value)
+	if err != nil {
+		return 0, err
+	}
+	return clock.Sub(time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)), nil
+}
+
+// parseRecurrence reads a repeat rule, or the rec:2w, rec:+1m extensions of
+// todo.txt apps.
+func parseRecurrence(value string) (*string, error) {
+	rule, err := recurrence.Parse(strings.ToUpper(value))
+	if err == nil {
+		canonical := rule.String()
+		return &canonical, nil
+	}
+	short := strings.TrimPrefix(value, "+")
+	if len(short) < 2 {
+		return nil, err
+	}
+	freq, found := todoRecurrences[short[len(short)-1]]
+	interval, atoiErr := strconv.Atoi(short[:len(short)-1])
+	if !found || atoiErr != nil {
+		return nil, err
+	}
+	rule = recurrence.Rule{Freq: freq, Interval: interval}
+	if !rule.Valid() {
+		return nil, recurrence.ErrRule
+	}
+	canonical := rule.String()
+	return &canonical, nil
+}
+
+// readTodoLines reads the lines of a todo.txt file with their numbers,
+// blank lines left out.
+func readTodoLines(file io.Reader) ([]int, []string, error) {
+	numbers := make([]int, 0)
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 4096), maxTodoLine)
+	for n := 1; scanner.Scan(); n++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		numbers = append(numbers, n)
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrTodoTxt, err)
+	}
+	return numbers, lines, nil
+}
+
+// taskChanges names the fields of the task a todo.txt line changes. The
+// date and the completion time are only compared when the line has them.
+func taskChanges(current, line model.Task) []string {
+	changes := make([]string, 0)
+	if current.Text != line.Text {
+		changes = append(changes, "text")
+	}
+	if current.Status != line.Status {
+		changes = append(changes, "status")
+	}
+	if !line.Date.IsZero() && !current.Date.Equal(line.Date) {
+		changes = append(changes, "date")
+	}
+	if line.CompletedAt != nil && !sameTime(current.CompletedAt, line.CompletedAt) {
+		changes = append(changes, "completed_at")
+	}
+	if !sameTime(current.Due, line.Due) {
+		changes = append(changes, "due")
+	}
+	if !samePointer(current.Priority, line.Priority) {
+		changes = append(changes, "priority")
+	}
+	currentTags, lineTags := slices.Clone(current.Tags), slices.Clone(line.Tags)
+	slices.Sort(currentTags)
+	slices.Sort(lineTags)
+	if !slices.Equal(currentTags, lineTags) {
+		changes = append(changes, "tags")
+	}
+	if !samePointer(current.Recurrence, line.Recurrence) {
+		changes = append(changes, "recurrence")
+	}
+	if !samePointer(current.Estimate, line.Estimate) {
+		changes = append(changes, "estimate")
+	}
+	if !samePointer(current.ProjectID, line.ProjectID) {
+		changes = append(changes, "project_id")
+	}
+	if !samePointer(current.ParentID, line.ParentID) {
+		changes = append(changes, "parent_id")
+	}
+	return changes
+}
+
+func samePointer[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
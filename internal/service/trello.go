@@ -0,0 +1,149 @@
+package service
+
+import (
+	"cmp"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/trello"
+	"restAPI/pkg/lib/verification"
+	"slices"
+	"time"
+)
+
+// trelloBatch reads a Trello board. The board becomes a project and its
+// cards tasks, tagged with their labels and the name of their list. Done
+// cards are the ones with a completed due date. Checklists become the
+// checklists of their tasks, and descriptions and comments comments. The
+// archived cards and the cards of archived lists are skipped.
+func trelloBatch(board trello.Board, loc *time.Location, now time.Time) jobBatch {
+	var batch jobBatch
+	project := jobProject{
+		jobRecord: jobRecord{ref: board.ID, text: board.Name},
+		project: model.Project{
+			Name:        board.Name,
+			Description: board.Desc,
+			Archived:    board.Closed,
+		},
+	}
+	if verification.Project(project.project) {
+		batch.projects = append(batch.projects, project)
+	} else {
+		batch.problems = append(batch.problems, invalidRecord(model.ImportKindProject, project.jobRecord,
+			"incorrect project information"))
+	}
+
+	labels := make(map[string]string, len(board.Labels))
+	for _, label := range board.Labels {
+		labels[label.ID] = label.Name
+		// labels may have a color and no name
+		if label.Name == "" {
+			labels[label.ID] = label.Color
+		}
+	}
+	lists := make(map[string]trello.List, len(board.Lists))
+	for _, list := range board.Lists {
+		lists[list.ID] = list
+	}
+
+	comments := make([]jobComment, 0)
+	for _, card := range board.Cards {
+		record := jobTask{
+			jobRecord: jobRecord{ref: card.ID, text: card.Name},
+			project:   board.ID,
+		}
+		list, found := lists[card.IDList]
+		if card.Closed || found && list.Closed {
+			batch.problems = append(batch.problems, jobProblem{kind: model.ImportKindTask, item: model.ImportItem{
+				Ref:    model.ImportKindTask + " " + card.ID,
+				Text:   card.Name,
+				Status: model.ImportSkipped,
+				Reason: "archived in trello",
+			}})
+			continue
+		}
+		task := model.Task{
+			Text:   card.Name,
+			Tags:   make([]string, 0, len(card.IDLabels)+1),
+			Status: model.TaskStatusTodo,
+		}
+		for _, labelID := range card.IDLabels {
+			if tag := labels[labelID]; tag != "" && !slices.Contains(task.Tags, tag) {
+				task.Tags = append(task.Tags, tag)
+			}
+		}
+		if found && list.Name != "" && !slices.Contains(task.Tags, list.Name) {
+			task.Tags = append(task.Tags, list.Name)
+		}
+		task.Date = wallClock(now.In(loc))
+		if created, ok := trello.Created(card.ID); ok {
+			task.Date = wallClock(created.In(loc))
+		}
+		if card.Due != nil {
+			due := wallClock(card.Due.In(loc))
+			task.Date, task.Due = due, &due
+		}
+		if card.Start != nil {
+			task.Date = wallClock(card.Start.In(loc))
+		}
+		if card.DueComplete {
+			task.Status = model.TaskStatusDone
+		}
+		if task.Text == "" || !verification.Task(task) {
+			batch.problems = append(batch.problems, invalidRecord(model.ImportKindTask, record.jobRecord,
+				"incorrect task information"))
+			continue
+		}
+		record.task = task
+		batch.tasks = append(batch.tasks, record)
+		if card.Desc != "" {
+			comments = append(comments, jobComment{
+				jobRecord: jobRecord{ref: card.ID + "/desc", text: card.Desc},
+				task:      card.ID,
+				comment:   model.Comment{Text: card.Desc},
+			})
+		}
+	}
+
+	checklists := slices.Clone(board.Checklists)
+	slices.SortStableFunc(checklists, func(a, b trello.Checklist) int {
+		return cmp.Compare(a.Pos, b.Pos)
+	})
+	for _, checklist := range checklists {
+		checkItems := slices.Clone(checklist.CheckItems)
+		slices.SortStableFunc(checkItems, func(a, b trello.CheckItem) int {
+			return cmp.Compare(a.Pos, b.Pos)
+		})
+		for _, checkItem := range checkItems {
+			record := jobItem{
+				jobRecord: jobRecord{ref: checkItem.ID, text: checkItem.Name},
+				task:      checklist.IDCard,
+				item: model.TaskItem{
+					Text: checkItem.Name,
+					Done: checkItem.State == trello.CheckItemComplete,
+				},
+			}
+			if !verification.TaskItem(record.item) {
+				batch.problems = append(batch.problems, invalidRecord(model.ImportKindItem, record.jobRecord,
+					"incorrect checklist item information"))
+				continue
+			}
+			batch.items = append(batch.items, record)
+		}
+	}
+
+	for _, comment := range board.Comments() {
+		comments = append(comments, jobComment{
+			jobRecord: jobRecord{ref: comment.ID, text: comment.Text},
+			task:      comment.CardID,
+			comment:   model.Comment{Text: comment.Text, CreatedAt: comment.Date},
+		})
+	}
+	for _, record := range comments {
+		if !verification.Comment(record.comment) {
+			batch.problems = append(batch.problems, invalidRecord(model.ImportKindComment, record.jobRecord,
+				"incorrect comment information"))
+			continue
+		}
+		batch.comments = append(batch.comments, record)
+	}
+	return batch
+}
@@ -0,0 +1,19 @@
+// Package blobstore keeps the contents of uploaded files away from the database.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore saves blobs under keys made of slash separated segments.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open returns the blob as a seekable reader so it can be served with
+	// byte ranges; reading it may go back to the store.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,35 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	store, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "1/report.txt", strings.NewReader("report"), 6, "text/plain"))
+
+	object, err := store.Open(ctx, "1/report.txt")
+	require.NoError(t, err)
+	data, err := io.ReadAll(object)
+	require.NoError(t, err)
+	assert.Equal(t, "report", string(data))
+	require.NoError(t, object.Close())
+
+	require.NoError(t, store.Delete(ctx, "1/report.txt"))
+	_, err = store.Open(ctx, "1/report.txt")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.NoError(t, store.Delete(ctx, "1/report.txt"))
+
+	for _, key := range []string{"", "../escape", "1/../../escape", "/abs", "dir/"} {
+		assert.Error(t, store.Put(ctx, key, strings.NewReader("x"), 1, ""), key)
+	}
+}
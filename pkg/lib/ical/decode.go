@@ -0,0 +1,238 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLineSize bounds an unfolded content line.
+const maxLineSize = 1 << 20
+
+var ErrCalendar = errors.New("incorrect calendar")
+
+// Entry is a VTODO or a VEVENT read by Decode.
+type Entry struct {
+	Component string
+	Todo
+}
+
+// Decode reads the VTODO and VEVENT entries of a calendar, other components
+// are left out, like the alarms of the entries. Times are given in loc:
+// floating times and dates are read as wall clock times of loc, the others
+// are moved to it.
+func Decode(r io.Reader, loc *time.Location) ([]Entry, error) {
+	entries := make([]Entry, 0)
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	stack := make([]string, 0, 4)
+	var entry *Entry
+	for n, line := range lines {
+		name, params, value, err := property(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		switch name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(value))
+			if len(stack) == 2 && stack[0] == "VCALENDAR" && (stack[1] == ComponentTodo || stack[1] == ComponentEvent) {
+				entry = &Entry{Component: stack[1]}
+			}
+			continue
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1] != strings.ToUpper(value) {
+				return nil, fmt.Errorf("line %d: %w: END:%s out of place", n+1, ErrCalendar, value)
+			}
+			stack = stack[:len(stack)-1]
+			if len(stack) == 1 && entry != nil {
+				entries = append(entries, *entry)
+				entry = nil
+			}
+			continue
+		}
+		// properties of nested components, like alarms, aren't the entry's
+		if entry == nil || len(stack) != 2 {
+			continue
+		}
+		if err = entry.set(name, params, value, loc); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+	}
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("%w: %s is not ended", ErrCalendar, stack[len(stack)-1])
+	}
+	return entries, nil
+}
+
+func (e *Entry) set(name string, params map[string]string, value string, loc *time.Location) error {
+	switch name {
+	case "UID":
+		e.UID = unescape(value)
+	case "SUMMARY":
+		e.Summary = unescape(value)
+	case "CATEGORIES":
+		for _, category := range splitText(value) {
+			if category = strings.TrimSpace(category); category != "" {
+				e.Categories = append(e.Categories, category)
+			}
+		}
+	case "STATUS":
+		e.Status = strings.ToUpper(value)
+	case "DTSTART", "DUE", "COMPLETED":
+		t, err := parseTime(value, params, loc)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "DTSTART":
+			e.Start = &t
+		case "DUE":
+			e.Due = &t
+		default:
+			e.Completed = &t
+		}
+	case "PRIORITY":
+		priority, err := strconv.Atoi(value)
+		if err != nil || priority < 0 || priority > 9 {
+			return fmt.Errorf("%w: PRIORITY %q", ErrCalendar, value)
+		}
+		e.Priority = priority
+	case "RRULE":
+		e.RRule = value
+	case "RELATED-TO":
+		if relType := params["RELTYPE"]; relType == "" || strings.EqualFold(relType, "PARENT") {
+			e.RelatedTo = unescape(value)
+		}
+	}
+	return nil
+}
+
+// unfold reads the content lines, joining the folded ones back.
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	lines := make([]string, 0)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			if len(lines) == 0 {
+				return nil, fmt.Errorf("%w: folded first line", ErrCalendar)
+			}
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCalendar, err)
+	}
+	return lines, nil
+}
+
+// property splits a content line into its name, its parameters and its
+// value. Parameter values may be quoted, so the colon ending them is the
+// first one out of quotes.
+func property(line string) (string, map[string]string, string, error) {
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return "", nil, "", fmt.Errorf("%w: no value in %q", ErrCalendar, line)
+	}
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], nil
+}
+
+func parseTime(value string, params map[string]string, loc *time.Location) (time.Time, error) {
+	var t time.Time
+	var err error
+	switch {
+	case strings.EqualFold(params["VALUE"], "DATE") || len(value) == len(dateLayout):
+		t, err = time.ParseInLocation(dateLayout, value, loc)
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse(utcLayout, value)
+		t = t.In(loc)
+	default:
+		zone := loc
+		// zones unknown here, like the Windows names, are taken as floating
+		if tzid := params["TZID"]; tzid != "" && tzid != "Local" {
+			if tzidZone, tzidErr := time.LoadLocation(tzid); tzidErr == nil {
+				zone = tzidZone
+			}
+		}
+		t, err = time.ParseInLocation(floatingLayout, value, zone)
+		t = t.In(loc)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: time %q", ErrCalendar, value)
+	}
+	return t, nil
+}
+
+// splitText splits a list of TEXT values on the commas that aren't escaped.
+func splitText(value string) []string {
+	values := make([]string, 0, 1)
+	var b strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			b.WriteRune('\\')
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			values = append(values, unescape(b.String()))
+			b.Reset()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return append(values, unescape(b.String()))
+}
+
+// unescape reads a TEXT value.
+func unescape(s string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		if !escaped {
+			if r == '\\' {
+				escaped = true
+			} else {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		escaped = false
+		if r == 'n' || r == 'N' {
+			b.WriteRune('\n')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package ical
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecode(t *testing.T) {
+	zone := time.FixedZone("UTC+3", 3*60*60)
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VTODO",
+		"UID:a@example.com",
+		`SUMMARY:Pay rent\; call landlord\, again\nsoon`,
+		`CATEGORIES:finance,a\,b`,
+		"CATEGORIES:home",
+		"STATUS:in-process",
+		"DTSTART;VALUE=DATE:20240307",
+		`DUE;TZID="Europe/Berlin":20240308T180000`,
+		"PRIORITY:1",
+		"RRULE:FREQ=MONTHLY",
+		"BEGIN:VALARM",
+		"SUMMARY:not the task",
+		"END:VALARM",
+		"END:VTODO",
+		"BEGIN:VEVENT",
+		"UID:b@example.com",
+		"SUMMARY:A very long meeting name that goes over the limit of a content li",
+		" ne",
+		"DTSTART:20240306T070000Z",
+		"COMPLETED:20240306T071500Z",
+		"RELATED-TO:a@example.com",
+		"END:VEVENT",
+		"BEGIN:VJOURNAL",
+		"SUMMARY:left out",
+		"END:VJOURNAL",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	entries, err := Decode(strings.NewReader(calendar), zone)
+	assert.NoError(t, err)
+	start := time.Date(2024, time.March, 7, 0, 0, 0, 0, zone)
+	due := time.Date(2024, time.March, 8, 20, 0, 0, 0, zone)
+	eventStart := time.Date(2024, time.March, 6, 10, 0, 0, 0, zone)
+	completed := time.Date(2024, time.March, 6, 10, 15, 0, 0, zone)
+	assert.Equal(t, []Entry{
+		{
+			Component: ComponentTodo,
+			Todo: Todo{
+				UID:        "a@example.com",
+				Summary:    "Pay rent; call landlord, again\nsoon",
+				Categories: []string{"finance", "a,b", "home"},
+				Status:     StatusInProcess,
+				Start:      &start,
+				Due:        &due,
+				Priority:   1,
+				RRule:      "FREQ=MONTHLY",
+			},
+		}, {
+			Component: ComponentEvent,
+			Todo: Todo{
+				UID:       "b@example.com",
+				Summary:   "A very long meeting name that goes over the limit of a content line",
+				Start:     &eventStart,
+				Completed: &completed,
+				RelatedTo: "a@example.com",
+			},
+		},
+	}, entries)
+}
+
+func TestDecode_Encoded(t *testing.T) {
+	start := time.Date(2024, time.March, 7, 9, 0, 0, 0, time.UTC)
+	todos := []Todo{{
+		UID:        "task-1@restapi",
+		Summary:    strings.Repeat("é", 40) + `; \ ,`,
+		Categories: []string{"a,b", "c"},
+		Status:     StatusNeedsAction,
+		Start:      &start,
+		Priority:   5,
+	}}
+	var b bytes.Buffer
+	assert.NoError(t, Encode(&b, "Tasks", todos, time.Now()))
+
+	entries, err := Decode(&b, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{{Component: ComponentTodo, Todo: todos[0]}}, entries)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		calendar string
+	}{
+		{
+			name:     "not ended",
+			calendar: "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VCALENDAR",
+		}, {
+			name:     "no value",
+			calendar: "BEGIN:VCALENDAR\r\nSUMMARY\r\nEND:VCALENDAR",
+		}, {
+			name:     "bad time",
+			calendar: "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nDUE:tomorrow\r\nEND:VTODO\r\nEND:VCALENDAR",
+		}, {
+			name:     "bad priority",
+			calendar: "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nPRIORITY:10\r\nEND:VTODO\r\nEND:VCALENDAR",
+		}, {
+			name:     "folded first line",
+			calendar: " BEGIN:VCALENDAR",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Decode(strings.NewReader(test.calendar), time.UTC)
+			assert.ErrorIs(t, err, ErrCalendar)
+		})
+	}
+}
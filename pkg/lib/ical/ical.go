@@ -0,0 +1,142 @@
+// Package ical writes tasks as the VTODO entries of an iCalendar (RFC 5545)
+// calendar and reads them back, with the VEVENT entries. Times without a
+// zone are written as floating times, which calendar apps show at the same
+// wall clock time wherever they are.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	StatusNeedsAction = "NEEDS-ACTION"
+	StatusInProcess   = "IN-PROCESS"
+	StatusCompleted   = "COMPLETED"
+	StatusCancelled   = "CANCELLED"
+)
+
+const (
+	ComponentTodo  = "VTODO"
+	ComponentEvent = "VEVENT"
+)
+
+const (
+	floatingLayout = "20060102T150405"
+	utcLayout      = "20060102T150405Z"
+	dateLayout     = "20060102"
+	// maxLine is the length in octets after which content lines are folded
+	maxLine = 75
+)
+
+// Todo is a VTODO entry. Start and Due are floating times, Completed is a
+// UTC one; Priority goes from 1, the highest, to 9 and 0 leaves it out.
+type Todo struct {
+	UID        string
+	Summary    string
+	Categories []string
+	Status     string
+	Start      *time.Time
+	Due        *time.Time
+	Completed  *time.Time
+	Priority   int
+	RRule      string
+	// RelatedTo is the UID of the parent entry
+	RelatedTo string
+}
+
+// Encode writes the todos as a calendar named name. stamp is the DTSTAMP of
+// every entry, the time the calendar was made.
+func Encode(w io.Writer, name string, todos []Todo, stamp time.Time) error {
+	e := encoder{w: bufio.NewWriter(w)}
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", "-//restAPI//tasks//EN")
+	e.line("CALSCALE", "GREGORIAN")
+	if name != "" {
+		e.line("X-WR-CALNAME", escape(name))
+	}
+	for _, todo := range todos {
+		e.line("BEGIN", "VTODO")
+		e.line("UID", escape(todo.UID))
+		e.line("DTSTAMP", stamp.UTC().Format(utcLayout))
+		e.line("SUMMARY", escape(todo.Summary))
+		if len(todo.Categories) != 0 {
+			categories := make([]string, 0, len(todo.Categories))
+			for _, category := range todo.Categories {
+				categories = append(categories, escape(category))
+			}
+			e.line("CATEGORIES", strings.Join(categories, ","))
+		}
+		if todo.Status != "" {
+			e.line("STATUS", todo.Status)
+		}
+		if todo.Start != nil {
+			e.line("DTSTART", todo.Start.Format(floatingLayout))
+		}
+		if todo.Due != nil {
+			e.line("DUE", todo.Due.Format(floatingLayout))
+		}
+		if todo.Completed != nil {
+			e.line("COMPLETED", todo.Completed.UTC().Format(utcLayout))
+		}
+		if todo.Priority != 0 {
+			e.line("PRIORITY", fmt.Sprint(todo.Priority))
+		}
+		if todo.RRule != "" {
+			e.line("RRULE", todo.RRule)
+		}
+		if todo.RelatedTo != "" {
+			e.line("RELATED-TO", escape(todo.RelatedTo))
+		}
+		e.line("END", "VTODO")
+	}
+	e.line("END", "VCALENDAR")
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes a content line, folded so that no line is longer than maxLine
+// octets without cutting a character in two.
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+	content := name + ":" + value
+	var b strings.Builder
+	width := 0
+	for _, r := range content {
+		size := utf8.RuneLen(r)
+		if width+size > maxLine {
+			// the space starting the next line counts in its length
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+	_, e.err = e.w.WriteString(b.String())
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
@@ -0,0 +1,81 @@
+package ical
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	stamp := time.Date(2024, time.March, 6, 10, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+	start := time.Date(2024, time.March, 7, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2024, time.March, 8, 18, 0, 0, 0, time.UTC)
+	completed := time.Date(2024, time.March, 6, 7, 15, 0, 0, time.UTC)
+	todos := []Todo{
+		{
+			UID:        "task-1@restapi",
+			Summary:    "Pay rent; call landlord, again",
+			Categories: []string{"finance", "a,b"},
+			Status:     StatusNeedsAction,
+			Start:      &start,
+			Due:        &due,
+			Priority:   1,
+			RRule:      "FREQ=MONTHLY",
+		}, {
+			UID:       "task-2@restapi",
+			Summary:   "Sign\nthe lease",
+			Status:    StatusCompleted,
+			Completed: &completed,
+			RelatedTo: "task-1@restapi",
+		},
+	}
+
+	var b bytes.Buffer
+	assert.NoError(t, Encode(&b, "Tasks", todos, stamp))
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//restAPI//tasks//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:Tasks",
+		"BEGIN:VTODO",
+		"UID:task-1@restapi",
+		"DTSTAMP:20240306T073000Z",
+		`SUMMARY:Pay rent\; call landlord\, again`,
+		`CATEGORIES:finance,a\,b`,
+		"STATUS:NEEDS-ACTION",
+		"DTSTART:20240307T090000",
+		"DUE:20240308T180000",
+		"PRIORITY:1",
+		"RRULE:FREQ=MONTHLY",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:task-2@restapi",
+		"DTSTAMP:20240306T073000Z",
+		`SUMMARY:Sign\nthe lease`,
+		"STATUS:COMPLETED",
+		"COMPLETED:20240306T071500Z",
+		"RELATED-TO:task-1@restapi",
+		"END:VTODO",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	assert.Equal(t, want, b.String())
+}
+
+func TestEncode_Folding(t *testing.T) {
+	// two octets a letter, so a fold can't fall in the middle of one
+	summary := strings.Repeat("é", 40)
+	var b bytes.Buffer
+	assert.NoError(t, Encode(&b, "", []Todo{{UID: "1", Summary: summary}}, time.Time{}))
+
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLine)
+		lines = append(lines, line)
+	}
+	assert.Contains(t, lines, "SUMMARY:"+strings.Repeat("é", 33))
+	assert.Contains(t, lines, " "+strings.Repeat("é", 7))
+}
@@ -0,0 +1,468 @@
+// Package quickadd reads a task typed as a single line, like
+// "Pay rent tomorrow 9am #finance !high every month". It picks out the day,
+// the time of day, #tags, a priority marker and a repeat rule, whatever is
+// left is the text of the task.
+//
+// Only the first day, time and repeat rule are taken, later ones stay in the
+// text. Days are relative to now and in its location.
+package quickadd
+
+import (
+	"errors"
+	"regexp"
+	"restAPI/internal/model"
+	"restAPI/pkg/lib/recurrence"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNoText = errors.New("nothing is left for the task text")
+
+type Result struct {
+	Text string
+	Tags []string
+	// Date is set when the line names a day or a time of day, it is the
+	// midnight of the day when no time is given.
+	Date       *time.Time
+	HasTime    bool
+	Priority   string
+	Recurrence string
+}
+
+type clock struct {
+	hour, minute int
+}
+
+type parser struct {
+	now   time.Time
+	words []string
+	// lower are the words in lower case without trailing punctuation
+	lower    []string
+	day      *time.Time
+	clock    *clock
+	rule     *recurrence.Rule
+	priority string
+	tags     []string
+}
+
+var (
+	isoDate       = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	clock12       = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)$`)
+	clock24       = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+	bareHour      = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?$`)
+	dayOfMonth    = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+	yearNumber    = regexp.MustCompile(`^\d{4}$`)
+	weekdayNames  = map[string]time.Weekday{}
+	monthNames    = map[string]time.Month{}
+	priorityMarks = map[string]string{
+		"!high": model.TaskPriorityHigh, "!h": model.TaskPriorityHigh, "!1": model.TaskPriorityHigh,
+		"!!!":     model.TaskPriorityHigh,
+		"!medium": model.TaskPriorityMedium, "!med": model.TaskPriorityMedium, "!m": model.TaskPriorityMedium,
+		"!2": model.TaskPriorityMedium, "!!": model.TaskPriorityMedium,
+		"!low": model.TaskPriorityLow, "!l": model.TaskPriorityLow, "!3": model.TaskPriorityLow,
+	}
+	periods = map[string]string{
+		"day": recurrence.Daily, "days": recurrence.Daily,
+		"week": recurrence.Weekly, "weeks": recurrence.Weekly,
+		"month": recurrence.Monthly, "months": recurrence.Monthly,
+		"year": recurrence.Yearly, "years": recurrence.Yearly,
+	}
+	adverbs = map[string]string{
+		"daily":    recurrence.Daily,
+		"weekly":   recurrence.Weekly,
+		"monthly":  recurrence.Monthly,
+		"yearly":   recurrence.Yearly,
+		"annually": recurrence.Yearly,
+	}
+)
+
+func init() {
+	// weekdays only by their full names, "sun" or "sat" are too likely to be
+	// part of the text
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		weekdayNames[strings.ToLower(day.String())] = day
+	}
+	for month := time.January; month <= time.December; month++ {
+		name := strings.ToLower(month.String())
+		monthNames[name] = month
+		monthNames[name[:3]] = month
+	}
+	monthNames["sept"] = time.September
+}
+
+// Parse reads the line. It returns ErrNoText when nothing but markers is
+// left for the text of the task.
+func Parse(line string, now time.Time) (Result, error) {
+	p := parser{
+		now:   now,
+		words: strings.Fields(line),
+	}
+	p.lower = make([]string, len(p.words))
+	for i, word := range p.words {
+		p.lower[i] = strings.TrimRight(strings.ToLower(word), ",.;")
+	}
+	matchers := []func(int) int{p.tag, p.priorityMark, p.repeat, p.timeOfDay, p.date}
+	text := make([]string, 0, len(p.words))
+	for i := 0; i < len(p.words); {
+		used := 0
+		for _, match := range matchers {
+			if used = match(i); used != 0 {
+				break
+			}
+		}
+		if used == 0 {
+			text = append(text, p.words[i])
+			used = 1
+		}
+		i += used
+	}
+	if len(text) == 0 {
+		return Result{}, ErrNoText
+	}
+	return p.result(strings.Join(text, " ")), nil
+}
+
+func (p *parser) result(text string) Result {
+	result := Result{
+		Text:     text,
+		Tags:     p.tags,
+		Priority: p.priority,
+	}
+	if result.Tags == nil {
+		result.Tags = []string{}
+	}
+	if p.rule != nil {
+		result.Recurrence = p.rule.String()
+		// "every monday" starts on the first of its days, today included
+		if p.day == nil && len(p.rule.ByDay) != 0 {
+			day := p.today()
+			for !hasWeekday(p.rule.ByDay, day.Weekday()) {
+				day = day.AddDate(0, 0, 1)
+			}
+			p.day = &day
+		}
+	}
+	if p.day == nil && p.clock != nil {
+		today := p.today()
+		p.day = &today
+	}
+	if p.day != nil {
+		date := *p.day
+		if p.clock != nil {
+			date = time.Date(date.Year(), date.Month(), date.Day(), p.clock.hour, p.clock.minute, 0, 0, date.Location())
+			result.HasTime = true
+		}
+		result.Date = &date
+	}
+	return result
+}
+
+func hasWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) word(i int) string {
+	if i < 0 || i >= len(p.lower) {
+		return ""
+	}
+	return p.lower[i]
+}
+
+func (p *parser) today() time.Time {
+	return time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+}
+
+func (p *parser) tag(i int) int {
+	word := p.words[i]
+	if !strings.HasPrefix(word, "#") {
+		return 0
+	}
+	tag := strings.TrimRight(word[1:], ",.;:!?")
+	if tag == "" || strings.Contains(tag, "#") {
+		return 0
+	}
+	for _, known := range p.tags {
+		if known == tag {
+			return 1
+		}
+	}
+	p.tags = append(p.tags, tag)
+	return 1
+}
+
+func (p *parser) priorityMark(i int) int {
+	priority, ok := priorityMarks[p.word(i)]
+	if !ok || p.priority != "" {
+		return 0
+	}
+	p.priority = priority
+	return 1
+}
+
+// count reads the number of periods in "in 3 days" or "every 2 weeks".
+func count(word string) (int, bool) {
+	switch word {
+	case "a", "an", "one":
+		return 1, true
+	case "other":
+		return 2, true
+	}
+	n, err := strconv.Atoi(word)
+	if err != nil || n < 1 || n > recurrence.MaxInterval {
+		return 0, false
+	}
+	return n, true
+}
+
+func (p *parser) repeat(i int) int {
+	if p.rule != nil {
+		return 0
+	}
+	if freq, ok := adverbs[p.word(i)]; ok {
+		p.rule = &recurrence.Rule{Freq: freq, Interval: 1}
+		return 1
+	}
+	if p.word(i) != "every" {
+		return 0
+	}
+	next := p.word(i + 1)
+	if freq, ok := periods[next]; ok {
+		p.rule = &recurrence.Rule{Freq: freq, Interval: 1}
+		return 2
+	}
+	if next == "weekday" || next == "weekdays" {
+		p.rule = &recurrence.Rule{Freq: recurrence.Weekly, Interval: 1, ByDay: []time.Weekday{
+			time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+		}}
+		return 2
+	}
+	if n, ok := count(next); ok {
+		freq, ok := periods[p.word(i+2)]
+		if !ok {
+			return 0
+		}
+		p.rule = &recurrence.Rule{Freq: freq, Interval: n}
+		return 3
+	}
+	// "every monday", "every monday and thursday", "every monday, friday"
+	days := make([]time.Weekday, 0, 1)
+	used := 1
+	for {
+		day, ok := weekdayNames[p.word(i+used)]
+		if !ok {
+			break
+		}
+		days = append(days, day)
+		used++
+		if _, ok = weekdayNames[p.word(i+used+1)]; p.word(i+used) == "and" && ok {
+			used++
+		}
+	}
+	if len(days) == 0 {
+		return 0
+	}
+	p.rule = &recurrence.Rule{Freq: recurrence.Weekly, Interval: 1, ByDay: days}
+	return used
+}
+
+func (p *parser) timeOfDay(i int) int {
+	if p.clock != nil {
+		return 0
+	}
+	start := i
+	if p.word(i) == "at" {
+		i++
+	}
+	word := p.word(i)
+	var c clock
+	switch {
+	case word == "noon":
+		c = clock{hour: 12}
+	case word == "midnight":
+		c = clock{}
+	case clock12.MatchString(word):
+		m := clock12.FindStringSubmatch(word)
+		var ok bool
+		if c, ok = twelveHour(m[1], m[2], m[3]); !ok {
+			return 0
+		}
+	case bareHour.MatchString(word) && (p.word(i+1) == "am" || p.word(i+1) == "pm"):
+		m := bareHour.FindStringSubmatch(word)
+		var ok bool
+		if c, ok = twelveHour(m[1], m[2], p.word(i+1)); !ok {
+			return 0
+		}
+		i++
+	case clock24.MatchString(word):
+		m := clock24.FindStringSubmatch(word)
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		if hour > 23 || minute > 59 {
+			return 0
+		}
+		c = clock{hour: hour, minute: minute}
+	default:
+		return 0
+	}
+	p.clock = &c
+	return i - start + 1
+}
+
+func twelveHour(hourText, minuteText, half string) (clock, bool) {
+	hour, _ := strconv.Atoi(hourText)
+	minute := 0
+	if minuteText != "" {
+		minute, _ = strconv.Atoi(minuteText)
+	}
+	if hour < 1 || hour > 12 || minute > 59 {
+		return clock{}, false
+	}
+	hour %= 12
+	if half == "pm" {
+		hour += 12
+	}
+	return clock{hour: hour, minute: minute}, true
+}
+
+func (p *parser) date(i int) int {
+	if p.day != nil {
+		return 0
+	}
+	today := p.today()
+	word := p.word(i)
+	switch word {
+	case "today":
+		p.day = &today
+		return 1
+	case "tomorrow":
+		day := today.AddDate(0, 0, 1)
+		p.day = &day
+		return 1
+	case "in":
+		n, ok := count(p.word(i + 1))
+		freq, known := periods[p.word(i+2)]
+		if !ok || !known || p.word(i+1) == "other" {
+			return 0
+		}
+		day := addPeriods(today, freq, n)
+		p.day = &day
+		return 3
+	case "next":
+		switch p.word(i + 1) {
+		case "week":
+			// the monday of the next week
+			day := today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7)
+			p.day = &day
+			return 2
+		case "month":
+			day := time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location())
+			p.day = &day
+			return 2
+		}
+		if used := p.weekday(i + 1); used != 0 {
+			return used + 1
+		}
+		return 0
+	case "on":
+		if used := p.absoluteDate(i + 1); used != 0 {
+			return used + 1
+		}
+		if used := p.weekday(i + 1); used != 0 {
+			return used + 1
+		}
+		return 0
+	}
+	if used := p.weekday(i); used != 0 {
+		return used
+	}
+	return p.absoluteDate(i)
+}
+
+// weekday takes the nearest such day after today, so "friday" said on a
+// friday is a week later.
+func (p *parser) weekday(i int) int {
+	day, ok := weekdayNames[p.word(i)]
+	if !ok {
+		return 0
+	}
+	today := p.today()
+	ahead := (int(day) - int(today.Weekday()) + 7) % 7
+	if ahead == 0 {
+		ahead = 7
+	}
+	date := today.AddDate(0, 0, ahead)
+	p.day = &date
+	return 1
+}
+
+func addPeriods(day time.Time, freq string, n int) time.Time {
+	switch freq {
+	case recurrence.Weekly:
+		return day.AddDate(0, 0, 7*n)
+	case recurrence.Monthly:
+		return day.AddDate(0, n, 0)
+	case recurrence.Yearly:
+		return day.AddDate(n, 0, 0)
+	}
+	return day.AddDate(0, 0, n)
+}
+
+// absoluteDate reads 2024-03-05, "march 5", "5 march" and "mar 5th 2025".
+// Without a year the date is the next one to come, today included.
+func (p *parser) absoluteDate(i int) int {
+	loc := p.now.Location()
+	if m := isoDate.FindStringSubmatch(p.word(i)); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		dayNumber, _ := strconv.Atoi(m[3])
+		day, ok := validDate(year, time.Month(month), dayNumber, loc)
+		if !ok {
+			return 0
+		}
+		p.day = &day
+		return 1
+	}
+	var month time.Month
+	var dayText string
+	if known, ok := monthNames[p.word(i)]; ok && dayOfMonth.MatchString(p.word(i+1)) {
+		month, dayText = known, p.word(i+1)
+	} else if known, ok = monthNames[p.word(i+1)]; ok && dayOfMonth.MatchString(p.word(i)) {
+		month, dayText = known, p.word(i)
+	} else {
+		return 0
+	}
+	dayNumber, _ := strconv.Atoi(dayOfMonth.FindStringSubmatch(dayText)[1])
+	used := 2
+	year := p.now.Year()
+	yearGiven := yearNumber.MatchString(p.word(i + 2))
+	if yearGiven {
+		year, _ = strconv.Atoi(p.word(i + 2))
+		used++
+	}
+	day, ok := validDate(year, month, dayNumber, loc)
+	if !ok {
+		return 0
+	}
+	if !yearGiven && day.Before(p.today()) {
+		if day, ok = validDate(year+1, month, dayNumber, loc); !ok {
+			return 0
+		}
+	}
+	p.day = &day
+	return used
+}
+
+// validDate refuses days that time.Date would roll over, like february 30.
+func validDate(year int, month time.Month, dayNumber int, loc *time.Location) (time.Time, bool) {
+	day := time.Date(year, month, dayNumber, 0, 0, 0, 0, loc)
+	if day.Month() != month || day.Day() != dayNumber {
+		return time.Time{}, false
+	}
+	return day, true
+}
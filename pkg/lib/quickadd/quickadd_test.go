@@ -0,0 +1,116 @@
+package quickadd
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	zone := time.FixedZone("UTC+3", 3*60*60)
+	// a wednesday
+	now := time.Date(2024, time.March, 6, 10, 30, 0, 0, zone)
+	day := func(month time.Month, day, hour, minute int) *time.Time {
+		date := time.Date(2024, month, day, hour, minute, 0, 0, zone)
+		return &date
+	}
+
+	var tests = []struct {
+		name    string
+		line    string
+		want    Result
+		wantErr error
+	}{
+		{
+			name: "everything",
+			line: "Pay rent tomorrow 9am #finance !high every month",
+			want: Result{
+				Text:       "Pay rent",
+				Tags:       []string{"finance"},
+				Date:       day(time.March, 7, 9, 0),
+				HasTime:    true,
+				Priority:   "high",
+				Recurrence: "FREQ=MONTHLY",
+			},
+		}, {
+			name: "plain text",
+			line: "Buy 2 apples",
+			want: Result{Text: "Buy 2 apples", Tags: []string{}},
+		}, {
+			name: "weekday is the next one",
+			line: "Call mom on Wednesday at 18:45",
+			want: Result{Text: "Call mom", Tags: []string{}, Date: day(time.March, 13, 18, 45), HasTime: true},
+		}, {
+			name: "next week",
+			line: "Plan sprint next week",
+			want: Result{Text: "Plan sprint", Tags: []string{}, Date: day(time.March, 11, 0, 0)},
+		}, {
+			name: "in days",
+			line: "Renew passport in 3 days !!",
+			want: Result{Text: "Renew passport", Tags: []string{}, Date: day(time.March, 9, 0, 0), Priority: "medium"},
+		}, {
+			name: "month and day already passed",
+			line: "Send card feb 14th #family #family",
+			want: Result{
+				Text: "Send card",
+				Tags: []string{"family"},
+				Date: func() *time.Time {
+					date := time.Date(2025, time.February, 14, 0, 0, 0, 0, zone)
+					return &date
+				}(),
+			},
+		}, {
+			name: "iso date and time with a space",
+			line: "Dentist 2024-04-02 at 3:15 pm",
+			want: Result{Text: "Dentist", Tags: []string{}, Date: day(time.April, 2, 15, 15), HasTime: true},
+		}, {
+			name: "time only is today",
+			line: "Standup at noon daily",
+			want: Result{
+				Text:       "Standup",
+				Tags:       []string{},
+				Date:       day(time.March, 6, 12, 0),
+				HasTime:    true,
+				Recurrence: "FREQ=DAILY",
+			},
+		}, {
+			name: "repeat on weekdays starts on the first of them",
+			line: "Gym every monday and friday",
+			want: Result{
+				Text:       "Gym",
+				Tags:       []string{},
+				Date:       day(time.March, 8, 0, 0),
+				Recurrence: "FREQ=WEEKLY;BYDAY=MO,FR",
+			},
+		}, {
+			name: "every other week",
+			line: "Water plants every other week !low",
+			want: Result{Text: "Water plants", Tags: []string{}, Priority: "low", Recurrence: "FREQ=WEEKLY;INTERVAL=2"},
+		}, {
+			name: "second date stays in the text",
+			line: "Move meeting from today to tomorrow",
+			want: Result{Text: "Move meeting from to tomorrow", Tags: []string{}, Date: day(time.March, 6, 0, 0)},
+		}, {
+			name: "impossible date is text",
+			line: "Party 2024-02-30",
+			want: Result{Text: "Party 2024-02-30", Tags: []string{}},
+		}, {
+			name:    "only markers",
+			line:    "tomorrow #home !high",
+			wantErr: ErrNoText,
+		}, {
+			name:    "empty",
+			wantErr: ErrNoText,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := Parse(test.line, now)
+			assert.ErrorIs(t, err, test.wantErr)
+			assert.Equal(t, test.want, result)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package rank
+
+// List reads the keys of an ordered list for Place. The element being moved
+// is left out of it.
+type List interface {
+	// Key returns the key of the element with the id.
+	Key(id int64) (string, error)
+	// Last returns the greatest key, or "" for an empty list.
+	Last() (string, error)
+	// Following returns the smallest key greater than key, or "" when there is none.
+	Following(key string) (string, error)
+	// Preceding returns the greatest key less than key, or "" when there is none.
+	Preceding(key string) (string, error)
+}
+
+// Place returns the key that puts an element between the elements after and
+// before of the list. With only one of them the element lands right next to
+// it, with none it goes to the end.
+func Place(list List, after, before *int64) (string, error) {
+	var prev, next string
+	var err error
+	if after != nil {
+		if prev, err = list.Key(*after); err != nil {
+			return "", err
+		}
+	}
+	if before != nil {
+		if next, err = list.Key(*before); err != nil {
+			return "", err
+		}
+	}
+	switch {
+	case after == nil && before == nil:
+		prev, err = list.Last()
+	case before == nil:
+		next, err = list.Following(prev)
+	case after == nil:
+		prev, err = list.Preceding(next)
+	}
+	if err != nil {
+		return "", err
+	}
+	return Between(prev, next)
+}
@@ -0,0 +1,113 @@
+// Package rank builds fractional ordering keys: strings that sort the way the
+// items they belong to are ordered, so that putting an item between two
+// others only needs a new key for that one item.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+// MaxLength is the key length after which keys should be rebalanced with Spread.
+const MaxLength = 32
+
+var (
+	ErrOrder      = errors.New("keys are not in order")
+	ErrInvalidKey = errors.New("invalid key")
+)
+
+// Valid reports whether key can be used as a rank key. Keys never end with the
+// smallest digit, otherwise there would be nothing to put before them.
+func Valid(key string) bool {
+	if key == "" || key[len(key)-1] == digits[0] {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Between returns a key that sorts after prev and before next. An empty prev
+// means the beginning of the list and an empty next means its end.
+func Between(prev, next string) (string, error) {
+	if prev != "" && !Valid(prev) || next != "" && !Valid(next) {
+		return "", ErrInvalidKey
+	}
+	if next != "" && prev >= next {
+		return "", ErrOrder
+	}
+	return midpoint(prev, next), nil
+}
+
+func digitAt(key string, i int) int {
+	if i < len(key) {
+		return strings.IndexByte(digits, key[i])
+	}
+	return 0
+}
+
+// midpoint expects prev < next with next == "" standing for the end of the list.
+func midpoint(prev, next string) string {
+	if next != "" {
+		n := 0
+		for n < len(next) && digitAt(prev, n) == digitAt(next, n) {
+			n++
+		}
+		if n > 0 {
+			if n > len(prev) {
+				prev = ""
+			} else {
+				prev = prev[n:]
+			}
+			return next[:n] + midpoint(prev, next[n:])
+		}
+	}
+	low := digitAt(prev, 0)
+	high := base
+	if next != "" {
+		high = digitAt(next, 0)
+	}
+	if high-low > 1 {
+		return string(digits[(low+high)/2])
+	}
+	if next != "" && len(next) > 1 {
+		return next[:1]
+	}
+	rest := ""
+	if len(prev) > 1 {
+		rest = prev[1:]
+	}
+	return string(digits[low]) + midpoint(rest, "")
+}
+
+// Spread returns n keys evenly spaced over the whole key space, used to
+// rebalance a list whose keys got too long.
+func Spread(n int) []string {
+	width := 1
+	for capacity := base; capacity <= n; capacity *= base {
+		width++
+	}
+	total := 1
+	for i := 0; i < width; i++ {
+		total *= base
+	}
+	step := total / (n + 1)
+	keys := make([]string, 0, n)
+	for i := 1; i <= n; i++ {
+		value := i * step
+		key := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			key[j] = digits[value%base]
+			value /= base
+		}
+		keys = append(keys, strings.TrimRight(string(key), digits[:1]))
+	}
+	return keys
+}
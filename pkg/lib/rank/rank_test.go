@@ -0,0 +1,209 @@
+package rank
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	var tests = []struct {
+		name    string
+		prev    string
+		next    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "empty list",
+			want: "i",
+		}, {
+			name: "append",
+			prev: "i",
+			want: "r",
+		}, {
+			name: "prepend",
+			next: "i",
+			want: "9",
+		}, {
+			name: "between far keys",
+			prev: "a",
+			next: "k",
+			want: "f",
+		}, {
+			name: "between neighbour digits",
+			prev: "a",
+			next: "b",
+			want: "ai",
+		}, {
+			name: "common prefix",
+			prev: "a1",
+			next: "a3",
+			want: "a2",
+		}, {
+			name: "next is longer",
+			prev: "a",
+			next: "b5",
+			want: "b",
+		}, {
+			name: "prev is prefix of next",
+			prev: "a",
+			next: "a1",
+			want: "a0i",
+		}, {
+			name:    "wrong order",
+			prev:    "b",
+			next:    "a",
+			wantErr: ErrOrder,
+		}, {
+			name:    "same keys",
+			prev:    "b",
+			next:    "b",
+			wantErr: ErrOrder,
+		}, {
+			name:    "trailing zero",
+			prev:    "b0",
+			wantErr: ErrInvalidKey,
+		}, {
+			name:    "incorrect char",
+			prev:    "B",
+			wantErr: ErrInvalidKey,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Between(test.prev, test.next)
+			assert.ErrorIs(t, err, test.wantErr)
+			assert.Equal(t, test.want, got)
+			if err == nil {
+				assert.True(t, Valid(got))
+				assert.Less(t, test.prev, got)
+				if test.next != "" {
+					assert.Less(t, got, test.next)
+				}
+			}
+		})
+	}
+}
+
+func TestBetweenRepeated(t *testing.T) {
+	prev, next := "a", "b"
+	for i := 0; i < 200; i++ {
+		key, err := Between(prev, next)
+		assert.NoError(t, err)
+		assert.Less(t, prev, key)
+		assert.Less(t, key, next)
+		next = key
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{1, 10, 35, 36, 500} {
+		keys := Spread(n)
+		assert.Len(t, keys, n)
+		assert.True(t, sort.StringsAreSorted(keys))
+		for i, key := range keys {
+			assert.True(t, Valid(key), key)
+			if i > 0 {
+				assert.NotEqual(t, keys[i-1], key)
+			}
+		}
+	}
+}
+
+// sliceList is a list of keys in order, the element of id i has the key i.
+type sliceList []string
+
+func (l sliceList) Key(id int64) (string, error) {
+	if id < 0 || int(id) >= len(l) {
+		return "", errNoElement
+	}
+	return l[id], nil
+}
+
+func (l sliceList) Last() (string, error) {
+	if len(l) == 0 {
+		return "", nil
+	}
+	return l[len(l)-1], nil
+}
+
+func (l sliceList) Following(key string) (string, error) {
+	for _, k := range l {
+		if k > key {
+			return k, nil
+		}
+	}
+	return "", nil
+}
+
+func (l sliceList) Preceding(key string) (string, error) {
+	for i := len(l) - 1; i >= 0; i-- {
+		if l[i] < key {
+			return l[i], nil
+		}
+	}
+	return "", nil
+}
+
+var errNoElement = errors.New("no such element")
+
+func TestPlace(t *testing.T) {
+	id := func(id int64) *int64 {
+		return &id
+	}
+	list := sliceList{"a", "b", "k"}
+	var tests = []struct {
+		name    string
+		list    sliceList
+		after   *int64
+		before  *int64
+		want    string
+		wantErr error
+	}{
+		{
+			name: "empty list",
+			list: sliceList{},
+			want: "i",
+		}, {
+			name: "to the end",
+			list: list,
+			want: "s",
+		}, {
+			name:  "right after",
+			list:  list,
+			after: id(1),
+			want:  "f",
+		}, {
+			name:   "right before",
+			list:   list,
+			before: id(0),
+			want:   "5",
+		}, {
+			name:   "between",
+			list:   list,
+			after:  id(0),
+			before: id(1),
+			want:   "ai",
+		}, {
+			name:    "wrong order",
+			list:    list,
+			after:   id(2),
+			before:  id(0),
+			wantErr: ErrOrder,
+		}, {
+			name:    "unknown neighbour",
+			list:    list,
+			after:   id(3),
+			wantErr: errNoElement,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Place(test.list, test.after, test.before)
+			assert.ErrorIs(t, err, test.wantErr)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
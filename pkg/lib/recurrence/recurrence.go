@@ -0,0 +1,208 @@
+// Package recurrence reads and writes the repeat rules of tasks. Rules are a
+// subset of the iCalendar RRULE: a frequency, an interval and, for weekly
+// rules, the days of the week, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH".
+package recurrence
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+	Yearly  = "YEARLY"
+)
+
+// MaxInterval is the largest number of periods between two occurrences.
+const MaxInterval = 999
+
+var ErrRule = errors.New("incorrect recurrence rule")
+
+var dayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+type Rule struct {
+	Freq     string
+	Interval int
+	// ByDay is only used by weekly rules, empty means the day of the start.
+	ByDay []time.Weekday
+}
+
+// Parse reads a rule written by String. Parts may come in any order.
+func Parse(s string) (Rule, error) {
+	rule := Rule{Interval: 1}
+	seen := make(map[string]bool, 3)
+	for _, part := range strings.Split(s, ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found || seen[name] {
+			return Rule{}, ErrRule
+		}
+		seen[name] = true
+		switch name {
+		case "FREQ":
+			rule.Freq = value
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil {
+				return Rule{}, ErrRule
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := weekday(code)
+				if !ok {
+					return Rule{}, ErrRule
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		default:
+			return Rule{}, ErrRule
+		}
+	}
+	if !rule.Valid() {
+		return Rule{}, ErrRule
+	}
+	return rule, nil
+}
+
+func weekday(code string) (time.Weekday, bool) {
+	for day, dayCode := range dayCodes {
+		if code == dayCode {
+			return time.Weekday(day), true
+		}
+	}
+	return 0, false
+}
+
+func (r Rule) Valid() bool {
+	switch r.Freq {
+	case Daily, Monthly, Yearly:
+		if len(r.ByDay) != 0 {
+			return false
+		}
+	case Weekly:
+	default:
+		return false
+	}
+	return r.Interval >= 1 && r.Interval <= MaxInterval
+}
+
+// String writes the rule in its canonical form: the interval only when it
+// isn't 1 and the days in week order, starting with Monday.
+func (r Rule) String() string {
+	s := "FREQ=" + r.Freq
+	if r.Interval > 1 {
+		s += ";INTERVAL=" + strconv.Itoa(r.Interval)
+	}
+	if len(r.ByDay) != 0 {
+		days := append([]time.Weekday(nil), r.ByDay...)
+		// Monday first, as iCalendar weeks start on Monday by default
+		sort.Slice(days, func(i, j int) bool {
+			return (days[i]+6)%7 < (days[j]+6)%7
+		})
+		codes := make([]string, 0, len(days))
+		for i, day := range days {
+			if i > 0 && days[i-1] == day {
+				continue
+			}
+			codes = append(codes, dayCodes[day])
+		}
+		s += ";BYDAY=" + strings.Join(codes, ",")
+	}
+	return s
+}
+
+// Valid reports whether s is a rule Parse accepts.
+func Valid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+// maxOccurrences bounds the occurrences Between returns.
+const maxOccurrences = 1000
+
+// Between returns the occurrences of the rule that fall in [from, to), the
+// first occurrence is start. Occurrences keep the time of day of start, and
+// monthly and yearly rules skip the months without the day of start, like
+// the 31st or february 29.
+func (r Rule) Between(start, from, to time.Time) []time.Time {
+	occurrences := make([]time.Time, 0)
+	if !to.After(start) || !to.After(from) {
+		return occurrences
+	}
+	add := func(t time.Time) bool {
+		if !t.Before(from) && !t.Before(start) && t.Before(to) {
+			occurrences = append(occurrences, t)
+		}
+		return len(occurrences) < maxOccurrences
+	}
+	switch r.Freq {
+	case Daily:
+		for t := start.AddDate(0, 0, skipped(start, from, r.Interval)); t.Before(to); t = t.AddDate(0, 0, r.Interval) {
+			if !add(t) {
+				break
+			}
+		}
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		// weeks start on monday, the first one is the week of start
+		monday := start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+		step := 7 * r.Interval
+		for week := monday.AddDate(0, 0, skipped(monday, from, step)); week.Before(to); week = week.AddDate(0, 0, step) {
+			full := true
+			for offset := 0; offset < 7 && full; offset++ {
+				day := week.AddDate(0, 0, offset)
+				if hasDay(days, day.Weekday()) {
+					full = add(day)
+				}
+			}
+			if !full {
+				break
+			}
+		}
+	case Monthly, Yearly:
+		months := r.Interval
+		if r.Freq == Yearly {
+			months *= 12
+		}
+		for k := 0; ; k += months {
+			first := time.Date(start.Year(), start.Month()+time.Month(k), 1,
+				start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+			if !first.Before(to) {
+				break
+			}
+			t := first.AddDate(0, 0, start.Day()-1)
+			if t.Month() == first.Month() && !add(t) {
+				break
+			}
+		}
+	}
+	return occurrences
+}
+
+// skipped returns the whole steps of days between start and from, so long
+// series don't have to be walked from their start.
+func skipped(start, from time.Time, step int) int {
+	if !from.After(start) {
+		return 0
+	}
+	days := int(from.Sub(start).Hours() / 24)
+	// a day less keeps clock changes from skipping past from
+	return max(days-1, 0) / step * step
+}
+
+func hasDay(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package recurrence
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	var tests = []struct {
+		name    string
+		rule    string
+		want    Rule
+		wantErr error
+	}{
+		{
+			name: "daily",
+			rule: "FREQ=DAILY",
+			want: Rule{Freq: Daily, Interval: 1},
+		}, {
+			name: "every other week on days",
+			rule: "BYDAY=TH,MO;FREQ=WEEKLY;INTERVAL=2",
+			want: Rule{Freq: Weekly, Interval: 2, ByDay: []time.Weekday{time.Thursday, time.Monday}},
+		}, {
+			name:    "unknown frequency",
+			rule:    "FREQ=HOURLY",
+			wantErr: ErrRule,
+		}, {
+			name:    "days of a monthly rule",
+			rule:    "FREQ=MONTHLY;BYDAY=MO",
+			wantErr: ErrRule,
+		}, {
+			name:    "zero interval",
+			rule:    "FREQ=DAILY;INTERVAL=0",
+			wantErr: ErrRule,
+		}, {
+			name:    "unknown day",
+			rule:    "FREQ=WEEKLY;BYDAY=XX",
+			wantErr: ErrRule,
+		}, {
+			name:    "repeated part",
+			rule:    "FREQ=DAILY;FREQ=WEEKLY",
+			wantErr: ErrRule,
+		}, {
+			name:    "empty",
+			wantErr: ErrRule,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			rule, err := Parse(test.rule)
+			assert.ErrorIs(t, err, test.wantErr)
+			assert.Equal(t, test.want, rule)
+		})
+	}
+}
+
+func TestRule_String(t *testing.T) {
+	rule := Rule{Freq: Weekly, Interval: 2, ByDay: []time.Weekday{time.Sunday, time.Friday, time.Monday, time.Friday}}
+	assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR,SU", rule.String())
+
+	parsed, err := Parse(rule.String())
+	assert.NoError(t, err)
+	assert.Equal(t, rule.String(), parsed.String())
+
+	assert.Equal(t, "FREQ=MONTHLY", Rule{Freq: Monthly, Interval: 1}.String())
+}
+
+func TestRule_Between(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 9, 0, 0, 0, time.UTC)
+	}
+	midnight := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	var tests = []struct {
+		name  string
+		rule  Rule
+		start time.Time
+		from  time.Time
+		to    time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "daily from long ago",
+			rule:  Rule{Freq: Daily, Interval: 3},
+			start: time.Date(2020, time.January, 1, 9, 0, 0, 0, time.UTC),
+			from:  midnight(time.March, 1),
+			to:    midnight(time.March, 8),
+			want:  []time.Time{date(time.March, 1), date(time.March, 4), date(time.March, 7)},
+		}, {
+			name:  "starts inside the range",
+			rule:  Rule{Freq: Daily, Interval: 1},
+			start: date(time.March, 6),
+			from:  midnight(time.March, 4),
+			to:    midnight(time.March, 8),
+			want:  []time.Time{date(time.March, 6), date(time.March, 7)},
+		}, {
+			name:  "every other week on days",
+			rule:  Rule{Freq: Weekly, Interval: 2, ByDay: []time.Weekday{time.Monday, time.Thursday}},
+			start: date(time.March, 5),
+			from:  midnight(time.March, 1),
+			to:    midnight(time.March, 29),
+			want:  []time.Time{date(time.March, 7), date(time.March, 18), date(time.March, 21)},
+		}, {
+			name:  "weekly on the day of start",
+			rule:  Rule{Freq: Weekly, Interval: 1},
+			start: date(time.January, 3),
+			from:  midnight(time.March, 1),
+			to:    midnight(time.March, 15),
+			want:  []time.Time{date(time.March, 6), date(time.March, 13)},
+		}, {
+			name:  "monthly skips short months",
+			rule:  Rule{Freq: Monthly, Interval: 1},
+			start: date(time.January, 31),
+			from:  midnight(time.January, 1),
+			to:    midnight(time.May, 1),
+			want:  []time.Time{date(time.January, 31), date(time.March, 31)},
+		}, {
+			name:  "yearly",
+			rule:  Rule{Freq: Yearly, Interval: 1},
+			start: time.Date(2019, time.March, 6, 9, 0, 0, 0, time.UTC),
+			from:  midnight(time.March, 1),
+			to:    midnight(time.April, 1),
+			want:  []time.Time{date(time.March, 6)},
+		}, {
+			name:  "range before start",
+			rule:  Rule{Freq: Daily, Interval: 1},
+			start: date(time.April, 1),
+			from:  midnight(time.March, 1),
+			to:    midnight(time.March, 8),
+			want:  []time.Time{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, test.rule.Between(test.start, test.from, test.to))
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Package svgchart draws the charts of the stats as standalone SVG documents,
+// small enough to be embedded as images where no script can run.
+package svgchart
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// cell is the side of a heatmap day, gap the space between two days
+	cell = 10
+	gap  = 2
+	step = cell + gap
+	// the heatmap leaves room for the weekday labels on the left and the
+	// month labels on top
+	heatmapLeft = 28
+	heatmapTop  = 14
+)
+
+// heatColours go from a day without tasks to the busiest days.
+var heatColours = [...]string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+const (
+	burndownWidth  = 600
+	burndownHeight = 240
+	burndownLeft   = 40
+	burndownRight  = 16
+	burndownTop    = 16
+	burndownBottom = 28
+)
+
+type Day struct {
+	Date  time.Time
+	Count int
+}
+
+type Point struct {
+	Date      time.Time
+	Remaining int
+}
+
+// Heatmap draws days as a grid with a column per week, weeks starting on
+// Monday. Days are expected in order and without holes; the busier the day
+// compared to the busiest one, the darker its cell.
+func Heatmap(days []Day) []byte {
+	offset := 0
+	if len(days) != 0 {
+		offset = (int(days[0].Date.Weekday()) + 6) % 7
+	}
+	weeks := (offset + len(days) + 6) / 7
+	busiest := 0
+	for _, day := range days {
+		busiest = max(busiest, day.Count)
+	}
+
+	var b strings.Builder
+	header(&b, heatmapLeft+weeks*step, heatmapTop+7*step)
+	for row, label := range [...]string{"Mon", "", "Wed", "", "Fri", "", ""} {
+		if label != "" {
+			fmt.Fprintf(&b, `<text x="0" y="%d" fill="#57606a">%s</text>`+"\n", heatmapTop+row*step+cell-1, label)
+		}
+	}
+	// a month is labelled over the column of its first day, unless the
+	// label before it would overlap
+	lastLabel := -3
+	for i, day := range days {
+		if i != 0 && day.Date.Day() != 1 {
+			continue
+		}
+		column := (offset + i) / 7
+		if column-lastLabel < 3 {
+			continue
+		}
+		lastLabel = column
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#57606a">%s</text>`+"\n",
+			heatmapLeft+column*step, heatmapTop-4, day.Date.Format("Jan"))
+	}
+	for i, day := range days {
+		index := offset + i
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s: %s</title></rect>`+"\n",
+			heatmapLeft+index/7*step, heatmapTop+index%7*step, cell, cell,
+			heatColours[level(day.Count, busiest)], day.Date.Format("2006-01-02"), tasks(day.Count))
+	}
+	b.WriteString("</svg>\n")
+	return []byte(b.String())
+}
+
+// level picks the colour of a day, the busiest days get the darkest one.
+func level(count, busiest int) int {
+	if count <= 0 || busiest <= 0 {
+		return 0
+	}
+	return (count*(len(heatColours)-1) + busiest - 1) / busiest
+}
+
+func tasks(count int) string {
+	if count == 1 {
+		return "1 task"
+	}
+	return fmt.Sprintf("%d tasks", count)
+}
+
+// Burndown draws the tasks remaining day by day next to the ideal line,
+// which goes from the tasks remaining the first day down to none the last.
+func Burndown(points []Point) []byte {
+	plotWidth := float64(burndownWidth - burndownLeft - burndownRight)
+	plotHeight := float64(burndownHeight - burndownTop - burndownBottom)
+	most := 1
+	for _, point := range points {
+		most = max(most, point.Remaining)
+	}
+	x := func(i int) float64 {
+		if len(points) < 2 {
+			return burndownLeft + plotWidth/2
+		}
+		return burndownLeft + float64(i)*plotWidth/float64(len(points)-1)
+	}
+	y := func(remaining int) float64 {
+		return burndownTop + plotHeight - float64(remaining)*plotHeight/float64(most)
+	}
+
+	var b strings.Builder
+	header(&b, burndownWidth, burndownHeight)
+	bottom := burndownTop + plotHeight
+	fmt.Fprintf(&b, `<path d="M%d %d V%.1f H%d" fill="none" stroke="#8c959f"/>`+"\n",
+		burndownLeft, burndownTop, bottom, burndownWidth-burndownRight)
+	fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end" fill="#57606a">%d</text>`+"\n", burndownLeft-4, y(most)+4, most)
+	fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end" fill="#57606a">0</text>`+"\n", burndownLeft-4, bottom+4)
+	if len(points) != 0 {
+		last := len(points) - 1
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="start" fill="#57606a">%s</text>`+"\n",
+			x(0), bottom+16, points[0].Date.Format("Jan 2"))
+		if last != 0 {
+			fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="end" fill="#57606a">%s</text>`+"\n",
+				x(last), bottom+16, points[last].Date.Format("Jan 2"))
+		}
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#8c959f" stroke-dasharray="4 4"/>`+"\n",
+			x(0), y(points[0].Remaining), x(last), y(0))
+		coordinates := make([]string, 0, len(points))
+		for i, point := range points {
+			coordinates = append(coordinates, fmt.Sprintf("%.1f,%.1f", x(i), y(point.Remaining)))
+		}
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="#216e39" stroke-width="2"/>`+"\n",
+			strings.Join(coordinates, " "))
+		for i, point := range points {
+			fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="2" fill="#216e39"><title>%s: %s left</title></circle>`+"\n",
+				x(i), y(point.Remaining), point.Date.Format("2006-01-02"), tasks(point.Remaining))
+		}
+	}
+	b.WriteString("</svg>\n")
+	return []byte(b.String())
+}
+
+func header(b *strings.Builder, width, height int) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" `+
+		`font-family="sans-serif" font-size="9">`+"\n", width, height, width, height)
+}
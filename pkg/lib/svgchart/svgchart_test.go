@@ -0,0 +1,81 @@
+package svgchart
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// wellFormed checks that the chart parses as XML.
+func wellFormed(t *testing.T, chart string) {
+	decoder := xml.NewDecoder(strings.NewReader(chart))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			assert.ErrorIs(t, err, io.EOF)
+			return
+		}
+	}
+}
+
+func TestHeatmap(t *testing.T) {
+	// a wednesday
+	first := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	days := make([]Day, 0, 6)
+	for i, count := range []int{0, 1, 4, 0, 2, 8} {
+		days = append(days, Day{Date: first.AddDate(0, 0, i), Count: count})
+	}
+
+	chart := string(Heatmap(days))
+	wellFormed(t, chart)
+	assert.Equal(t, 6, strings.Count(chart, "<rect "))
+	assert.Contains(t, chart, `<rect x="28" y="38" width="10" height="10" rx="2" fill="#ebedf0"><title>2024-01-31: 0 tasks</title></rect>`)
+	assert.Contains(t, chart, `<rect x="28" y="50" width="10" height="10" rx="2" fill="#9be9a8"><title>2024-02-01: 1 task</title></rect>`)
+	assert.Contains(t, chart, `fill="#40c463"><title>2024-02-02: 4 tasks</title>`)
+	assert.Contains(t, chart, `fill="#9be9a8"><title>2024-02-04: 2 tasks</title>`)
+	// the monday after starts the next column
+	assert.Contains(t, chart, `<rect x="40" y="14" width="10" height="10" rx="2" fill="#216e39"><title>2024-02-05: 8 tasks</title></rect>`)
+	// february starts in the column of january, too close for a label
+	assert.Contains(t, chart, ">Jan</text>")
+	assert.NotContains(t, chart, ">Feb</text>")
+}
+
+func TestHeatmap_Empty(t *testing.T) {
+	chart := string(Heatmap(nil))
+	wellFormed(t, chart)
+	assert.NotContains(t, chart, "<rect ")
+}
+
+func TestLevel(t *testing.T) {
+	assert.Equal(t, 0, level(0, 10))
+	assert.Equal(t, 1, level(1, 10))
+	assert.Equal(t, 2, level(5, 10))
+	assert.Equal(t, 4, level(10, 10))
+	assert.Equal(t, 0, level(3, 0))
+}
+
+func TestBurndown(t *testing.T) {
+	first := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	points := []Point{
+		{Date: first, Remaining: 4},
+		{Date: first.AddDate(0, 0, 1), Remaining: 2},
+		{Date: first.AddDate(0, 0, 2), Remaining: 0},
+	}
+
+	chart := string(Burndown(points))
+	wellFormed(t, chart)
+	assert.Contains(t, chart, `<polyline points="40.0,16.0 312.0,114.0 584.0,212.0"`)
+	assert.Contains(t, chart, `<line x1="40.0" y1="16.0" x2="584.0" y2="212.0"`)
+	assert.Contains(t, chart, "<title>2024-03-05: 2 tasks left</title>")
+	assert.Contains(t, chart, ">Mar 4</text>")
+	assert.Contains(t, chart, ">Mar 6</text>")
+}
+
+func TestBurndown_Empty(t *testing.T) {
+	chart := string(Burndown(nil))
+	wellFormed(t, chart)
+	assert.NotContains(t, chart, "<polyline")
+}
@@ -0,0 +1,148 @@
+// Package todoist reads the JSON export of a Todoist account, the way the
+// sync API gives it:
+//
+//	{"projects": [...], "items": [...], "notes": [...]}
+//
+// IDs are read as strings, whether the export has them as strings or, like
+// the older exports, as numbers.
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var ErrExport = errors.New("incorrect todoist export")
+
+// Colors are the hex values of the named colors of the projects.
+var Colors = map[string]string{
+	"berry_red":   "#b8256f",
+	"red":         "#db4035",
+	"orange":      "#ff9933",
+	"yellow":      "#fad000",
+	"olive_green": "#afb83b",
+	"lime_green":  "#7ecc49",
+	"green":       "#299438",
+	"mint_green":  "#6accbc",
+	"teal":        "#158fad",
+	"sky_blue":    "#14aaf5",
+	"light_blue":  "#96c3eb",
+	"blue":        "#4073ff",
+	"grape":       "#884dff",
+	"violet":      "#af38eb",
+	"lavender":    "#eb96eb",
+	"magenta":     "#e05194",
+	"salmon":      "#ff8d85",
+	"charcoal":    "#808080",
+	"grey":        "#b8b8b8",
+	"taupe":       "#ccac93",
+}
+
+type Export struct {
+	Projects []Project `json:"projects"`
+	Items    []Item    `json:"items"`
+	Notes    []Note    `json:"notes"`
+}
+
+type Project struct {
+	ID         ID     `json:"id"`
+	Name       string `json:"name"`
+	Color      string `json:"color"`
+	ParentID   *ID    `json:"parent_id"`
+	IsArchived bool   `json:"is_archived"`
+	IsDeleted  bool   `json:"is_deleted"`
+	// InboxProject marks the project tasks go to when none is given.
+	InboxProject bool `json:"inbox_project"`
+}
+
+// Item is a task. Priority goes from 1, the normal one, to 4, the highest,
+// and Labels are the names of its labels.
+type Item struct {
+	ID          ID         `json:"id"`
+	ProjectID   ID         `json:"project_id"`
+	ParentID    *ID        `json:"parent_id"`
+	Content     string     `json:"content"`
+	Description string     `json:"description"`
+	Priority    int        `json:"priority"`
+	Due         *Due       `json:"due"`
+	Labels      []string   `json:"labels"`
+	Checked     bool       `json:"checked"`
+	IsDeleted   bool       `json:"is_deleted"`
+	CompletedAt *time.Time `json:"completed_at"`
+	AddedAt     *time.Time `json:"added_at"`
+}
+
+// Due is the due date of an item. Date is a day like 2016-12-01, a floating
+// time like 2016-12-01T12:00:00 or a time in UTC like 2016-12-01T12:00:00Z,
+// String is how the user typed it, "every monday" for recurring ones.
+type Due struct {
+	Date        string `json:"date"`
+	String      string `json:"string"`
+	IsRecurring bool   `json:"is_recurring"`
+	Timezone    string `json:"timezone"`
+}
+
+// Note is a comment on an item.
+type Note struct {
+	ID        ID         `json:"id"`
+	ItemID    ID         `json:"item_id"`
+	Content   string     `json:"content"`
+	PostedAt  *time.Time `json:"posted_at"`
+	IsDeleted bool       `json:"is_deleted"`
+}
+
+type ID string
+
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*id = ID(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("id %s: %w", data, ErrExport)
+	}
+	*id = ID(n.String())
+	return nil
+}
+
+// Decode reads an export. A JSON document with neither projects nor items
+// isn't one.
+func Decode(r io.Reader) (Export, error) {
+	var export Export
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return Export{}, fmt.Errorf("%w: %w", ErrExport, err)
+	}
+	if export.Projects == nil && export.Items == nil {
+		return Export{}, fmt.Errorf("%w: no projects or items", ErrExport)
+	}
+	return export, nil
+}
+
+// AllDay tells whether the due date is a day without a time.
+func (d Due) AllDay() bool {
+	return len(d.Date) == len(time.DateOnly)
+}
+
+// Time reads the due date. Days and floating times are read as wall clock
+// times of loc, times in UTC are moved to it.
+func (d Due) Time(loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{time.DateOnly, "2006-01-02T15:04:05"} {
+		if t, err := time.ParseInLocation(layout, d.Date, loc); err == nil {
+			return t, nil
+		}
+	}
+	t, err := time.Parse(time.RFC3339, d.Date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("due date %q: %w", d.Date, ErrExport)
+	}
+	return t.In(loc), nil
+}
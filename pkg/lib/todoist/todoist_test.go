@@ -0,0 +1,73 @@
+package todoist
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecode(t *testing.T) {
+	input := `{
+		"projects": [{"id": "2203306141", "name": "Inbox", "color": "grey", "inbox_project": true},
+			{"id": 2203306142, "name": "Home", "color": "berry_red", "parent_id": null, "is_archived": true}],
+		"items": [{"id": "2995104339", "project_id": "2203306142", "parent_id": "2995104338", "content": "Buy milk",
+			"priority": 4, "labels": ["shopping"], "checked": true, "completed_at": "2024-03-10T07:15:03.000000Z",
+			"due": {"date": "2024-03-12", "string": "every tuesday", "is_recurring": true}}],
+		"notes": [{"id": "2992679862", "item_id": "2995104339", "content": "Oat milk", "posted_at": "2024-03-09T10:00:00Z"}],
+		"labels": [{"id": "2156154810", "name": "shopping"}]
+	}`
+	export, err := Decode(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	parent := ID("2995104338")
+	completed := time.Date(2024, time.March, 10, 7, 15, 3, 0, time.UTC)
+	posted := time.Date(2024, time.March, 9, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, Export{
+		Projects: []Project{
+			{ID: "2203306141", Name: "Inbox", Color: "grey", InboxProject: true},
+			{ID: "2203306142", Name: "Home", Color: "berry_red", IsArchived: true},
+		},
+		Items: []Item{{
+			ID: "2995104339", ProjectID: "2203306142", ParentID: &parent, Content: "Buy milk", Priority: 4,
+			Labels: []string{"shopping"}, Checked: true, CompletedAt: &completed,
+			Due: &Due{Date: "2024-03-12", String: "every tuesday", IsRecurring: true},
+		}},
+		Notes: []Note{{ID: "2992679862", ItemID: "2995104339", Content: "Oat milk", PostedAt: &posted}},
+	}, export)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	for _, input := range []string{"", "[]", `{"projects": [{"id": true}]}`, `{"name": "board", "cards": []}`} {
+		_, err := Decode(strings.NewReader(input))
+		assert.True(t, errors.Is(err, ErrExport), input)
+	}
+}
+
+func TestDue_Time(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests = []struct {
+		date   string
+		want   time.Time
+		allDay bool
+	}{
+		{date: "2024-03-12", want: time.Date(2024, time.March, 12, 0, 0, 0, 0, moscow), allDay: true},
+		{date: "2024-03-12T18:30:00", want: time.Date(2024, time.March, 12, 18, 30, 0, 0, moscow)},
+		{date: "2024-03-12T15:30:00Z", want: time.Date(2024, time.March, 12, 18, 30, 0, 0, moscow)},
+	}
+	for _, test := range tests {
+		due := Due{Date: test.date}
+		got, err := due.Time(moscow)
+		assert.NoError(t, err)
+		assert.True(t, test.want.Equal(got), test.date)
+		assert.Equal(t, moscow, got.Location())
+		assert.Equal(t, test.allDay, due.AllDay())
+	}
+
+	_, err = Due{Date: "next week"}.Time(moscow)
+	assert.True(t, errors.Is(err, ErrExport))
+}
@@ -0,0 +1,173 @@
+// Package todotxt reads and writes the lines of todo.txt files, as described
+// on https://github.com/todotxt/todo.txt:
+//
+//	x (A) 2024-03-10 2024-03-01 Call mom +family @phone due:2024-03-12
+//
+// A line may be marked done, then come its priority, its completion date,
+// only for done tasks, and its creation date. The rest is the description,
+// where +project and @context tags and key:value extensions may appear.
+package todotxt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// priorityKey keeps the priority of done tasks, which may not start with it.
+const priorityKey = "pri"
+
+var ErrLine = errors.New("incorrect todo.txt line")
+
+type Task struct {
+	Done bool
+	// Priority goes from 'A', the highest, to 'Z' and 0 leaves it out.
+	Priority byte
+	// Completed and Created are days, Completed is only written for done tasks.
+	Completed   *time.Time
+	Created     *time.Time
+	Description string
+}
+
+type Extension struct {
+	Key   string
+	Value string
+}
+
+// Parse reads a line. The priority of a done task may also be given by a
+// pri:A extension, which is then taken out of the description.
+func Parse(line string) (Task, error) {
+	var task Task
+	rest := strings.TrimSpace(line)
+	if rest == "" {
+		return Task{}, fmt.Errorf("%w: empty line", ErrLine)
+	}
+	if after, found := strings.CutPrefix(rest, "x "); found {
+		task.Done = true
+		rest = strings.TrimLeft(after, " ")
+	}
+	if len(rest) >= 4 && rest[0] == '(' && isPriority(rest[1]) && rest[2] == ')' && rest[3] == ' ' {
+		task.Priority = rest[1]
+		rest = strings.TrimLeft(rest[4:], " ")
+	}
+	first, rest, err := date(rest)
+	if err != nil {
+		return Task{}, err
+	}
+	if first != nil {
+		second, after, err := date(rest)
+		if err != nil {
+			return Task{}, err
+		}
+		switch {
+		case task.Done && second != nil:
+			task.Completed, task.Created, rest = first, second, after
+		case task.Done:
+			task.Completed = first
+		default:
+			task.Created = first
+		}
+	}
+	if task.Done && task.Priority == 0 {
+		rest = takePriority(&task, rest)
+	}
+	task.Description = rest
+	if task.Description == "" {
+		return Task{}, fmt.Errorf("%w: no description", ErrLine)
+	}
+	return task, nil
+}
+
+// date reads a date from the start of s, nil when s doesn't start with one.
+func date(s string) (*time.Time, string, error) {
+	word, rest, _ := strings.Cut(s, " ")
+	if len(word) != len(dateLayout) || word[4] != '-' || word[7] != '-' {
+		return nil, s, nil
+	}
+	day, err := time.Parse(dateLayout, word)
+	if err != nil {
+		return nil, s, fmt.Errorf("%w: date %q", ErrLine, word)
+	}
+	return &day, strings.TrimLeft(rest, " "), nil
+}
+
+func takePriority(task *Task, description string) string {
+	words := strings.Fields(description)
+	for i, word := range words {
+		value, found := strings.CutPrefix(word, priorityKey+":")
+		if found && len(value) == 1 && isPriority(value[0]) {
+			task.Priority = value[0]
+			return strings.Join(append(words[:i:i], words[i+1:]...), " ")
+		}
+	}
+	return description
+}
+
+func isPriority(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+// String writes the line, the priority of a done task goes in a pri:A
+// extension at its end.
+func (t Task) String() string {
+	var b strings.Builder
+	if t.Done {
+		b.WriteString("x ")
+	} else if t.Priority != 0 {
+		fmt.Fprintf(&b, "(%c) ", t.Priority)
+	}
+	if t.Done && t.Completed != nil {
+		b.WriteString(t.Completed.Format(dateLayout) + " ")
+	}
+	if t.Created != nil {
+		b.WriteString(t.Created.Format(dateLayout) + " ")
+	}
+	b.WriteString(t.Description)
+	if t.Done && t.Priority != 0 {
+		fmt.Fprintf(&b, " %s:%c", priorityKey, t.Priority)
+	}
+	return b.String()
+}
+
+// Projects returns the +project tags of the description, without the plus.
+func (t Task) Projects() []string {
+	return t.tags('+')
+}
+
+// Contexts returns the @context tags of the description, without the at.
+func (t Task) Contexts() []string {
+	return t.tags('@')
+}
+
+func (t Task) tags(sign byte) []string {
+	tags := make([]string, 0)
+	for _, word := range strings.Fields(t.Description) {
+		if len(word) > 1 && word[0] == sign {
+			tags = append(tags, word[1:])
+		}
+	}
+	return tags
+}
+
+// Extensions returns the key:value extensions of the description in order.
+func (t Task) Extensions() []Extension {
+	extensions := make([]Extension, 0)
+	for _, word := range strings.Fields(t.Description) {
+		if extension, ok := ParseExtension(word); ok {
+			extensions = append(extensions, extension)
+		}
+	}
+	return extensions
+}
+
+// ParseExtension reads a word of a description as a key:value extension.
+func ParseExtension(word string) (Extension, bool) {
+	key, value, found := strings.Cut(word, ":")
+	if !found || key == "" || value == "" || strings.ContainsAny(key, "+@") {
+		return Extension{}, false
+	}
+	return Extension{Key: key, Value: value}, true
+}
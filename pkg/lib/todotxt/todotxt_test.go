@@ -0,0 +1,90 @@
+package todotxt
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func day(year int, month time.Month, d int) *time.Time {
+	t := time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestParse(t *testing.T) {
+	var tests = []struct {
+		name  string
+		input string
+		want  Task
+	}{
+		{
+			name:  "description only",
+			input: "Call mom",
+			want:  Task{Description: "Call mom"},
+		}, {
+			name:  "priority and creation date",
+			input: "(A) 2024-03-01 Call mom +family @phone due:2024-03-12",
+			want:  Task{Priority: 'A', Created: day(2024, time.March, 1), Description: "Call mom +family @phone due:2024-03-12"},
+		}, {
+			name:  "done with both dates",
+			input: "x 2024-03-10 2024-03-01 Call mom",
+			want:  Task{Done: true, Completed: day(2024, time.March, 10), Created: day(2024, time.March, 1), Description: "Call mom"},
+		}, {
+			name:  "done with the completion date only",
+			input: "x 2024-03-10 Call mom",
+			want:  Task{Done: true, Completed: day(2024, time.March, 10), Description: "Call mom"},
+		}, {
+			name:  "priority of a done task",
+			input: "x 2024-03-10 Call mom pri:B @phone",
+			want:  Task{Done: true, Priority: 'B', Completed: day(2024, time.March, 10), Description: "Call mom @phone"},
+		}, {
+			name:  "not a priority",
+			input: "(a) Call mom",
+			want:  Task{Description: "(a) Call mom"},
+		}, {
+			name:  "x without a space is a description",
+			input: "xylophone lessons",
+			want:  Task{Description: "xylophone lessons"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			task, err := Parse(test.input)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, task)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, line := range []string{"", "   ", "(A) 2024-02-30 Call mom", "x 2024-03-10"} {
+		_, err := Parse(line)
+		assert.True(t, errors.Is(err, ErrLine), line)
+	}
+}
+
+func TestTask_String(t *testing.T) {
+	lines := []string{
+		"Call mom",
+		"(A) 2024-03-01 Call mom +family @phone due:2024-03-12",
+		"x 2024-03-10 2024-03-01 Call mom",
+		"x 2024-03-10 Call mom @phone pri:B",
+	}
+	for _, line := range lines {
+		task, err := Parse(line)
+		assert.NoError(t, err)
+		assert.Equal(t, line, task.String())
+	}
+}
+
+func TestTask_Tags(t *testing.T) {
+	task := Task{Description: "Call mom +family @phone +calls due:2024-03-12 a:b:c http://x.org + @ email@example.com"}
+	assert.Equal(t, []string{"family", "calls"}, task.Projects())
+	assert.Equal(t, []string{"phone"}, task.Contexts())
+	assert.Equal(t, []Extension{
+		{Key: "due", Value: "2024-03-12"},
+		{Key: "a", Value: "b:c"},
+		{Key: "http", Value: "//x.org"},
+	}, task.Extensions())
+}
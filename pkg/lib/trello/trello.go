@@ -0,0 +1,144 @@
+// Package trello reads the JSON export of a Trello board, with its lists,
+// labels, cards, checklists and the actions that hold the comments.
+package trello
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+var ErrBoard = errors.New("incorrect trello board")
+
+const (
+	// CheckItemComplete is the state of a checked item.
+	CheckItemComplete = "complete"
+	// ActionCommentCard is the type of the actions commenting on a card.
+	ActionCommentCard = "commentCard"
+)
+
+type Board struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Desc       string      `json:"desc"`
+	Closed     bool        `json:"closed"`
+	Labels     []Label     `json:"labels"`
+	Lists      []List      `json:"lists"`
+	Cards      []Card      `json:"cards"`
+	Checklists []Checklist `json:"checklists"`
+	Actions    []Action    `json:"actions"`
+}
+
+// Label is a label of the board, Trello allows labels with a color only.
+type Label struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type List struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Closed bool   `json:"closed"`
+}
+
+type Card struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Desc        string     `json:"desc"`
+	IDList      string     `json:"idList"`
+	IDLabels    []string   `json:"idLabels"`
+	Start       *time.Time `json:"start"`
+	Due         *time.Time `json:"due"`
+	DueComplete bool       `json:"dueComplete"`
+	Closed      bool       `json:"closed"`
+}
+
+type Checklist struct {
+	ID         string      `json:"id"`
+	IDCard     string      `json:"idCard"`
+	Name       string      `json:"name"`
+	Pos        float64     `json:"pos"`
+	CheckItems []CheckItem `json:"checkItems"`
+}
+
+type CheckItem struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	State string  `json:"state"`
+	Pos   float64 `json:"pos"`
+}
+
+// Action is a change made on the board. The export has the latest actions
+// only, so it may miss the older comments.
+type Action struct {
+	ID   string     `json:"id"`
+	Type string     `json:"type"`
+	Date time.Time  `json:"date"`
+	Data ActionData `json:"data"`
+}
+
+type ActionData struct {
+	Text string `json:"text"`
+	Card *struct {
+		ID string `json:"id"`
+	} `json:"card"`
+}
+
+// Comment is a comment on a card, read from its action.
+type Comment struct {
+	ID     string
+	CardID string
+	Text   string
+	Date   time.Time
+}
+
+// Decode reads a board export. A JSON document without the id and the cards
+// of a board isn't one.
+func Decode(r io.Reader) (Board, error) {
+	var board Board
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return Board{}, fmt.Errorf("%w: %w", ErrBoard, err)
+	}
+	if board.ID == "" || board.Cards == nil {
+		return Board{}, fmt.Errorf("%w: no board id or cards", ErrBoard)
+	}
+	return board, nil
+}
+
+// Comments returns the comments on the cards, oldest first.
+func (b Board) Comments() []Comment {
+	comments := make([]Comment, 0)
+	for _, action := range b.Actions {
+		if action.Type != ActionCommentCard || action.Data.Card == nil {
+			continue
+		}
+		comments = append(comments, Comment{
+			ID:     action.ID,
+			CardID: action.Data.Card.ID,
+			Text:   action.Data.Text,
+			Date:   action.Date,
+		})
+	}
+	// the actions come newest first
+	for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+		comments[i], comments[j] = comments[j], comments[i]
+	}
+	return comments
+}
+
+// Created returns the time the object with the id was created at, the first
+// eight hex digits of the ids of Trello are its unix time.
+func Created(id string) (time.Time, bool) {
+	if len(id) < 8 {
+		return time.Time{}, false
+	}
+	seconds, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0).UTC(), true
+}
@@ -0,0 +1,64 @@
+package trello
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecode(t *testing.T) {
+	input := `{
+		"id": "65f0a1b2c3d4e5f6a7b8c9d0", "name": "Launch", "desc": "Spring launch", "closed": false,
+		"labels": [{"id": "l1", "name": "urgent", "color": "red"}, {"id": "l2", "name": "", "color": "green"}],
+		"lists": [{"id": "list1", "name": "Doing", "closed": false}],
+		"cards": [{"id": "65f0a1b2c3d4e5f6a7b8c9d1", "name": "Write post", "desc": "About the launch",
+			"idList": "list1", "idLabels": ["l1"], "due": "2024-03-12T15:30:00.000Z", "dueComplete": true}],
+		"checklists": [{"id": "ch1", "idCard": "65f0a1b2c3d4e5f6a7b8c9d1", "name": "Steps", "pos": 16384,
+			"checkItems": [{"id": "i1", "name": "Draft", "state": "complete", "pos": 16384}]}],
+		"actions": [
+			{"id": "a2", "type": "commentCard", "date": "2024-03-10T10:00:00.000Z",
+				"data": {"text": "Done", "card": {"id": "65f0a1b2c3d4e5f6a7b8c9d1"}}},
+			{"id": "a1", "type": "updateCard", "date": "2024-03-09T10:00:00.000Z", "data": {}},
+			{"id": "a0", "type": "commentCard", "date": "2024-03-08T10:00:00.000Z",
+				"data": {"text": "Started", "card": {"id": "65f0a1b2c3d4e5f6a7b8c9d1"}}}]
+	}`
+	board, err := Decode(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	due := time.Date(2024, time.March, 12, 15, 30, 0, 0, time.UTC)
+	assert.Equal(t, "Launch", board.Name)
+	assert.Equal(t, []Label{{ID: "l1", Name: "urgent", Color: "red"}, {ID: "l2", Color: "green"}}, board.Labels)
+	assert.Equal(t, []List{{ID: "list1", Name: "Doing"}}, board.Lists)
+	assert.Equal(t, []Card{{
+		ID: "65f0a1b2c3d4e5f6a7b8c9d1", Name: "Write post", Desc: "About the launch", IDList: "list1",
+		IDLabels: []string{"l1"}, Due: &due, DueComplete: true,
+	}}, board.Cards)
+	assert.Equal(t, []Checklist{{
+		ID: "ch1", IDCard: "65f0a1b2c3d4e5f6a7b8c9d1", Name: "Steps", Pos: 16384,
+		CheckItems: []CheckItem{{ID: "i1", Name: "Draft", State: CheckItemComplete, Pos: 16384}},
+	}}, board.Checklists)
+	assert.Equal(t, []Comment{
+		{ID: "a0", CardID: "65f0a1b2c3d4e5f6a7b8c9d1", Text: "Started", Date: time.Date(2024, time.March, 8, 10, 0, 0, 0, time.UTC)},
+		{ID: "a2", CardID: "65f0a1b2c3d4e5f6a7b8c9d1", Text: "Done", Date: time.Date(2024, time.March, 10, 10, 0, 0, 0, time.UTC)},
+	}, board.Comments())
+}
+
+func TestDecode_Errors(t *testing.T) {
+	for _, input := range []string{"", "[]", `{"id": "b1"}`, `{"projects": [], "items": []}`} {
+		_, err := Decode(strings.NewReader(input))
+		assert.True(t, errors.Is(err, ErrBoard), input)
+	}
+}
+
+func TestCreated(t *testing.T) {
+	created, ok := Created("65f0a1b2c3d4e5f6a7b8c9d1")
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(0x65f0a1b2, 0).UTC(), created)
+
+	for _, id := range []string{"", "65f0", "zzzzzzzz0000"} {
+		_, ok = Created(id)
+		assert.False(t, ok, id)
+	}
+}
@@ -0,0 +1,10 @@
+package verification
+
+import "strings"
+
+func AttachmentName(name string) bool {
+	if name == "" || name == "." || len(name) > 255 {
+		return false
+	}
+	return !strings.ContainsAny(name, "/\\\x00")
+}
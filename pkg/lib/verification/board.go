@@ -0,0 +1,22 @@
+package verification
+
+import "restAPI/internal/model"
+
+func Board(board model.Board) bool {
+	if board.Name == "" || len(board.Name) > 255 {
+		return false
+	}
+	for _, column := range board.Columns {
+		if !BoardColumn(column) {
+			return false
+		}
+	}
+	return true
+}
+
+func BoardColumn(column model.BoardColumn) bool {
+	if column.Name == "" || len(column.Name) > 255 {
+		return false
+	}
+	return column.Status == nil || TaskStatus(*column.Status)
+}
@@ -0,0 +1,7 @@
+package verification
+
+import "restAPI/internal/model"
+
+func Comment(comment model.Comment) bool {
+	return comment.Text != "" && len(comment.Text) <= 10000
+}
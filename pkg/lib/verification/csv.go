@@ -0,0 +1,46 @@
+package verification
+
+import (
+	"restAPI/internal/model"
+	"slices"
+	"strings"
+)
+
+// maxTagDelimiter bounds the length of the tag delimiter of CSV files.
+const maxTagDelimiter = 8
+
+// CSVColumns accepts a choice of task CSV columns, each given once.
+func CSVColumns(columns []string) bool {
+	if len(columns) == 0 {
+		return false
+	}
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		if seen[column] || !slices.Contains(model.CSVColumns, column) {
+			return false
+		}
+		seen[column] = true
+	}
+	return true
+}
+
+// CSVMapping accepts a mapping of CSV headers to the task columns, each
+// column given once.
+func CSVMapping(mapping map[string]string) bool {
+	columns := make([]string, 0, len(mapping))
+	for header, column := range mapping {
+		if strings.TrimSpace(header) == "" {
+			return false
+		}
+		columns = append(columns, column)
+	}
+	return len(columns) == 0 || CSVColumns(columns)
+}
+
+// TagDelimiter accepts a short delimiter that keeps the tags on one line.
+func TagDelimiter(delimiter string) bool {
+	if delimiter == "" || len(delimiter) > maxTagDelimiter {
+		return false
+	}
+	return !strings.ContainsAny(delimiter, "\r\n")
+}
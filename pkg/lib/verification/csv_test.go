@@ -0,0 +1,80 @@
+package verification
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCSVColumns(t *testing.T) {
+	var tests = []struct {
+		name  string
+		input []string
+		want  bool
+	}{
+		{
+			name:  "some columns",
+			input: []string{"text", "tags", "due"},
+			want:  true,
+		}, {
+			name:  "no columns",
+			input: []string{},
+			want:  false,
+		}, {
+			name:  "unknown column",
+			input: []string{"text", "title"},
+			want:  false,
+		}, {
+			name:  "column given twice",
+			input: []string{"text", "tags", "text"},
+			want:  false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, CSVColumns(test.input))
+		})
+	}
+}
+
+func TestCSVMapping(t *testing.T) {
+	var tests = []struct {
+		name  string
+		input map[string]string
+		want  bool
+	}{
+		{
+			name:  "no mapping",
+			input: map[string]string{},
+			want:  true,
+		}, {
+			name:  "some headers",
+			input: map[string]string{"Title": "text", "Labels": "tags"},
+			want:  true,
+		}, {
+			name:  "blank header",
+			input: map[string]string{" ": "text"},
+			want:  false,
+		}, {
+			name:  "unknown column",
+			input: map[string]string{"Title": "name"},
+			want:  false,
+		}, {
+			name:  "column given twice",
+			input: map[string]string{"Title": "text", "Name": "text"},
+			want:  false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, CSVMapping(test.input))
+		})
+	}
+}
+
+func TestTagDelimiter(t *testing.T) {
+	assert.True(t, TagDelimiter(";"))
+	assert.True(t, TagDelimiter(", "))
+	assert.False(t, TagDelimiter(""))
+	assert.False(t, TagDelimiter("\n"))
+	assert.False(t, TagDelimiter("---------"))
+}
@@ -0,0 +1,8 @@
+package verification
+
+import "restAPI/internal/model"
+
+// ImportSource accepts the apps whose export files the import jobs read.
+func ImportSource(source string) bool {
+	return source == model.ImportSourceTodoist || source == model.ImportSourceTrello
+}
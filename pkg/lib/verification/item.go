@@ -0,0 +1,7 @@
+package verification
+
+import "restAPI/internal/model"
+
+func TaskItem(item model.TaskItem) bool {
+	return item.Text != "" && len(item.Text) <= 255
+}
@@ -12,3 +12,8 @@ func monthVer(month string) bool {
 	}
 	return false
 }
+
+// Month checks a month of a year the same way Date checks a day.
+func Month(month, year string) bool {
+	return monthVer(month) && yearVer(year)
+}
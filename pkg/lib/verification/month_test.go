@@ -52,3 +52,35 @@ func TestMonth(t *testing.T) {
 		})
 	}
 }
+
+func TestMonthOfYear(t *testing.T) {
+
+	var tests = []struct {
+		name  string
+		month string
+		year  string
+		want  bool
+	}{
+		{
+			"correct month",
+			"2",
+			"2024",
+			true,
+		}, {
+			"incorrect month",
+			"13",
+			"2024",
+			false,
+		}, {
+			"incorrect year",
+			"12",
+			"1999",
+			false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, Month(test.month, test.year))
+		})
+	}
+}
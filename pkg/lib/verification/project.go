@@ -0,0 +1,24 @@
+package verification
+
+import "restAPI/internal/model"
+
+func Project(project model.Project) bool {
+	if project.Name == "" || len(project.Name) > 255 {
+		return false
+	}
+	return project.Colour == "" || colourVer(project.Colour)
+}
+
+// colourVer accepts colours in the #rrggbb form.
+func colourVer(colour string) bool {
+	if len(colour) != 7 || colour[0] != '#' {
+		return false
+	}
+	for i := 1; i < len(colour); i++ {
+		c := colour[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
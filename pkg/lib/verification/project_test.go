@@ -0,0 +1,51 @@
+package verification
+
+import (
+	"github.com/stretchr/testify/assert"
+	"restAPI/internal/model"
+	"strings"
+	"testing"
+)
+
+func TestProject(t *testing.T) {
+	var tests = []struct {
+		name  string
+		input model.Project
+		want  bool
+	}{
+		{
+			name:  "name only",
+			input: model.Project{Name: "home"},
+			want:  true,
+		}, {
+			name:  "correct colour",
+			input: model.Project{Name: "home", Colour: "#1a2B3c"},
+			want:  true,
+		}, {
+			name:  "empty name",
+			input: model.Project{Colour: "#ffffff"},
+			want:  false,
+		}, {
+			name:  "too long name",
+			input: model.Project{Name: strings.Repeat("a", 256)},
+			want:  false,
+		}, {
+			name:  "colour without hash",
+			input: model.Project{Name: "home", Colour: "ffffff0"},
+			want:  false,
+		}, {
+			name:  "short colour",
+			input: model.Project{Name: "home", Colour: "#fff"},
+			want:  false,
+		}, {
+			name:  "incorrect char",
+			input: model.Project{Name: "home", Colour: "#ffffgg"},
+			want:  false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, Project(test.input))
+		})
+	}
+}
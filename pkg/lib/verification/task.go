@@ -2,11 +2,74 @@ package verification
 
 import (
 	"restAPI/internal/model"
+	"restAPI/pkg/lib/recurrence"
+)
+
+const (
+	// maxEstimate is the largest estimate in seconds a task may have, a thousand hours.
+	maxEstimate = 1000 * 60 * 60
+	// maxSubtaskDepth and maxSubtasks bound the subtasks created with a task.
+	maxSubtaskDepth = 3
+	maxSubtasks     = 100
+	// maxMovedTasks bounds the tasks one bulk move may take.
+	maxMovedTasks = 500
 )
 
 func Task(task model.Task) bool {
+	return taskTree(task, 0)
+}
+
+func taskTree(task model.Task, depth int) bool {
 	if task.Text == "" {
 		return false
 	}
+	if task.Status != "" && !TaskStatus(task.Status) {
+		return false
+	}
+	if task.Estimate != nil && (*task.Estimate <= 0 || *task.Estimate > maxEstimate) {
+		return false
+	}
+	if task.Priority != nil && !TaskPriority(*task.Priority) {
+		return false
+	}
+	if task.Recurrence != nil && !recurrence.Valid(*task.Recurrence) {
+		return false
+	}
+	if len(task.Subtasks) != 0 && (depth == maxSubtaskDepth || len(task.Subtasks) > maxSubtasks) {
+		return false
+	}
+	for _, subtask := range task.Subtasks {
+		if !taskTree(subtask, depth+1) {
+			return false
+		}
+	}
 	return true
 }
+
+func TaskPriority(priority string) bool {
+	switch priority {
+	case model.TaskPriorityLow, model.TaskPriorityMedium, model.TaskPriorityHigh:
+		return true
+	}
+	return false
+}
+
+func TaskStatus(status string) bool {
+	switch status {
+	case model.TaskStatusTodo, model.TaskStatusInProgress, model.TaskStatusDone:
+		return true
+	}
+	return false
+}
+
+// TaskMove accepts a move of some tasks to exactly one project choice, to a
+// day or to both.
+func TaskMove(move model.TaskMove) bool {
+	if len(move.TaskIDs) == 0 || len(move.TaskIDs) > maxMovedTasks {
+		return false
+	}
+	if move.ProjectID != nil && move.WithoutProject {
+		return false
+	}
+	return move.ProjectID != nil || move.WithoutProject || move.Date != nil
+}
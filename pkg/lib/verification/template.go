@@ -0,0 +1,52 @@
+package verification
+
+import "restAPI/internal/model"
+
+const (
+	maxTemplateTasks = 100
+	// maxDueOffset is how many days from the start a template task may be due, ten years.
+	maxDueOffset = 3650
+)
+
+func Template(template model.Template) bool {
+	if template.Name == "" || len(template.Name) > 255 {
+		return false
+	}
+	if len(template.Tasks) == 0 || len(template.Tasks) > maxTemplateTasks {
+		return false
+	}
+	return templateTasks(template.Tasks, 0)
+}
+
+func templateTasks(tasks []model.TemplateTask, depth int) bool {
+	if len(tasks) != 0 && depth > maxSubtaskDepth || len(tasks) > maxTemplateTasks {
+		return false
+	}
+	for _, task := range tasks {
+		if task.Text == "" {
+			return false
+		}
+		for _, tag := range task.Tags {
+			if tag == "" || len(tag) > 255 {
+				return false
+			}
+		}
+		if task.DueOffset != nil && (*task.DueOffset < -maxDueOffset || *task.DueOffset > maxDueOffset) {
+			return false
+		}
+		if !templateTasks(task.Subtasks, depth+1) {
+			return false
+		}
+	}
+	return true
+}
+
+// TemplateVariables accepts the values a template is filled with.
+func TemplateVariables(variables map[string]string) bool {
+	for _, value := range variables {
+		if value == "" || len(value) > 255 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,17 @@
+package verification
+
+import (
+	"restAPI/internal/model"
+	"time"
+)
+
+// maxTimeEntry is the longest span a manual time entry may cover.
+const maxTimeEntry = 24 * time.Hour
+
+func TimeEntry(entry model.TimeEntry) bool {
+	if entry.StartedAt.IsZero() || entry.StoppedAt == nil {
+		return false
+	}
+	duration := entry.StoppedAt.Sub(entry.StartedAt)
+	return duration >= 0 && duration <= maxTimeEntry
+}
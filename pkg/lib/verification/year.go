@@ -14,3 +14,8 @@ func yearVer(year string) bool {
 	}
 	return true
 }
+
+// Year checks a year the same way Date checks the year of a day.
+func Year(year string) bool {
+	return yearVer(year)
+}
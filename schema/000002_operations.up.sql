@@ -0,0 +1,10 @@
+CREATE TABLE task_operations
+(
+    id serial primary key,
+    owner_id int references users (id) on delete cascade not null,
+    kind varchar(32) not null,
+    payload jsonb not null,
+    created_at timestamp not null default now()
+);
+
+CREATE INDEX task_operations_owner_id_idx ON task_operations (owner_id, id);
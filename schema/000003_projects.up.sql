@@ -0,0 +1,13 @@
+CREATE TABLE projects
+(
+    id serial primary key,
+    owner_id int references users (id) on delete cascade not null,
+    name varchar(255) not null,
+    description text not null default '',
+    colour varchar(7) not null default '',
+    archived boolean not null default false
+);
+
+ALTER TABLE tasks ADD COLUMN project_id int references projects (id) on delete set null;
+
+CREATE INDEX tasks_project_id_idx ON tasks (project_id);
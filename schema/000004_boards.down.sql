@@ -0,0 +1,8 @@
+DROP TABLE board_cards;
+
+DROP TABLE board_columns;
+
+DROP TABLE boards;
+
+ALTER TABLE tasks DROP COLUMN completed_at;
+ALTER TABLE tasks DROP COLUMN status;
@@ -0,0 +1,29 @@
+ALTER TABLE tasks ADD COLUMN status varchar(16) not null default 'todo';
+ALTER TABLE tasks ADD COLUMN completed_at timestamp;
+
+CREATE TABLE boards
+(
+    id serial primary key,
+    owner_id int references users (id) on delete cascade not null,
+    name varchar(255) not null
+);
+
+CREATE TABLE board_columns
+(
+    id serial primary key,
+    board_id int references boards (id) on delete cascade not null,
+    name varchar(255) not null,
+    status varchar(16),
+    rank varchar(255) not null
+);
+
+CREATE TABLE board_cards
+(
+    board_id int references boards (id) on delete cascade not null,
+    column_id int references board_columns (id) on delete cascade not null,
+    task_id int references tasks (id) on delete cascade not null,
+    rank varchar(255) not null,
+    primary key (board_id, task_id)
+);
+
+CREATE INDEX board_cards_column_id_idx ON board_cards (column_id, rank);
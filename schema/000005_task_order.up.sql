@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN sort_key varchar(255);
+
+CREATE INDEX tasks_owner_id_sort_key_idx ON tasks (owner_id, sort_key);
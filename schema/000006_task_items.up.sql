@@ -0,0 +1,10 @@
+CREATE TABLE task_items
+(
+    id serial primary key,
+    task_id int references tasks (id) on delete cascade not null,
+    text varchar(255) not null,
+    done boolean not null default false,
+    rank varchar(255) not null
+);
+
+CREATE INDEX task_items_task_id_idx ON task_items (task_id, rank);
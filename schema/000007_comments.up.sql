@@ -0,0 +1,12 @@
+CREATE TABLE comments
+(
+    id serial primary key,
+    task_id int references tasks (id) on delete cascade not null,
+    author_id int references users (id) on delete cascade not null,
+    parent_id int references comments (id) on delete cascade,
+    text text not null,
+    created_at timestamp not null default (now() AT TIME ZONE 'UTC'),
+    edited_at timestamp
+);
+
+CREATE INDEX comments_task_id_idx ON comments (task_id, id);
@@ -0,0 +1,14 @@
+CREATE TABLE attachments
+(
+    id serial primary key,
+    task_id int references tasks (id) on delete cascade not null,
+    owner_id int references users (id) on delete cascade not null,
+    name varchar(255) not null,
+    content_type varchar(255) not null,
+    size bigint not null,
+    blob_key varchar(255) not null unique,
+    created_at timestamp not null default (now() AT TIME ZONE 'UTC')
+);
+
+CREATE INDEX attachments_task_id_idx ON attachments (task_id);
+CREATE INDEX attachments_owner_id_idx ON attachments (owner_id);
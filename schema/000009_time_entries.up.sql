@@ -0,0 +1,13 @@
+CREATE TABLE time_entries
+(
+    id serial primary key,
+    task_id int references tasks (id) on delete cascade not null,
+    owner_id int references users (id) on delete cascade not null,
+    started_at timestamp not null,
+    stopped_at timestamp,
+    check (stopped_at IS NULL OR stopped_at >= started_at)
+);
+
+CREATE INDEX time_entries_owner_id_idx ON time_entries (owner_id, started_at);
+-- a user has at most one running timer
+CREATE UNIQUE INDEX time_entries_running_idx ON time_entries (owner_id) WHERE stopped_at IS NULL;
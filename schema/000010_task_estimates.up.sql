@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN estimate int CHECK (estimate > 0);
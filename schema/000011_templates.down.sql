@@ -0,0 +1,4 @@
+DROP TABLE templates;
+
+ALTER TABLE tasks DROP COLUMN due;
+ALTER TABLE tasks DROP COLUMN parent_id;
@@ -0,0 +1,14 @@
+ALTER TABLE tasks ADD COLUMN parent_id int references tasks (id) on delete cascade;
+ALTER TABLE tasks ADD COLUMN due timestamp;
+
+CREATE INDEX tasks_parent_id_idx ON tasks (parent_id);
+
+CREATE TABLE templates
+(
+    id serial primary key,
+    owner_id int references users (id) on delete cascade not null,
+    name varchar(255) not null,
+    tasks jsonb not null
+);
+
+CREATE INDEX templates_owner_id_idx ON templates (owner_id);
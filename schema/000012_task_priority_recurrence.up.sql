@@ -0,0 +1,2 @@
+ALTER TABLE tasks ADD COLUMN priority varchar(10) CHECK (priority IN ('low', 'medium', 'high'));
+ALTER TABLE tasks ADD COLUMN recurrence varchar(255);
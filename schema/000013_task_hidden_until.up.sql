@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN hidden_until timestamp;
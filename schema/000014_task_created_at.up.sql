@@ -0,0 +1,7 @@
+ALTER TABLE tasks ADD COLUMN created_at timestamp not null default (now() AT TIME ZONE 'UTC');
+
+-- the tasks older than the column are dated by their own date, and can't have
+-- been created after they were done
+UPDATE tasks SET created_at = LEAST(date, COALESCE(completed_at, date));
+
+CREATE INDEX tasks_owner_id_created_at_idx ON tasks (owner_id, created_at);
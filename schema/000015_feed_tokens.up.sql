@@ -0,0 +1,6 @@
+CREATE TABLE feed_tokens
+(
+    user_id int primary key references users (id) on delete cascade,
+    token_hash varchar(64) not null unique,
+    created_at timestamp not null default now()
+);
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN ical_uid varchar(255);
+
+CREATE UNIQUE INDEX tasks_owner_id_ical_uid_idx ON tasks (owner_id, ical_uid);
@@ -0,0 +1,33 @@
+CREATE TABLE import_jobs
+(
+    id serial primary key,
+    owner_id int references users (id) on delete cascade not null,
+    source varchar(16) not null,
+    status varchar(16) not null default 'running',
+    done int not null default 0,
+    total int not null default 0,
+    report jsonb,
+    error text,
+    created_at timestamp not null default (now() AT TIME ZONE 'UTC'),
+    finished_at timestamp
+);
+
+-- a user runs one import at a time, so that two uploads of a file can't
+-- both create its records
+CREATE UNIQUE INDEX import_jobs_running_idx ON import_jobs (owner_id) WHERE status = 'running';
+
+-- import_refs remember what the records of a source were imported as, so that
+-- uploading the file again skips them. Deleting the record drops its ref.
+CREATE TABLE import_refs
+(
+    owner_id int references users (id) on delete cascade not null,
+    source varchar(16) not null,
+    kind varchar(16) not null,
+    external_id varchar(255) not null,
+    project_id int references projects (id) on delete cascade,
+    task_id int references tasks (id) on delete cascade,
+    item_id int references task_items (id) on delete cascade,
+    comment_id int references comments (id) on delete cascade,
+    primary key (owner_id, source, kind, external_id),
+    check (num_nonnulls(project_id, task_id, item_id, comment_id) = 1)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE account_exports
+(
+    id serial primary key,
+    owner_id int references users (id) on delete cascade not null,
+    status varchar(16) not null default 'running',
+    blob_key varchar(255) unique,
+    size bigint not null default 0,
+    error text,
+    created_at timestamp not null default (now() AT TIME ZONE 'UTC'),
+    finished_at timestamp
+);
+
+CREATE UNIQUE INDEX account_exports_running_idx ON account_exports (owner_id) WHERE status = 'running';
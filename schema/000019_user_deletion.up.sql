@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN delete_after timestamp;
+
+CREATE INDEX users_delete_after_idx ON users (delete_after) WHERE delete_after IS NOT NULL;